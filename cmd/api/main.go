@@ -1,7 +1,18 @@
 package main
 
-import "github.com/canyouhearthemusic/project-management/internal/app"
+import (
+	"os"
+
+	"github.com/canyouhearthemusic/project-management/internal/app"
+)
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "set-password" {
+		if !app.SetPassword(os.Args[2:]) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	app.Run()
 }
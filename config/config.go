@@ -3,14 +3,21 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 )
 
 type Configs struct {
-	APP app
-	DB  DB
+	APP      app
+	DB       DB
+	Reminder Reminder
+	Health   Health
+	Tenant   Tenant
+	Auth     Auth
+	OIDC     OIDC
+	Import   Import
 }
 
 type DB struct {
@@ -21,6 +28,57 @@ type DB struct {
 	Name     string
 }
 
+// Reminder configures the due-date reminder worker.
+type Reminder struct {
+	Enabled           bool          `default:"true"`
+	Interval          time.Duration `default:"1h"`
+	LeadDays          []int         `split_words:"true" default:"3,1"`
+	EscalateAfterDays int           `split_words:"true" default:"1"`
+}
+
+// Health configures the worker that reassesses project health.
+type Health struct {
+	Enabled  bool          `default:"true"`
+	Interval time.Duration `default:"1h"`
+}
+
+// Tenant configures how requests are assigned to an organization.
+type Tenant struct {
+	// BaseDomain enables resolving the organization from the subdomain of
+	// the request host, e.g. acme.example.com for example.com.
+	BaseDomain string `split_words:"true"`
+}
+
+// Auth configures signing in and the tokens requests authenticate with.
+type Auth struct {
+	Secret     string        `required:"true"`
+	AccessTTL  time.Duration `split_words:"true" default:"15m"`
+	RefreshTTL time.Duration `split_words:"true" default:"720h"`
+	// Required rejects requests without an access token. Turned off, such
+	// requests go on without an actor and only reach what is open to anyone.
+	Required bool `default:"true"`
+}
+
+// OIDC configures signing in through an OpenID Connect provider, enabled
+// when Issuer is set. RedirectURL is the /api/v1/oidc/callback route.
+type OIDC struct {
+	Issuer       string
+	ClientID     string   `split_words:"true"`
+	ClientSecret string   `split_words:"true"`
+	RedirectURL  string   `split_words:"true"`
+	Scopes       []string `default:"openid,email,profile"`
+	GroupsClaim  string   `split_words:"true" default:"groups"`
+	// Roles maps provider groups to user roles, e.g. "leads:manager,it:admin".
+	Roles       map[string]string
+	DefaultRole string `split_words:"true" default:"developer"`
+}
+
+// Import bounds the archives POST /projects/import decompresses.
+type Import struct {
+	MaxEntries int   `split_words:"true" default:"16"`
+	MaxSize    int64 `split_words:"true" default:"67108864"`
+}
+
 type app struct {
 	Port string
 	Path string
@@ -42,6 +100,30 @@ func New() (cfg Configs, err error) {
 		cfg.DB.Port = os.Getenv("DB_PORT")
 		cfg.DB.Name = os.Getenv("DB_NAME")
 
+		if err = envconfig.Process("REMINDER", &cfg.Reminder); err != nil {
+			return
+		}
+
+		if err = envconfig.Process("HEALTH", &cfg.Health); err != nil {
+			return
+		}
+
+		if err = envconfig.Process("TENANT", &cfg.Tenant); err != nil {
+			return
+		}
+
+		if err = envconfig.Process("AUTH", &cfg.Auth); err != nil {
+			return
+		}
+
+		if err = envconfig.Process("OIDC", &cfg.OIDC); err != nil {
+			return
+		}
+
+		if err = envconfig.Process("IMPORT", &cfg.Import); err != nil {
+			return
+		}
+
 		return cfg, nil
 	}
 
@@ -53,5 +135,29 @@ func New() (cfg Configs, err error) {
 		return
 	}
 
+	if err = envconfig.Process("REMINDER", &cfg.Reminder); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("HEALTH", &cfg.Health); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("TENANT", &cfg.Tenant); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("AUTH", &cfg.Auth); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("OIDC", &cfg.OIDC); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("IMPORT", &cfg.Import); err != nil {
+		return
+	}
+
 	return
 }
@@ -8,11 +8,16 @@ import (
 	"time"
 
 	"github.com/canyouhearthemusic/project-management/config"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/notification"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
 	"github.com/canyouhearthemusic/project-management/internal/handler"
 	"github.com/canyouhearthemusic/project-management/internal/repository"
 	"github.com/canyouhearthemusic/project-management/internal/repository/postgres"
 	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/oidc"
 	"github.com/canyouhearthemusic/project-management/pkg/server"
+	"github.com/canyouhearthemusic/project-management/pkg/worker"
 	"github.com/sirupsen/logrus"
 )
 
@@ -38,17 +43,57 @@ func Run() {
 		return
 	}
 
+	var sso *auth.SSO
+	if configs.OIDC.Issuer != "" {
+		roles := []string{configs.OIDC.DefaultRole}
+		for _, role := range configs.OIDC.Roles {
+			roles = append(roles, role)
+		}
+
+		for _, role := range roles {
+			if !user.IsValidRole(role) {
+				logger.Errorf("invalid single sign-on role %q\n", role)
+				return
+			}
+		}
+
+		sso = &auth.SSO{
+			Client:      oidc.New(configs.OIDC.Issuer, configs.OIDC.ClientID, configs.OIDC.ClientSecret, configs.OIDC.RedirectURL, configs.OIDC.Scopes),
+			GroupsClaim: configs.OIDC.GroupsClaim,
+			Roles:       configs.OIDC.Roles,
+			DefaultRole: configs.OIDC.DefaultRole,
+		}
+	}
+
 	managementService := management.New(
 		management.WithProjectRepository(repositories.Project),
 		management.WithTaskRepository(repositories.Task),
 		management.WithUserRepository(repositories.User),
+		management.WithTemplateRepository(repositories.Template),
+		management.WithBoardRepository(repositories.Board),
+		management.WithSprintRepository(repositories.Sprint),
+		management.WithMilestoneRepository(repositories.Milestone),
+		management.WithEpicRepository(repositories.Epic),
+		management.WithNotificationRepository(repositories.Notification),
+		management.WithMemberRepository(repositories.Member),
+		management.WithProjectTemplateRepository(repositories.ProjectTemplate),
+		management.WithBudgetRepository(repositories.Budget),
+		management.WithOrganizationRepository(repositories.Organization),
+		management.WithTeamRepository(repositories.Team),
+		management.WithCalendarRepository(repositories.Calendar),
+		management.WithAuthRepository(repositories.Auth),
+		management.WithAccessTokenRepository(repositories.AccessToken),
+		management.WithSessionRepository(repositories.Session),
+		management.WithTransactor(repositories.Transactor),
+		management.WithSigner(auth.NewSigner(configs.Auth.Secret, configs.Auth.AccessTTL, configs.Auth.RefreshTTL)),
+		management.WithSSO(sso),
 	)
 
 	handler := handler.New(
 		handler.Dependencies{
 			ManagementService: managementService,
 		},
-		handler.WithHTTPHandler())
+		handler.WithHTTPHandler(configs.Tenant, configs.Auth, configs.Import))
 
 	server, err := server.New(server.WithHTTPServer(handler.Mux, configs.APP.Port))
 	if err != nil {
@@ -61,6 +106,50 @@ func Run() {
 		return
 	}
 
+	schedule := notification.Schedule{
+		LeadDays:          configs.Reminder.LeadDays,
+		EscalateAfterDays: configs.Reminder.EscalateAfterDays,
+	}
+
+	reminders, err := worker.New(configs.Reminder.Interval, func(ctx context.Context) error {
+		sent, err := managementService.DispatchDueReminders(ctx, schedule)
+		if sent > 0 {
+			logger.Infof("sent %d due-date notifications\n", sent)
+		}
+		return err
+	}, worker.WithErrorHandler(func(err error) {
+		logger.Errorln("failed to dispatch due-date reminders:", err)
+	}))
+	if err != nil {
+		logger.Errorln("failed to create reminder worker")
+		return
+	}
+
+	if configs.Reminder.Enabled {
+		if err := reminders.Start(); err != nil {
+			logger.Errorln("failed to start reminder worker")
+			return
+		}
+	}
+
+	health, err := worker.New(configs.Health.Interval, func(ctx context.Context) error {
+		_, err := managementService.RefreshProjectHealth(ctx)
+		return err
+	}, worker.WithErrorHandler(func(err error) {
+		logger.Errorln("failed to refresh project health:", err)
+	}))
+	if err != nil {
+		logger.Errorln("failed to create health worker")
+		return
+	}
+
+	if configs.Health.Enabled {
+		if err := health.Start(); err != nil {
+			logger.Errorln("failed to start health worker")
+			return
+		}
+	}
+
 	logger.Infof("server is running on port %s, swagger is at /swagger/index.html\n", configs.APP.Port)
 
 	shutdown := make(chan os.Signal, 1)
@@ -72,6 +161,14 @@ func Run() {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
+	if err := reminders.Stop(ctx); err != nil {
+		logger.Errorln("failed to stop reminder worker")
+	}
+
+	if err := health.Stop(ctx); err != nil {
+		logger.Errorln("failed to stop health worker")
+	}
+
 	if err := server.Stop(ctx); err != nil {
 		logger.Errorln("failed to stop server")
 		return
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/canyouhearthemusic/project-management/config"
+	"github.com/canyouhearthemusic/project-management/internal/domain/organization"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/repository"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/sirupsen/logrus"
+)
+
+// SetPassword gives a user a password, read from the first line of standard
+// input, and reports whether it did:
+//
+//	app set-password [-org slug] email
+//
+// It is how operators let users without a password sign in, such as the
+// admins of an install from before passwords.
+func SetPassword(args []string) bool {
+	logger := logrus.New().WithContext(context.Background())
+
+	flags := flag.NewFlagSet("set-password", flag.ContinueOnError)
+	org := flags.String("org", organization.DefaultSlug, "slug or ID of the organization of the user")
+	if err := flags.Parse(args); err != nil {
+		return false
+	}
+
+	if flags.NArg() != 1 {
+		logger.Errorln("usage: set-password [-org slug] email")
+		return false
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		logger.Errorln("failed to read password from standard input")
+		return false
+	}
+	password := strings.TrimRight(line, "\r\n")
+
+	if password == "" {
+		logger.Errorln("password is required")
+		return false
+	}
+
+	if errs := (&user.UpdateRequest{Password: password}).Validate(); errs != nil {
+		for _, err := range errs {
+			logger.Errorln(err.Message)
+		}
+		return false
+	}
+
+	configs, err := config.New()
+	if err != nil {
+		logger.Errorln("failed to load configurations")
+		return false
+	}
+
+	repositories, err := repository.New(repository.WithPostgresStore(configs.DB))
+	if err != nil {
+		logger.Errorln("failed to create repositories")
+		return false
+	}
+
+	managementService := management.New(
+		management.WithUserRepository(repositories.User),
+		management.WithSessionRepository(repositories.Session),
+		management.WithOrganizationRepository(repositories.Organization),
+	)
+
+	ctx, err := managementService.ResolveTenant(context.Background(), *org, "")
+	if err != nil {
+		logger.Errorf("failed to find organization %q\n", *org)
+		return false
+	}
+
+	if err := managementService.SetPassword(ctx, flags.Arg(0), password); err != nil {
+		logger.Errorf("failed to set password of %q: %v\n", flags.Arg(0), err)
+		return false
+	}
+
+	logger.Infof("password of %s has been set\n", flags.Arg(0))
+
+	return true
+}
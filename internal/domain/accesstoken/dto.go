@@ -0,0 +1,77 @@
+package accesstoken
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+type Request struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// ExpiresAt is the date the token stops working, at most a year ahead.
+	ExpiresAt string `json:"expires_at"`
+}
+
+func (a *Request) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if a.Name == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "name is required", Field: "name"})
+	}
+	if len(a.Name) > 100 {
+		errs = append(errs, domain.ErrorResponse{Message: "name must be less than 100 characters", Field: "name"})
+	}
+
+	if len(a.Scopes) == 0 {
+		errs = append(errs, domain.ErrorResponse{Message: "at least one scope is required", Field: "scopes"})
+	}
+	for _, s := range a.Scopes {
+		if !IsValidScope(s) {
+			errs = append(errs, domain.ErrorResponse{Message: "invalid scope " + s, Field: "scopes"})
+		}
+	}
+
+	expires, err := time.Parse(domain.DateLayout, a.ExpiresAt)
+	if err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid expires_at format", Field: "expires_at"})
+	} else if today := time.Now().UTC().Truncate(24 * time.Hour); !expires.After(today) || expires.After(today.AddDate(1, 0, 0)) {
+		errs = append(errs, domain.ErrorResponse{Message: "expires_at must be within the next year", Field: "expires_at"})
+	}
+
+	return errs
+}
+
+type Response struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at"`
+	CreatedAt string   `json:"created_at"`
+}
+
+// CreatedResponse is the only time the token itself is shown.
+type CreatedResponse struct {
+	Response
+	Token string `json:"token"`
+}
+
+func ParseFromEntity(t Token) Response {
+	return Response{
+		ID:        t.ID,
+		Name:      t.Name,
+		Scopes:    append([]string{}, t.Scopes...),
+		ExpiresAt: t.ExpiresAt.Format(domain.DateLayout),
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ParseFromEntities(tokens []Token) []Response {
+	res := []Response{}
+
+	for _, t := range tokens {
+		res = append(res, ParseFromEntity(t))
+	}
+
+	return res
+}
@@ -0,0 +1,120 @@
+package accesstoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Token is a personal access token, a bearer credential of its user limited
+// to its scopes. Only its hash is stored.
+type Token struct {
+	ID             string
+	UserID         string `db:"user_id"`
+	Name           string
+	Scopes         pq.StringArray `db:"scopes"`
+	Hash           string         `db:"token_hash"`
+	ExpiresAt      time.Time      `db:"expires_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	OrganizationID string         `db:"organization_id"`
+}
+
+// Prefix tells personal access tokens from the access tokens of a sign-in.
+const Prefix = "pmt_"
+
+// Scopes are "<resource>:<level>". Each level includes those before it: read
+// for reading, write for creating and changing, admin for deleting.
+var (
+	Resources = []string{"users", "tasks", "projects", "teams", "organizations"}
+	Levels    = []string{"read", "write", "admin"}
+)
+
+func IsValidScope(scope string) bool {
+	resource, level, ok := strings.Cut(scope, ":")
+	if !ok {
+		return false
+	}
+
+	return contains(Resources, resource) && contains(Levels, level)
+}
+
+// Allows reports whether scopes grant scope. Nil scopes, those of a
+// sign-in, grant every scope.
+func Allows(scopes []string, scope string) bool {
+	if scopes == nil {
+		return true
+	}
+
+	resource, level, _ := strings.Cut(scope, ":")
+
+	for _, s := range scopes {
+		r, l, _ := strings.Cut(s, ":")
+		if r == resource && rank(l) >= rank(level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func rank(level string) int {
+	for i, l := range Levels {
+		if l == level {
+			return i
+		}
+	}
+
+	return len(Levels)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewSecret returns a random token and the hash to store for it.
+func NewSecret() (secret, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+
+	secret = Prefix + base64.RawURLEncoding.EncodeToString(b)
+
+	return secret, Hash(secret), nil
+}
+
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+var (
+	ErrNotFound       = &AccessTokenError{"access token not found"}
+	ErrBadRequest     = &AccessTokenError{"access token bad request"}
+	ErrInvalidToken   = &AccessTokenError{"invalid, expired or revoked access token"}
+	ErrNotOwner       = &AccessTokenError{"access tokens are managed by their user only"}
+	ErrSignInRequired = &AccessTokenError{"access tokens are managed when signed in, not with another access token"}
+)
+
+type AccessTokenError struct {
+	message string
+}
+
+func (e *AccessTokenError) Error() string {
+	return e.message
+}
+
+func (e *AccessTokenError) Is(err error) bool {
+	return e == err
+}
@@ -0,0 +1,11 @@
+package accesstoken
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, t Token) error
+	List(ctx context.Context, userID string) ([]Token, error)
+	// Delete revokes the token.
+	Delete(ctx context.Context, userID, id string) error
+	GetByHash(ctx context.Context, hash string) (Token, error)
+}
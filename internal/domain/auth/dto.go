@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (l *LoginRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if l.Email == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "email is required", Field: "email"})
+	}
+
+	if l.Password == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "password is required", Field: "password"})
+	}
+
+	return errs
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (r *RefreshRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if r.RefreshToken == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "refresh_token is required", Field: "refresh_token"})
+	}
+
+	return errs
+}
+
+// TokenResponse is sent in the Authorization header as "Bearer <access_token>"
+// until it expires, then traded for a new one with the refresh token.
+type TokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt string `json:"refresh_expires_at"`
+}
+
+func ParseFromTokens(access string, accessTTL time.Duration, refresh string, r RefreshToken) TokenResponse {
+	return TokenResponse{
+		AccessToken:      access,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(accessTTL.Seconds()),
+		RefreshToken:     refresh,
+		RefreshExpiresAt: r.ExpiresAt.Format(time.RFC3339),
+	}
+}
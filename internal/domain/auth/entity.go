@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// RefreshToken trades for a new pair of tokens of its session once. Only its
+// hash is stored.
+type RefreshToken struct {
+	ID             string
+	UserID         string    `db:"user_id"`
+	SessionID      string    `db:"session_id"`
+	Hash           string    `db:"token_hash"`
+	ExpiresAt      time.Time `db:"expires_at"`
+	CreatedAt      time.Time `db:"created_at"`
+	OrganizationID string    `db:"organization_id"`
+}
+
+// Claims of an access token: the user it was issued to in the subject, the
+// organization the user signed in to and the session of the sign-in.
+type Claims struct {
+	jwt.RegisteredClaims
+	Organization string `json:"org"`
+	Session      string `json:"sid"`
+}
+
+// Signer issues and verifies access tokens signed with a shared secret.
+type Signer struct {
+	secret []byte
+
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+func NewSigner(secret string, accessTTL, refreshTTL time.Duration) *Signer {
+	if secret == "" {
+		panic("secret is required")
+	}
+
+	return &Signer{
+		secret:     []byte(secret),
+		AccessTTL:  accessTTL,
+		RefreshTTL: refreshTTL,
+	}
+}
+
+// Sign returns an access token for the session of the user in the
+// organization, valid for AccessTTL from now.
+func (s *Signer) Sign(userID, organizationID, sessionID string, now time.Time) (string, error) {
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.AccessTTL)),
+		},
+		Organization: organizationID,
+		Session:      sessionID,
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+}
+
+// Verify returns the claims of a token signed by s that has not expired.
+func (s *Signer) Verify(token string) (Claims, error) {
+	claims := Claims{}
+
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || claims.Subject == "" || claims.Session == "" {
+		return Claims{}, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// NewSecret returns a random refresh token and the hash to store for it.
+func NewSecret() (secret, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+
+	secret = base64.RawURLEncoding.EncodeToString(b)
+
+	return secret, Hash(secret), nil
+}
+
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// unset is compared against for users without a password, so that signing in
+// takes as long whether or not the user can.
+var unset, _ = bcrypt.GenerateFromPassword([]byte("unset"), bcrypt.DefaultCost)
+
+// CheckPassword reports whether password matches hash. An empty hash, that
+// of a user without a password, matches none.
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		bcrypt.CompareHashAndPassword(unset, []byte(password))
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+var (
+	ErrBadRequest         = &AuthError{"auth bad request"}
+	ErrInvalidCredentials = &AuthError{"invalid email or password"}
+	ErrInvalidToken       = &AuthError{"invalid or expired token"}
+	ErrSSODisabled        = &AuthError{"single sign-on is not configured"}
+	ErrInvalidLogin       = &AuthError{"unknown or expired single sign-on attempt"}
+	ErrSSORejected        = &AuthError{"single sign-on was rejected"}
+	ErrEmailUnverified    = &AuthError{"the provider has not verified the email address"}
+)
+
+type AuthError struct {
+	message string
+}
+
+func (e *AuthError) Error() string {
+	return e.message
+}
+
+func (e *AuthError) Is(err error) bool {
+	return e == err
+}
@@ -0,0 +1,15 @@
+package auth
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, t RefreshToken) error
+	// Consume deletes the token with the hash and returns it, so that it is
+	// only ever traded once.
+	Consume(ctx context.Context, hash string) (RefreshToken, error)
+
+	CreateLogin(ctx context.Context, l Login) error
+	// ConsumeLogin is not tenant scoped: the login names the organization
+	// the provider calls back for.
+	ConsumeLogin(ctx context.Context, stateHash string) (Login, error)
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/pkg/oidc"
+)
+
+// Login is a sign-in through the provider waiting for its callback. It is
+// found by the hash of the state sent to the provider and names the
+// organization, which the callback from the provider cannot.
+type Login struct {
+	StateHash      string    `db:"state_hash"`
+	Verifier       string    `db:"verifier"`
+	Nonce          string    `db:"nonce"`
+	ExpiresAt      time.Time `db:"expires_at"`
+	OrganizationID string    `db:"organization_id"`
+}
+
+// LoginTTL is how long users have to sign in at the provider.
+const LoginTTL = 10 * time.Minute
+
+// SSO signs users in through an OpenID Connect provider. Users are matched
+// by email and created when missing.
+type SSO struct {
+	Client *oidc.Client
+	// GroupsClaim names the ID token claim listing the user's groups.
+	GroupsClaim string
+	// Roles maps provider groups to user roles.
+	Roles map[string]string
+	// DefaultRole is given to new users none of whose groups map to a role.
+	DefaultRole string
+}
+
+// roleRanks orders roles for users in groups mapping to several.
+var roleRanks = map[string]int{
+	user.RoleDeveloper: 1,
+	user.RoleManager:   2,
+	user.RoleAdmin:     3,
+}
+
+// Role is the highest role the groups map to, or empty when none does.
+func (s *SSO) Role(groups []string) string {
+	role := ""
+	for _, g := range groups {
+		if r, ok := s.Roles[g]; ok && roleRanks[r] > roleRanks[role] {
+			role = r
+		}
+	}
+
+	return role
+}
@@ -0,0 +1,98 @@
+package board
+
+import (
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+)
+
+type LimitRequest struct {
+	Status   string `json:"status"`
+	WIPLimit int    `json:"wip_limit"`
+	Strict   bool   `json:"strict"`
+}
+
+// LimitsRequest replaces all WIP limits of a project; columns that are not
+// listed become unlimited.
+type LimitsRequest struct {
+	Limits []LimitRequest `json:"limits"`
+}
+
+func (l *LimitsRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	seen := map[string]bool{}
+	for _, limit := range l.Limits {
+		if !task.IsValidStatus(limit.Status) {
+			errs = append(errs, domain.ErrorResponse{Message: "invalid status value", Field: "status"})
+		}
+
+		if seen[limit.Status] {
+			errs = append(errs, domain.ErrorResponse{Message: "duplicate limit for " + limit.Status, Field: "status"})
+		}
+		seen[limit.Status] = true
+
+		if limit.WIPLimit <= 0 {
+			errs = append(errs, domain.ErrorResponse{Message: "wip_limit must be greater than 0", Field: "wip_limit"})
+		}
+	}
+
+	return errs
+}
+
+type LimitResponse struct {
+	Status   string `json:"status"`
+	WIPLimit int    `json:"wip_limit"`
+	Strict   bool   `json:"strict"`
+}
+
+func ParseFromLimits(limits []Limit) []LimitResponse {
+	res := []LimitResponse{}
+	for _, l := range limits {
+		res = append(res, LimitResponse{Status: l.Status, WIPLimit: l.WIPLimit, Strict: l.Strict})
+	}
+	return res
+}
+
+type ColumnResponse struct {
+	Status    string          `json:"status"`
+	Count     int             `json:"count"`
+	WIPLimit  int             `json:"wip_limit,omitempty"`
+	Strict    bool            `json:"strict,omitempty"`
+	OverLimit bool            `json:"over_limit"`
+	Tasks     []task.Response `json:"tasks"`
+}
+
+type Response struct {
+	ProjectID string           `json:"project_id"`
+	Columns   []ColumnResponse `json:"columns"`
+}
+
+// Build groups the project's tasks into columns by status.
+func Build(projectID string, tasks []task.Entity, limits []Limit) Response {
+	byStatus := map[string]Limit{}
+	for _, l := range limits {
+		byStatus[l.Status] = l
+	}
+
+	res := Response{ProjectID: projectID}
+	for _, status := range Columns {
+		col := ColumnResponse{Status: status, Tasks: []task.Response{}}
+
+		for _, t := range tasks {
+			if t.Status == status {
+				col.Tasks = append(col.Tasks, task.ParseFromEntity(t))
+			}
+		}
+		col.Count = len(col.Tasks)
+
+		if l, ok := byStatus[status]; ok {
+			col.WIPLimit = l.WIPLimit
+			col.Strict = l.Strict
+			col.OverLimit = col.Count > l.WIPLimit
+		}
+
+		res.Columns = append(res.Columns, col)
+	}
+
+	return res
+}
@@ -0,0 +1,38 @@
+package board
+
+import "github.com/canyouhearthemusic/project-management/internal/domain/task"
+
+// Columns lists the board columns in display order.
+var Columns = []string{task.StatusActive, task.StatusInProgress, task.StatusDone}
+
+// Limit caps the number of tasks in a board column. A strict limit rejects
+// moves into a full column, otherwise the move is allowed with a warning.
+type Limit struct {
+	ProjectID string `db:"project_id"`
+	Status    string
+	WIPLimit  int `db:"wip_limit"`
+	Strict    bool
+}
+
+// Exceeded reports whether adding n more tasks to a column that already
+// holds count tasks breaks the limit.
+func (l Limit) Exceeded(count, n int) bool {
+	return count+n > l.WIPLimit
+}
+
+var (
+	ErrWIPLimit   = &BoardError{"column work-in-progress limit reached"}
+	ErrBadRequest = &BoardError{"board bad request"}
+)
+
+type BoardError struct {
+	message string
+}
+
+func (e *BoardError) Error() string {
+	return e.message
+}
+
+func (e *BoardError) Is(err error) bool {
+	return e == err
+}
@@ -0,0 +1,8 @@
+package board
+
+import "context"
+
+type Repository interface {
+	Limits(ctx context.Context, projectID string) ([]Limit, error)
+	SetLimits(ctx context.Context, projectID string, limits []Limit) error
+}
@@ -0,0 +1,221 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+type RateRequest struct {
+	HourlyRate    float64 `json:"hourly_rate"`
+	Currency      string  `json:"currency"`
+	EffectiveFrom string  `json:"effective_from"`
+}
+
+// TimeEntryRequest logs hours on a task. UserID defaults to the acting user.
+type TimeEntryRequest struct {
+	UserID  string  `json:"user_id"`
+	Hours   float64 `json:"hours"`
+	SpentOn string  `json:"spent_on"`
+	Note    string  `json:"note"`
+}
+
+type ExpenseRequest struct {
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	SpentOn     string  `json:"spent_on"`
+	Description string  `json:"description"`
+}
+
+func (r *RateRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if r.HourlyRate < 0 {
+		errs = append(errs, domain.ErrorResponse{Message: "hourly_rate must not be negative", Field: "hourly_rate"})
+	}
+
+	if !domain.IsValidCurrency(r.Currency) {
+		errs = append(errs, domain.ErrorResponse{Message: "currency must be a three-letter ISO 4217 code", Field: "currency"})
+	}
+
+	if _, err := time.Parse(domain.DateLayout, r.EffectiveFrom); err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid effective_from format", Field: "effective_from"})
+	}
+
+	return errs
+}
+
+func (e *TimeEntryRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if e.Hours <= 0 || e.Hours > 24 {
+		errs = append(errs, domain.ErrorResponse{Message: "hours must be greater than 0 and at most 24", Field: "hours"})
+	}
+
+	if _, err := time.Parse(domain.DateLayout, e.SpentOn); err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid spent_on format", Field: "spent_on"})
+	}
+
+	if len(e.Note) > 200 {
+		errs = append(errs, domain.ErrorResponse{Message: "note must be less than 200 characters", Field: "note"})
+	}
+
+	return errs
+}
+
+func (e *ExpenseRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if e.Amount <= 0 {
+		errs = append(errs, domain.ErrorResponse{Message: "amount must be greater than 0", Field: "amount"})
+	}
+
+	if !domain.IsValidCurrency(e.Currency) {
+		errs = append(errs, domain.ErrorResponse{Message: "currency must be a three-letter ISO 4217 code", Field: "currency"})
+	}
+
+	if _, err := time.Parse(domain.DateLayout, e.SpentOn); err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid spent_on format", Field: "spent_on"})
+	}
+
+	if len(e.Description) > 200 {
+		errs = append(errs, domain.ErrorResponse{Message: "description must be less than 200 characters", Field: "description"})
+	}
+
+	return errs
+}
+
+type RateResponse struct {
+	ID            string  `json:"id"`
+	UserID        string  `json:"user_id"`
+	HourlyRate    float64 `json:"hourly_rate"`
+	Currency      string  `json:"currency"`
+	EffectiveFrom string  `json:"effective_from"`
+}
+
+type TimeEntryResponse struct {
+	ID      string  `json:"id"`
+	TaskID  string  `json:"task_id"`
+	UserID  string  `json:"user_id"`
+	Hours   float64 `json:"hours"`
+	SpentOn string  `json:"spent_on"`
+	Note    string  `json:"note"`
+}
+
+type ExpenseResponse struct {
+	ID          string  `json:"id"`
+	TaskID      string  `json:"task_id"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	SpentOn     string  `json:"spent_on"`
+	Description string  `json:"description"`
+}
+
+type CostResponse struct {
+	Kind     string  `json:"kind"`
+	Currency string  `json:"currency"`
+	Hours    float64 `json:"hours"`
+	Amount   float64 `json:"amount"`
+}
+
+type ReportResponse struct {
+	ProjectID     string         `json:"project_id"`
+	Amount        float64        `json:"amount"`
+	Currency      string         `json:"currency"`
+	Labour        float64        `json:"labour"`
+	Expenses      float64        `json:"expenses"`
+	Spent         float64        `json:"spent"`
+	Remaining     float64        `json:"remaining"`
+	Hours         float64        `json:"hours"`
+	UnpricedHours float64        `json:"unpriced_hours"`
+	Other         []CostResponse `json:"other_currencies"`
+	ElapsedDays   int            `json:"elapsed_days"`
+	TotalDays     int            `json:"total_days"`
+	DailyBurn     float64        `json:"daily_burn"`
+	Forecast      float64        `json:"forecast"`
+	OverBudget    bool           `json:"over_budget"`
+	ExhaustedOn   string         `json:"exhausted_on,omitempty"`
+}
+
+func ParseFromRate(r Rate) RateResponse {
+	return RateResponse{
+		ID:            r.ID,
+		UserID:        r.UserID,
+		HourlyRate:    r.HourlyRate,
+		Currency:      r.Currency,
+		EffectiveFrom: r.EffectiveFrom.String(),
+	}
+}
+
+func ParseFromRates(rates []Rate) []RateResponse {
+	res := []RateResponse{}
+	for _, r := range rates {
+		res = append(res, ParseFromRate(r))
+	}
+	return res
+}
+
+func ParseFromTimeEntry(e TimeEntry) TimeEntryResponse {
+	return TimeEntryResponse{
+		ID:      e.ID,
+		TaskID:  e.TaskID,
+		UserID:  e.UserID,
+		Hours:   e.Hours,
+		SpentOn: e.SpentOn.String(),
+		Note:    e.Note,
+	}
+}
+
+func ParseFromTimeEntries(entries []TimeEntry) []TimeEntryResponse {
+	res := []TimeEntryResponse{}
+	for _, e := range entries {
+		res = append(res, ParseFromTimeEntry(e))
+	}
+	return res
+}
+
+func ParseFromExpense(e Expense) ExpenseResponse {
+	return ExpenseResponse{
+		ID:          e.ID,
+		TaskID:      e.TaskID,
+		Amount:      e.Amount,
+		Currency:    e.Currency,
+		SpentOn:     e.SpentOn.String(),
+		Description: e.Description,
+	}
+}
+
+func ParseFromExpenses(expenses []Expense) []ExpenseResponse {
+	res := []ExpenseResponse{}
+	for _, e := range expenses {
+		res = append(res, ParseFromExpense(e))
+	}
+	return res
+}
+
+func ParseFromReport(projectID string, r Report) ReportResponse {
+	res := ReportResponse{
+		ProjectID:     projectID,
+		Amount:        r.Amount,
+		Currency:      r.Currency,
+		Labour:        r.Labour,
+		Expenses:      r.Expenses,
+		Spent:         r.Spent,
+		Remaining:     r.Remaining,
+		Hours:         r.Hours,
+		UnpricedHours: r.UnpricedHours,
+		Other:         []CostResponse{},
+		ElapsedDays:   r.ElapsedDays,
+		TotalDays:     r.TotalDays,
+		DailyBurn:     r.DailyBurn,
+		Forecast:      r.Forecast,
+		OverBudget:    r.Spent > r.Amount,
+		ExhaustedOn:   r.ExhaustedOn,
+	}
+
+	for _, c := range r.Other {
+		res.Other = append(res.Other, CostResponse{Kind: c.Kind, Currency: c.Currency, Hours: c.Hours, Amount: c.Amount})
+	}
+
+	return res
+}
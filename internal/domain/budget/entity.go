@@ -0,0 +1,160 @@
+package budget
+
+import (
+	"math"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+)
+
+// Rate is the hourly rate of a user from EffectiveFrom until the next rate
+// of the same user takes effect.
+type Rate struct {
+	ID            string
+	UserID        string          `db:"user_id"`
+	HourlyRate    float64         `db:"hourly_rate"`
+	Currency      string          `db:"currency"`
+	EffectiveFrom domain.OnlyDate `db:"effective_from"`
+}
+
+type TimeEntry struct {
+	ID      string
+	TaskID  string          `db:"task_id"`
+	UserID  string          `db:"user_id"`
+	Hours   float64         `db:"hours"`
+	SpentOn domain.OnlyDate `db:"spent_on"`
+	Note    string
+}
+
+type Expense struct {
+	ID          string
+	TaskID      string          `db:"task_id"`
+	Amount      float64         `db:"amount"`
+	Currency    string          `db:"currency"`
+	SpentOn     domain.OnlyDate `db:"spent_on"`
+	Description string
+}
+
+const (
+	KindLabour  = "labour"
+	KindExpense = "expense"
+)
+
+// Cost is what a project has spent in one currency on one kind of cost.
+// Labour logged by users without a rate on the day has no currency and only
+// counts Hours.
+type Cost struct {
+	Kind     string
+	Currency string
+	Hours    float64
+	Amount   float64
+}
+
+type Report struct {
+	Amount        float64
+	Currency      string
+	Labour        float64
+	Expenses      float64
+	Spent         float64
+	Remaining     float64
+	Hours         float64
+	UnpricedHours float64
+	// Other holds costs in currencies other than the budget's; they are not
+	// converted and not part of Spent.
+	Other []Cost
+
+	ElapsedDays int
+	TotalDays   int
+	DailyBurn   float64
+	Forecast    float64
+	ExhaustedOn string
+}
+
+// Compute totals the costs of p against its budget and forecasts the spend
+// at finished_at by extending the average daily burn since started_at.
+// ExhaustedOn is the day the budget runs out at that burn, when it does
+// before the project finishes.
+func Compute(p project.Entity, costs []Cost, now time.Time) Report {
+	r := Report{Amount: p.BudgetAmount, Currency: p.BudgetCurrency, Other: []Cost{}}
+
+	for _, c := range costs {
+		r.Hours += c.Hours
+
+		switch {
+		case c.Kind == KindLabour && c.Currency == "":
+			r.UnpricedHours += c.Hours
+		case c.Currency != p.BudgetCurrency:
+			r.Other = append(r.Other, c)
+		case c.Kind == KindLabour:
+			r.Labour += c.Amount
+		default:
+			r.Expenses += c.Amount
+		}
+	}
+
+	r.Spent = r.Labour + r.Expenses
+	r.Remaining = r.Amount - r.Spent
+
+	today, _ := time.Parse(domain.DateLayout, now.Format(domain.DateLayout))
+
+	start, err := time.Parse(domain.DateLayout, p.StartedAt.String())
+	if err != nil {
+		return r.rounded()
+	}
+
+	finish, err := time.Parse(domain.DateLayout, p.FinishedAt.String())
+	if err != nil {
+		return r.rounded()
+	}
+
+	r.TotalDays = int(finish.Sub(start).Hours()/24) + 1
+
+	until := today
+	if finish.Before(today) {
+		until = finish
+	}
+	r.ElapsedDays = int(until.Sub(start).Hours()/24) + 1
+	if r.ElapsedDays < 0 {
+		r.ElapsedDays = 0
+	}
+
+	r.Forecast = r.Spent
+	if r.ElapsedDays > 0 {
+		r.DailyBurn = r.Spent / float64(r.ElapsedDays)
+		r.Forecast = r.DailyBurn * float64(r.TotalDays)
+	}
+
+	if r.DailyBurn > 0 && r.Forecast > r.Amount {
+		days := int(math.Ceil(r.Amount / r.DailyBurn))
+		r.ExhaustedOn = start.AddDate(0, 0, days-1).Format(domain.DateLayout)
+	}
+
+	return r.rounded()
+}
+
+func (r Report) rounded() Report {
+	for _, v := range []*float64{&r.Labour, &r.Expenses, &r.Spent, &r.Remaining, &r.Hours, &r.UnpricedHours, &r.DailyBurn, &r.Forecast} {
+		*v = math.Round(*v*100) / 100
+	}
+
+	return r
+}
+
+var (
+	ErrNotFound   = &BudgetError{"budget entry not found"}
+	ErrBadRequest = &BudgetError{"budget bad request"}
+	ErrRateExists = &BudgetError{"a rate already takes effect on that day"}
+)
+
+type BudgetError struct {
+	message string
+}
+
+func (e *BudgetError) Error() string {
+	return e.message
+}
+
+func (e *BudgetError) Is(err error) bool {
+	return e == err
+}
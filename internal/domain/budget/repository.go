@@ -0,0 +1,22 @@
+package budget
+
+import "context"
+
+type Repository interface {
+	AddRate(ctx context.Context, r Rate) error
+	ListRates(ctx context.Context, userID string) ([]Rate, error)
+
+	AddTimeEntry(ctx context.Context, e TimeEntry) error
+	GetTimeEntry(ctx context.Context, taskID, id string) (TimeEntry, error)
+	ListTimeEntries(ctx context.Context, taskID string) ([]TimeEntry, error)
+	DeleteTimeEntry(ctx context.Context, taskID, id string) error
+
+	AddExpense(ctx context.Context, e Expense) error
+	ListExpenses(ctx context.Context, taskID string) ([]Expense, error)
+	DeleteExpense(ctx context.Context, taskID, id string) error
+
+	// Costs prices the time entries of a project's tasks at the rate in
+	// effect on the day they were logged and sums them with the expenses,
+	// per kind and currency.
+	Costs(ctx context.Context, projectID string) ([]Cost, error)
+}
@@ -0,0 +1,55 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+type Request struct {
+	Name string `json:"name"`
+}
+
+func (c *Request) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if c.Name == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "name is required", Field: "name"})
+	}
+	if len(c.Name) > 100 {
+		errs = append(errs, domain.ErrorResponse{Message: "name must be less than 100 characters", Field: "name"})
+	}
+
+	return errs
+}
+
+type Response struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CreatedResponse is the only time the token itself is shown.
+type CreatedResponse struct {
+	Response
+	Token string `json:"token"`
+	// FeedURL is the path of the user's feed with the token, ready to
+	// subscribe to.
+	FeedURL string `json:"feed_url"`
+}
+
+func ParseFromEntity(t Token) Response {
+	return Response{
+		ID:        t.ID,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ParseFromEntities(tokens []Token) []Response {
+	res := []Response{}
+	for _, t := range tokens {
+		res = append(res, ParseFromEntity(t))
+	}
+	return res
+}
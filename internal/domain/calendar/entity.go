@@ -0,0 +1,123 @@
+package calendar
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/pkg/ical"
+)
+
+// Token grants read access to the feeds of its user: the user's own feed and
+// those of the projects the user is a member of. Only its hash is stored.
+type Token struct {
+	ID             string
+	UserID         string `db:"user_id"`
+	Name           string
+	Hash           string    `db:"token_hash"`
+	CreatedAt      time.Time `db:"created_at"`
+	OrganizationID string    `db:"organization_id"`
+}
+
+// NewSecret returns a random feed token and the hash to store for it.
+func NewSecret() (secret, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+
+	secret = base64.RawURLEncoding.EncodeToString(b)
+
+	return secret, Hash(secret), nil
+}
+
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// UserFeed holds the tasks assigned to a user and the projects the user is
+// a member of.
+func UserFeed(name string, projects []project.Entity, tasks []task.Entity, now time.Time) ical.Calendar {
+	return feed(name, projects, tasks, now)
+}
+
+func ProjectFeed(p project.Entity, tasks []task.Entity, now time.Time) ical.Calendar {
+	return feed(p.Title, []project.Entity{p}, tasks, now)
+}
+
+// feed turns projects into events from their start to their finish date and
+// tasks into to-dos due on their due date. Entries with dates that do not
+// parse are left out.
+func feed(name string, projects []project.Entity, tasks []task.Entity, now time.Time) ical.Calendar {
+	c := ical.Calendar{Name: name, Stamp: now}
+
+	for _, p := range projects {
+		start, err := time.Parse(domain.DateLayout, p.StartedAt.String())
+		if err != nil {
+			continue
+		}
+		finish, err := time.Parse(domain.DateLayout, p.FinishedAt.String())
+		if err != nil {
+			continue
+		}
+
+		c.Events = append(c.Events, ical.Event{
+			UID:         "project-" + p.ID,
+			Summary:     p.Title,
+			Description: p.Description,
+			Start:       start,
+			End:         finish,
+		})
+	}
+
+	for _, t := range tasks {
+		due, err := time.Parse(domain.DateLayout, t.DoneAt.String())
+		if err != nil {
+			continue
+		}
+
+		c.Todos = append(c.Todos, ical.Todo{
+			UID:         "task-" + t.ID,
+			Summary:     t.Title,
+			Description: t.Description,
+			Due:         due,
+			Completed:   t.Status == task.StatusDone,
+			Priority:    priorities[t.Priority],
+		})
+	}
+
+	return c
+}
+
+// priorities follow the high, medium and low ranges of RFC 5545.
+var priorities = map[string]int{
+	"high":   1,
+	"medium": 5,
+	"low":    9,
+}
+
+var (
+	ErrNotFound     = &CalendarError{"calendar token not found"}
+	ErrBadRequest   = &CalendarError{"calendar token bad request"}
+	ErrInvalidToken = &CalendarError{"invalid or revoked calendar token"}
+	ErrForbidden    = &CalendarError{"calendar token does not grant access to this feed"}
+	ErrNotOwner     = &CalendarError{"calendar tokens are managed by their user only"}
+)
+
+type CalendarError struct {
+	message string
+}
+
+func (e *CalendarError) Error() string {
+	return e.message
+}
+
+func (e *CalendarError) Is(err error) bool {
+	return e == err
+}
@@ -0,0 +1,13 @@
+package calendar
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, t Token) error
+	List(ctx context.Context, userID string) ([]Token, error)
+	// Delete revokes the token.
+	Delete(ctx context.Context, userID, id string) error
+	// GetByHash is not tenant scoped: the token names the organization its
+	// feeds are read from.
+	GetByHash(ctx context.Context, hash string) (Token, error)
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -44,3 +45,103 @@ func (o *OnlyDate) String() string {
 func (e ErrorResponse) Error() string {
 	return fmt.Sprintf("Field %s has issue: %s", e.Field, e.Message)
 }
+
+// IsValidCurrency reports whether code looks like an ISO 4217 currency code.
+func IsValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Transactor runs fn so that whatever the repositories do with the context
+// it is given is committed together or not at all.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the ID of the user on whose
+// behalf the request is executed.
+func WithActor(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext returns the acting user ID or an empty string when unknown.
+func ActorFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(actorKey{}).(string)
+	return id
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx confined to the organization with the
+// given ID. Repositories only read and write rows of that organization.
+func WithTenant(ctx context.Context, organizationID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, organizationID)
+}
+
+// TenantFromContext returns the organization ID of the request or an empty
+// string, which matches no rows.
+func TenantFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(tenantKey{}).(string)
+	return id
+}
+
+type scopesKey struct{}
+
+// WithScopes returns a copy of ctx limited to scopes, those of the personal
+// access token the request authenticated with.
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// ScopesFromContext returns the scopes the request is limited to or nil when
+// it is not limited.
+func ScopesFromContext(ctx context.Context) []string {
+	scopes, _ := ctx.Value(scopesKey{}).([]string)
+	return scopes
+}
+
+// Client is the device a request comes from.
+type Client struct {
+	UserAgent string
+	IP        string
+}
+
+type clientKey struct{}
+
+// WithClient returns a copy of ctx carrying the user agent and IP address of
+// the request.
+func WithClient(ctx context.Context, userAgent, ip string) context.Context {
+	return context.WithValue(ctx, clientKey{}, Client{UserAgent: userAgent, IP: ip})
+}
+
+// ClientFromContext returns the device of the request, empty when unknown.
+func ClientFromContext(ctx context.Context) Client {
+	c, _ := ctx.Value(clientKey{}).(Client)
+	return c
+}
+
+type sessionKey struct{}
+
+// WithSession returns a copy of ctx carrying the ID of the sign-in session
+// the request is made in.
+func WithSession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, sessionID)
+}
+
+// SessionFromContext returns the session ID of the request or an empty string
+// for requests made without one, such as with personal access tokens.
+func SessionFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(sessionKey{}).(string)
+	return id
+}
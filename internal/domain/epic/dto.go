@@ -0,0 +1,127 @@
+package epic
+
+import (
+	"math"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+type Request struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	OwnerID     string `json:"owner_id"`
+}
+
+type UpdateRequest struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	OwnerID     string `json:"owner_id,omitempty"`
+}
+
+type TasksRequest struct {
+	TaskIDs []string `json:"task_ids"`
+}
+
+func (e *Request) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if e.Title == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "title is required", Field: "title"})
+	}
+	if len(e.Title) > 100 {
+		errs = append(errs, domain.ErrorResponse{Message: "title must be less than 100 characters", Field: "title"})
+	}
+
+	if len(e.Description) > 200 {
+		errs = append(errs, domain.ErrorResponse{Message: "description must be less than 200 characters", Field: "description"})
+	}
+
+	if e.OwnerID == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "owner_id is required", Field: "owner_id"})
+	}
+
+	return errs
+}
+
+func (e *UpdateRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(e.Title) > 100 && e.Title != "" {
+		errs = append(errs, domain.ErrorResponse{Message: "title must be less than 100 characters", Field: "title"})
+	}
+
+	if len(e.Description) > 200 && e.Description != "" {
+		errs = append(errs, domain.ErrorResponse{Message: "description must be less than 200 characters", Field: "description"})
+	}
+
+	return errs
+}
+
+func (e *TasksRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(e.TaskIDs) == 0 {
+		errs = append(errs, domain.ErrorResponse{Message: "task_ids is required", Field: "task_ids"})
+	}
+
+	return errs
+}
+
+type ProjectProgressResponse struct {
+	ProjectID string  `json:"project_id"`
+	Total     int     `json:"total"`
+	Done      int     `json:"done"`
+	Percent   float64 `json:"percent"`
+}
+
+type ProgressResponse struct {
+	Total    int                       `json:"total"`
+	Done     int                       `json:"done"`
+	Percent  float64                   `json:"percent"`
+	ByStatus map[string]int            `json:"by_status"`
+	Projects []ProjectProgressResponse `json:"projects"`
+}
+
+type Response struct {
+	ID          string           `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	OwnerID     string           `json:"owner_id"`
+	CreatedAt   string           `json:"created_at"`
+	Progress    ProgressResponse `json:"progress"`
+}
+
+func ParseFromEntity(e Entity, p Progress) Response {
+	res := Response{
+		ID:          e.ID,
+		Title:       e.Title,
+		Description: e.Description,
+		OwnerID:     e.OwnerID,
+		CreatedAt:   e.CreatedAt.String(),
+		Progress: ProgressResponse{
+			Total:    p.Total,
+			Done:     p.Done,
+			Percent:  percent(p.Done, p.Total),
+			ByStatus: p.ByStatus,
+			Projects: []ProjectProgressResponse{},
+		},
+	}
+
+	for _, pp := range p.Projects {
+		res.Progress.Projects = append(res.Progress.Projects, ProjectProgressResponse{
+			ProjectID: pp.ProjectID,
+			Total:     pp.Total,
+			Done:      pp.Done,
+			Percent:   percent(pp.Done, pp.Total),
+		})
+	}
+
+	return res
+}
+
+func percent(done, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(done)/float64(total)*10000) / 100
+}
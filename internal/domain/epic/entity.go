@@ -0,0 +1,79 @@
+package epic
+
+import (
+	"sort"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+)
+
+type Entity struct {
+	ID          string
+	Title       string
+	Description string
+	OwnerID     string          `db:"owner_id"`
+	CreatedAt   domain.OnlyDate `db:"created_at"`
+}
+
+type ProjectProgress struct {
+	ProjectID string
+	Total     int
+	Done      int
+}
+
+type Progress struct {
+	Total    int
+	Done     int
+	ByStatus map[string]int
+	Projects []ProjectProgress
+}
+
+// RollUp sums up the epic's tasks overall and per project.
+func RollUp(tasks []task.Entity) Progress {
+	p := Progress{ByStatus: map[string]int{}}
+
+	byProject := map[string]*ProjectProgress{}
+	for _, t := range tasks {
+		pp, ok := byProject[t.ProjectID]
+		if !ok {
+			pp = &ProjectProgress{ProjectID: t.ProjectID}
+			byProject[t.ProjectID] = pp
+		}
+
+		p.Total++
+		pp.Total++
+		p.ByStatus[t.Status]++
+
+		if t.Status == task.StatusDone {
+			p.Done++
+			pp.Done++
+		}
+	}
+
+	for _, pp := range byProject {
+		p.Projects = append(p.Projects, *pp)
+	}
+	sort.Slice(p.Projects, func(i, j int) bool {
+		return p.Projects[i].ProjectID < p.Projects[j].ProjectID
+	})
+
+	return p
+}
+
+var (
+	ErrNotFound   = &EpicError{"epic not found"}
+	ErrBadRequest = &EpicError{"epic bad request"}
+	ErrNotLinked  = &EpicError{"task is not part of the epic"}
+)
+
+type EpicError struct {
+	message string
+}
+
+func (e *EpicError) Error() string {
+	return e.message
+}
+
+func (e *EpicError) Is(err error) bool {
+	return e == err
+}
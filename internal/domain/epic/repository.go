@@ -0,0 +1,11 @@
+package epic
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, e Entity) (string, Entity, error)
+	Get(ctx context.Context, id string) (Entity, error)
+	List(ctx context.Context) ([]Entity, error)
+	Update(ctx context.Context, id string, e Entity) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,21 @@
+package export
+
+// Mapping pairs an ID from the archive with the ID it got on import.
+type Mapping struct {
+	OldID string `json:"old_id"`
+	NewID string `json:"new_id"`
+}
+
+// UserMapping tells whether a user was matched by email or newly created.
+type UserMapping struct {
+	Mapping
+	Email   string `json:"email"`
+	Created bool   `json:"created"`
+}
+
+// Report is the ID mapping of an import.
+type Report struct {
+	Project Mapping       `json:"project"`
+	Tasks   []Mapping     `json:"tasks"`
+	Users   []UserMapping `json:"users"`
+}
@@ -0,0 +1,139 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+)
+
+// Version is the archive format written by Write. Read only accepts
+// archives of this version.
+const Version = 1
+
+const manifestName = "manifest.json"
+
+// Manifest describes an exported project. IDs are those of the exporting
+// instance; an import assigns new ones.
+type Manifest struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Project    project.Response  `json:"project"`
+	Tasks      []task.Response   `json:"tasks"`
+	Users      []user.Response   `json:"users"`
+	Members    []member.Response `json:"members"`
+}
+
+// Archive is an exported project as it is stored in the zip.
+type Archive struct {
+	Manifest Manifest
+}
+
+// Limits bound what Read decompresses, so that a small upload cannot expand
+// into more than the server is willing to hold.
+type Limits struct {
+	// MaxEntries is the number of files the zip may contain.
+	MaxEntries int
+	// MaxSize is the decompressed size of the entries read, in bytes.
+	MaxSize int64
+}
+
+// Write stores a as a zip with manifest.json at its root.
+func Write(w io.Writer, a Archive) error {
+	zw := zip.NewWriter(w)
+
+	a.Manifest.Version = Version
+
+	f, err := zw.Create(manifestName)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a.Manifest); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// Read parses an archive written by Write within limits. Entries other than
+// the manifest are not decompressed.
+func Read(r io.ReaderAt, size int64, limits Limits) (Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return Archive{}, ErrInvalid
+	}
+
+	if len(zr.File) > limits.MaxEntries {
+		return Archive{}, ErrTooLarge
+	}
+
+	a := Archive{}
+
+	found := false
+	for _, f := range zr.File {
+		if f.Name != manifestName {
+			continue
+		}
+		if found {
+			return Archive{}, ErrInvalid
+		}
+		found = true
+
+		rc, err := f.Open()
+		if err != nil {
+			return Archive{}, ErrInvalid
+		}
+
+		// the sizes in the zip headers are the uploader's word, so the
+		// limit is applied to what actually comes out
+		content, err := io.ReadAll(io.LimitReader(rc, limits.MaxSize+1))
+		rc.Close()
+		if err != nil {
+			return Archive{}, ErrInvalid
+		}
+
+		if int64(len(content)) > limits.MaxSize {
+			return Archive{}, ErrTooLarge
+		}
+
+		if err := json.Unmarshal(content, &a.Manifest); err != nil {
+			return Archive{}, ErrInvalid
+		}
+	}
+
+	if !found {
+		return Archive{}, ErrInvalid
+	}
+
+	if a.Manifest.Version != Version {
+		return Archive{}, ErrVersion
+	}
+
+	return a, nil
+}
+
+var (
+	ErrInvalid  = &ExportError{"invalid project archive"}
+	ErrVersion  = &ExportError{"unsupported project archive version"}
+	ErrTooLarge = &ExportError{"project archive is too large"}
+)
+
+type ExportError struct {
+	message string
+}
+
+func (e *ExportError) Error() string {
+	return e.message
+}
+
+func (e *ExportError) Is(err error) bool {
+	return e == err
+}
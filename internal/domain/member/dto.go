@@ -0,0 +1,62 @@
+package member
+
+import "github.com/canyouhearthemusic/project-management/internal/domain"
+
+type Request struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type UpdateRequest struct {
+	Role string `json:"role"`
+}
+
+type Response struct {
+	UserID  string `json:"user_id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Role    string `json:"role"`
+	AddedAt string `json:"added_at"`
+}
+
+func (m *Request) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if m.UserID == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "user_id is required", Field: "user_id"})
+	}
+
+	if !IsValidRole(m.Role) {
+		errs = append(errs, domain.ErrorResponse{Message: "role must be one of: owner, maintainer, contributor, viewer", Field: "role"})
+	}
+
+	return errs
+}
+
+func (m *UpdateRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if !IsValidRole(m.Role) {
+		errs = append(errs, domain.ErrorResponse{Message: "role must be one of: owner, maintainer, contributor, viewer", Field: "role"})
+	}
+
+	return errs
+}
+
+func ParseFromEntity(m Entity) Response {
+	return Response{
+		UserID:  m.UserID,
+		Name:    m.Name,
+		Email:   m.Email,
+		Role:    m.Role,
+		AddedAt: m.AddedAt.String(),
+	}
+}
+
+func ParseFromEntities(members []Entity) []Response {
+	res := []Response{}
+	for _, m := range members {
+		res = append(res, ParseFromEntity(m))
+	}
+	return res
+}
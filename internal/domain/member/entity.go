@@ -0,0 +1,56 @@
+package member
+
+import "github.com/canyouhearthemusic/project-management/internal/domain"
+
+const (
+	RoleOwner       = "owner"
+	RoleMaintainer  = "maintainer"
+	RoleContributor = "contributor"
+	RoleViewer      = "viewer"
+)
+
+var ranks = map[string]int{
+	RoleViewer:      1,
+	RoleContributor: 2,
+	RoleMaintainer:  3,
+	RoleOwner:       4,
+}
+
+type Entity struct {
+	ProjectID string          `db:"project_id"`
+	UserID    string          `db:"user_id"`
+	Role      string          `db:"role"`
+	AddedAt   domain.OnlyDate `db:"added_at"`
+	Name      string          `db:"name"`
+	Email     string          `db:"email"`
+}
+
+func IsValidRole(role string) bool {
+	_, ok := ranks[role]
+	return ok
+}
+
+// Allows reports whether role grants at least the rights of min.
+func Allows(role, min string) bool {
+	return ranks[role] >= ranks[min]
+}
+
+var (
+	ErrNotFound   = &MemberError{"project member not found"}
+	ErrBadRequest = &MemberError{"project member bad request"}
+	ErrExists     = &MemberError{"user is already a member of the project"}
+	ErrLastOwner  = &MemberError{"project must keep at least one owner"}
+	ErrForbidden  = &MemberError{"insufficient project role"}
+)
+
+type MemberError struct {
+	message string
+}
+
+func (e *MemberError) Error() string {
+	return e.message
+}
+
+func (e *MemberError) Is(err error) bool {
+	return e == err
+}
@@ -0,0 +1,15 @@
+package member
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, projectID string) ([]Entity, error)
+	// ListByUser returns the memberships of a user across projects.
+	ListByUser(ctx context.Context, userID string) ([]Entity, error)
+	Get(ctx context.Context, projectID, userID string) (Entity, error)
+	Add(ctx context.Context, m Entity) error
+	// SetRole and Remove fail with ErrLastOwner when the change would leave
+	// the project without an owner.
+	SetRole(ctx context.Context, projectID, userID, role string) error
+	Remove(ctx context.Context, projectID, userID string) error
+}
@@ -0,0 +1,118 @@
+package milestone
+
+import (
+	"math"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+type Request struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	TargetDate  string `json:"target_date"`
+}
+
+type UpdateRequest struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	TargetDate  string `json:"target_date,omitempty"`
+}
+
+type TasksRequest struct {
+	TaskIDs []string `json:"task_ids"`
+}
+
+func (m *Request) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if m.Title == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "title is required", Field: "title"})
+	}
+	if len(m.Title) > 100 {
+		errs = append(errs, domain.ErrorResponse{Message: "title must be less than 100 characters", Field: "title"})
+	}
+
+	if len(m.Description) > 200 {
+		errs = append(errs, domain.ErrorResponse{Message: "description must be less than 200 characters", Field: "description"})
+	}
+
+	if _, err := time.Parse(domain.DateLayout, m.TargetDate); err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid target_date format", Field: "target_date"})
+	}
+
+	return errs
+}
+
+func (m *UpdateRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(m.Title) > 100 && m.Title != "" {
+		errs = append(errs, domain.ErrorResponse{Message: "title must be less than 100 characters", Field: "title"})
+	}
+
+	if len(m.Description) > 200 && m.Description != "" {
+		errs = append(errs, domain.ErrorResponse{Message: "description must be less than 200 characters", Field: "description"})
+	}
+
+	if _, err := time.Parse(domain.DateLayout, m.TargetDate); m.TargetDate != "" && err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid target_date format", Field: "target_date"})
+	}
+
+	return errs
+}
+
+func (m *TasksRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(m.TaskIDs) == 0 {
+		errs = append(errs, domain.ErrorResponse{Message: "task_ids is required", Field: "task_ids"})
+	}
+
+	return errs
+}
+
+type ProgressResponse struct {
+	Total         int     `json:"total"`
+	Done          int     `json:"done"`
+	Open          int     `json:"open"`
+	Percent       float64 `json:"percent"`
+	DaysRemaining int     `json:"days_remaining"`
+	ProjectedDays float64 `json:"projected_days"`
+	AtRisk        bool    `json:"at_risk"`
+}
+
+type Response struct {
+	ID          string           `json:"id"`
+	ProjectID   string           `json:"project_id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	TargetDate  string           `json:"target_date"`
+	CreatedAt   string           `json:"created_at"`
+	Progress    ProgressResponse `json:"progress"`
+}
+
+func ParseFromEntity(m Entity, p Progress) Response {
+	res := Response{
+		ID:          m.ID,
+		ProjectID:   m.ProjectID,
+		Title:       m.Title,
+		Description: m.Description,
+		TargetDate:  m.TargetDate.String(),
+		CreatedAt:   m.CreatedAt.String(),
+		Progress: ProgressResponse{
+			Total:         p.Total,
+			Done:          p.Done,
+			Open:          p.Open,
+			DaysRemaining: p.DaysRemaining,
+			ProjectedDays: math.Round(p.ProjectedDays*10) / 10,
+			AtRisk:        p.AtRisk,
+		},
+	}
+
+	if p.Total > 0 {
+		res.Progress.Percent = math.Round(float64(p.Done)/float64(p.Total)*10000) / 100
+	}
+
+	return res
+}
@@ -0,0 +1,83 @@
+package milestone
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+)
+
+type Entity struct {
+	ID          string
+	ProjectID   string `db:"project_id"`
+	Title       string
+	Description string
+	TargetDate  domain.OnlyDate `db:"target_date"`
+	CreatedAt   domain.OnlyDate `db:"created_at"`
+}
+
+type Progress struct {
+	Total         int
+	Done          int
+	Open          int
+	DaysRemaining int
+	ProjectedDays float64
+	AtRisk        bool
+}
+
+// ComputeProgress measures how far the milestone is and whether the open work
+// still fits before the target date. The throughput observed since the
+// milestone was created (at least one task a day until something is done)
+// projects the days needed for the open tasks; the milestone is at risk when
+// that exceeds the days remaining or the target date has passed.
+func ComputeProgress(m Entity, tasks []task.Entity, now time.Time) Progress {
+	var p Progress
+
+	for _, t := range tasks {
+		p.Total++
+		if t.Status == task.StatusDone {
+			p.Done++
+		}
+	}
+	p.Open = p.Total - p.Done
+
+	today, _ := time.Parse(domain.DateLayout, now.Format(domain.DateLayout))
+
+	target, err := time.Parse(domain.DateLayout, m.TargetDate.String())
+	if err != nil {
+		return p
+	}
+	p.DaysRemaining = int(target.Sub(today).Hours() / 24)
+
+	throughput := 1.0
+	if created, err := time.Parse(domain.DateLayout, m.CreatedAt.String()); err == nil && p.Done > 0 {
+		elapsed := today.Sub(created).Hours() / 24
+		if elapsed < 1 {
+			elapsed = 1
+		}
+		throughput = float64(p.Done) / elapsed
+	}
+	p.ProjectedDays = float64(p.Open) / throughput
+
+	p.AtRisk = p.Open > 0 && (p.DaysRemaining < 0 || p.ProjectedDays > float64(p.DaysRemaining))
+
+	return p
+}
+
+var (
+	ErrNotFound    = &MilestoneError{"milestone not found"}
+	ErrBadRequest  = &MilestoneError{"milestone bad request"}
+	ErrTaskProject = &MilestoneError{"task and milestone belong to different projects"}
+)
+
+type MilestoneError struct {
+	message string
+}
+
+func (e *MilestoneError) Error() string {
+	return e.message
+}
+
+func (e *MilestoneError) Is(err error) bool {
+	return e == err
+}
@@ -0,0 +1,53 @@
+package milestone
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+)
+
+// ReleaseNotes renders the completed tasks of the milestone as Markdown,
+// grouped by priority.
+func ReleaseNotes(m Entity, tasks []task.Entity) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", m.Title)
+	fmt.Fprintf(&b, "_Target date: %s_\n\n", m.TargetDate.String())
+
+	if m.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", m.Description)
+	}
+
+	sections := []struct {
+		priority string
+		heading  string
+	}{
+		{"high", "High priority"},
+		{"medium", "Medium priority"},
+		{"low", "Low priority"},
+	}
+
+	written := 0
+	for _, s := range sections {
+		var lines []string
+		for _, t := range tasks {
+			if t.Status == task.StatusDone && t.Priority == s.priority {
+				lines = append(lines, fmt.Sprintf("- %s", t.Title))
+			}
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", s.heading, strings.Join(lines, "\n"))
+		written += len(lines)
+	}
+
+	if written == 0 {
+		b.WriteString("No completed tasks yet.\n")
+	}
+
+	return b.String()
+}
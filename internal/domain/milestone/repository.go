@@ -0,0 +1,11 @@
+package milestone
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, m Entity) (string, Entity, error)
+	Get(ctx context.Context, id string) (Entity, error)
+	ListByProject(ctx context.Context, projectID string) ([]Entity, error)
+	Update(ctx context.Context, id string, m Entity) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,36 @@
+package notification
+
+import "time"
+
+type Response struct {
+	ID        string `json:"id"`
+	TaskID    string `json:"task_id,omitempty"`
+	Kind      string `json:"kind"`
+	Message   string `json:"message"`
+	CreatedAt string `json:"created_at"`
+	ReadAt    string `json:"read_at,omitempty"`
+}
+
+func ParseFromEntity(n Entity) Response {
+	res := Response{
+		ID:        n.ID,
+		TaskID:    n.TaskID,
+		Kind:      n.Kind,
+		Message:   n.Message,
+		CreatedAt: n.CreatedAt.Format(time.RFC3339),
+	}
+
+	if n.ReadAt != nil {
+		res.ReadAt = n.ReadAt.Format(time.RFC3339)
+	}
+
+	return res
+}
+
+func ParseFromEntities(notifications []Entity) []Response {
+	res := []Response{}
+	for _, n := range notifications {
+		res = append(res, ParseFromEntity(n))
+	}
+	return res
+}
@@ -0,0 +1,124 @@
+package notification
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+)
+
+const (
+	KindReminder   = "reminder"
+	KindOverdue    = "overdue"
+	KindEscalation = "escalation"
+)
+
+// Entity is an in-app notification. DedupKey identifies the event it was
+// raised for and its recipient, so the same event never notifies a user twice
+// and a new assignee or manager is still notified.
+type Entity struct {
+	ID        string
+	UserID    string `db:"user_id"`
+	TaskID    string `db:"task_id"`
+	Kind      string
+	Message   string
+	DedupKey  string     `db:"dedup_key"`
+	CreatedAt time.Time  `db:"created_at"`
+	ReadAt    *time.Time `db:"read_at"`
+}
+
+// Schedule configures when due-date notifications are raised.
+type Schedule struct {
+	// LeadDays are the days before the due date at which the assignee is reminded.
+	LeadDays []int
+	// EscalateAfterDays is how many days overdue a task must be before the
+	// project manager is notified.
+	EscalateAfterDays int
+}
+
+// Plan returns the notifications a not yet done task is due for today.
+// Only the closest lead time that has been reached produces a reminder, so a
+// task first seen one day before its due date does not also get the earlier
+// reminders.
+func Plan(t task.Entity, managerID string, today time.Time, s Schedule) []Entity {
+	due, err := time.Parse(domain.DateLayout, t.DoneAt.String())
+	if err != nil || t.Status == task.StatusDone {
+		return nil
+	}
+
+	days := int(due.Sub(today).Hours() / 24)
+
+	var res []Entity
+	if days >= 0 {
+		lead := append([]int(nil), s.LeadDays...)
+		sort.Ints(lead)
+
+		for _, l := range lead {
+			if days > l || t.AuthorID == "" {
+				continue
+			}
+
+			res = append(res, Entity{
+				UserID:   t.AuthorID,
+				TaskID:   t.ID,
+				Kind:     KindReminder,
+				Message:  fmt.Sprintf("task %q is due on %s", t.Title, t.DoneAt.String()),
+				DedupKey: fmt.Sprintf("%s:%s:%s:%d:%s", t.AuthorID, t.ID, KindReminder, l, t.DoneAt.String()),
+			})
+			break
+		}
+
+		return res
+	}
+
+	if t.AuthorID != "" {
+		res = append(res, Entity{
+			UserID:   t.AuthorID,
+			TaskID:   t.ID,
+			Kind:     KindOverdue,
+			Message:  fmt.Sprintf("task %q was due on %s", t.Title, t.DoneAt.String()),
+			DedupKey: fmt.Sprintf("%s:%s:%s:%s", t.AuthorID, t.ID, KindOverdue, t.DoneAt.String()),
+		})
+	}
+
+	if managerID != "" && -days >= s.EscalateAfterDays {
+		res = append(res, Entity{
+			UserID:   managerID,
+			TaskID:   t.ID,
+			Kind:     KindEscalation,
+			Message:  fmt.Sprintf("task %q is %d day(s) overdue", t.Title, -days),
+			DedupKey: fmt.Sprintf("%s:%s:%s:%s", managerID, t.ID, KindEscalation, t.DoneAt.String()),
+		})
+	}
+
+	return res
+}
+
+// Horizon returns the latest due date that can produce a notification today.
+func (s Schedule) Horizon(today time.Time) time.Time {
+	max := 0
+	for _, l := range s.LeadDays {
+		if l > max {
+			max = l
+		}
+	}
+	return today.AddDate(0, 0, max)
+}
+
+var (
+	ErrNotFound = &NotificationError{"notification not found"}
+)
+
+type NotificationError struct {
+	message string
+}
+
+func (e *NotificationError) Error() string {
+	return e.message
+}
+
+func (e *NotificationError) Is(err error) bool {
+	return e == err
+}
@@ -0,0 +1,11 @@
+package notification
+
+import "context"
+
+type Repository interface {
+	// Create stores the notification unless one with the same DedupKey
+	// exists and reports whether it was stored.
+	Create(ctx context.Context, n Entity) (bool, error)
+	ListByUser(ctx context.Context, userID string, unreadOnly bool) ([]Entity, error)
+	MarkRead(ctx context.Context, userID, id string) error
+}
@@ -0,0 +1,49 @@
+package organization
+
+import "github.com/canyouhearthemusic/project-management/internal/domain"
+
+type Request struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+func (o *Request) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if o.Name == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "name is required", Field: "name"})
+	}
+	if len(o.Name) > 100 {
+		errs = append(errs, domain.ErrorResponse{Message: "name must be less than 100 characters", Field: "name"})
+	}
+
+	if !IsValidSlug(o.Slug) {
+		errs = append(errs, domain.ErrorResponse{Message: "slug must be 3 to 63 lowercase letters, digits or dashes", Field: "slug"})
+	}
+
+	return errs
+}
+
+type Response struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	CreatedAt string `json:"created_at"`
+}
+
+func ParseFromEntity(o Entity) Response {
+	return Response{
+		ID:        o.ID,
+		Name:      o.Name,
+		Slug:      o.Slug,
+		CreatedAt: o.CreatedAt.String(),
+	}
+}
+
+func ParseFromEntities(organizations []Entity) []Response {
+	res := []Response{}
+	for _, o := range organizations {
+		res = append(res, ParseFromEntity(o))
+	}
+	return res
+}
@@ -0,0 +1,43 @@
+package organization
+
+import (
+	"regexp"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+// DefaultSlug names the organization that serves requests which do not
+// name one.
+const DefaultSlug = "default"
+
+type Entity struct {
+	ID        string
+	Name      string
+	Slug      string
+	CreatedAt domain.OnlyDate `db:"created_at"`
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{1,61}[a-z0-9])?$`)
+
+// IsValidSlug reports whether slug can be used as a subdomain.
+func IsValidSlug(slug string) bool {
+	return slugPattern.MatchString(slug)
+}
+
+var (
+	ErrNotFound   = &OrganizationError{"organization not found"}
+	ErrExists     = &OrganizationError{"organization already exists"}
+	ErrBadRequest = &OrganizationError{"organization bad request"}
+)
+
+type OrganizationError struct {
+	message string
+}
+
+func (e *OrganizationError) Error() string {
+	return e.message
+}
+
+func (e *OrganizationError) Is(err error) bool {
+	return e == err
+}
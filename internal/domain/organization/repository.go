@@ -0,0 +1,12 @@
+package organization
+
+import "context"
+
+// Repository is not tenant scoped: organizations are what requests are
+// scoped to.
+type Repository interface {
+	Create(ctx context.Context, o Entity) error
+	Get(ctx context.Context, id string) (Entity, error)
+	GetBySlug(ctx context.Context, slug string) (Entity, error)
+	List(ctx context.Context) ([]Entity, error)
+}
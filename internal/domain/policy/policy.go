@@ -0,0 +1,102 @@
+package policy
+
+import "github.com/canyouhearthemusic/project-management/internal/domain/user"
+
+// Permission is an action on one kind of resource across the organization.
+// Project membership roles still apply on top of them.
+type Permission string
+
+const (
+	UsersRead   Permission = "users:read"
+	UsersManage Permission = "users:manage"
+
+	ProjectsRead   Permission = "projects:read"
+	ProjectsCreate Permission = "projects:create"
+	// ProjectsWrite changes, archives and deletes projects and their members,
+	// as far as the role in the project allows.
+	ProjectsWrite Permission = "projects:write"
+	// ProjectsAdmin acts as an owner of every project, member or not.
+	ProjectsAdmin Permission = "projects:admin"
+
+	TasksRead  Permission = "tasks:read"
+	TasksWrite Permission = "tasks:write"
+
+	// OrganizationsManage creates and lists the organizations of the
+	// instance. It only applies in the default organization, whose admins
+	// run the instance.
+	OrganizationsManage Permission = "organizations:manage"
+
+	// FinanceManage sets and reads what users are paid per hour.
+	FinanceManage Permission = "finance:manage"
+
+	TemplatesRead Permission = "templates:read"
+	// TemplatesManage saves, clones and deletes the templates everybody
+	// creates projects and tasks from.
+	TemplatesManage Permission = "templates:manage"
+
+	EpicsRead Permission = "epics:read"
+	// EpicsManage creates, renames and deletes epics and links tasks to
+	// them, which also takes a contributor of the tasks' projects.
+	EpicsManage Permission = "epics:manage"
+
+	TeamsRead Permission = "teams:read"
+	// TeamsManage creates and deletes teams, changes who is in them and
+	// assigns projects and tasks to them, which also takes a role in the
+	// projects.
+	TeamsManage Permission = "teams:manage"
+)
+
+var permissions = map[string][]Permission{
+	user.RoleAdmin: {
+		UsersRead, UsersManage,
+		ProjectsRead, ProjectsCreate, ProjectsWrite, ProjectsAdmin,
+		TasksRead, TasksWrite,
+		TemplatesRead, TemplatesManage,
+		EpicsRead, EpicsManage,
+		TeamsRead, TeamsManage,
+		FinanceManage,
+		OrganizationsManage,
+	},
+	user.RoleManager: {
+		UsersRead,
+		ProjectsRead, ProjectsCreate, ProjectsWrite,
+		TasksRead, TasksWrite,
+		TemplatesRead, TemplatesManage,
+		EpicsRead, EpicsManage,
+		TeamsRead, TeamsManage,
+	},
+	user.RoleDeveloper: {
+		UsersRead,
+		ProjectsRead, ProjectsWrite,
+		TasksRead, TasksWrite,
+		TemplatesRead,
+		EpicsRead,
+		TeamsRead,
+	},
+}
+
+// Allows reports whether role grants perm. Unknown roles, and the empty role
+// of anonymous requests, grant nothing.
+func Allows(role string, perm Permission) bool {
+	for _, p := range permissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+
+	return false
+}
+
+var ErrForbidden = &PolicyError{"role does not permit this action"}
+
+type PolicyError struct {
+	message string
+}
+
+func (e *PolicyError) Error() string {
+	return e.message
+}
+
+func (e *PolicyError) Is(err error) bool {
+	return e == err
+}
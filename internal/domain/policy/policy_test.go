@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+)
+
+// matrix lists, for every permission, the roles granting it. Roles left out
+// must not grant it.
+var matrix = map[Permission][]string{
+	UsersRead:   {user.RoleAdmin, user.RoleManager, user.RoleDeveloper},
+	UsersManage: {user.RoleAdmin},
+
+	ProjectsRead:   {user.RoleAdmin, user.RoleManager, user.RoleDeveloper},
+	ProjectsCreate: {user.RoleAdmin, user.RoleManager},
+	ProjectsWrite:  {user.RoleAdmin, user.RoleManager, user.RoleDeveloper},
+	ProjectsAdmin:  {user.RoleAdmin},
+
+	TasksRead:  {user.RoleAdmin, user.RoleManager, user.RoleDeveloper},
+	TasksWrite: {user.RoleAdmin, user.RoleManager, user.RoleDeveloper},
+
+	OrganizationsManage: {user.RoleAdmin},
+	FinanceManage:       {user.RoleAdmin},
+
+	TemplatesRead:   {user.RoleAdmin, user.RoleManager, user.RoleDeveloper},
+	TemplatesManage: {user.RoleAdmin, user.RoleManager},
+
+	EpicsRead:   {user.RoleAdmin, user.RoleManager, user.RoleDeveloper},
+	EpicsManage: {user.RoleAdmin, user.RoleManager},
+
+	TeamsRead:   {user.RoleAdmin, user.RoleManager, user.RoleDeveloper},
+	TeamsManage: {user.RoleAdmin, user.RoleManager},
+}
+
+// roles holds the empty role of anonymous requests and an unknown one
+// besides the roles users can have.
+var roles = []string{user.RoleAdmin, user.RoleManager, user.RoleDeveloper, "", "owner"}
+
+func TestAllows(t *testing.T) {
+	for perm, granted := range matrix {
+		for _, role := range roles {
+			want := false
+			for _, r := range granted {
+				want = want || r == role
+			}
+
+			if got := Allows(role, perm); got != want {
+				t.Errorf("Allows(%q, %q) = %v, want %v", role, perm, got, want)
+			}
+		}
+	}
+}
+
+// TestMatrixComplete keeps the matrix above in step with the permissions the
+// roles grant, so a new permission cannot go untested.
+func TestMatrixComplete(t *testing.T) {
+	for role, perms := range permissions {
+		for _, perm := range perms {
+			if _, ok := matrix[perm]; !ok {
+				t.Errorf("role %q grants %q, which the matrix does not list", role, perm)
+			}
+		}
+	}
+}
@@ -12,6 +12,10 @@ type Request struct {
 	FinishedAt  string `json:"finished_at"`
 	StartedAt   string `json:"started_at"`
 	ManagerID   string `json:"manager_id"`
+	// BudgetAmount is optional and requires BudgetCurrency, an ISO 4217 code.
+	BudgetAmount   float64 `json:"budget_amount"`
+	BudgetCurrency string  `json:"budget_currency"`
+	TeamID         string  `json:"team_id,omitempty"`
 }
 
 type UpdateRequest struct {
@@ -19,6 +23,10 @@ type UpdateRequest struct {
 	Description string `json:"description,omitempty"`
 	FinishedAt  string `json:"finished_at,omitempty"`
 	ManagerID   string `json:"manager_id,omitempty"`
+	// BudgetAmount and BudgetCurrency are changed together.
+	BudgetAmount   float64 `json:"budget_amount,omitempty"`
+	BudgetCurrency string  `json:"budget_currency,omitempty"`
+	TeamID         string  `json:"team_id,omitempty"`
 }
 
 func (p *Request) Validate() []domain.ErrorResponse {
@@ -40,6 +48,14 @@ func (p *Request) Validate() []domain.ErrorResponse {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid finished_at format", Field: "finished_at"})
 	}
 
+	if p.BudgetAmount < 0 {
+		errs = append(errs, domain.ErrorResponse{Message: "budget_amount must not be negative", Field: "budget_amount"})
+	}
+
+	if (p.BudgetAmount > 0 || p.BudgetCurrency != "") && !domain.IsValidCurrency(p.BudgetCurrency) {
+		errs = append(errs, domain.ErrorResponse{Message: "budget_currency must be a three-letter ISO 4217 code", Field: "budget_currency"})
+	}
+
 	return errs
 }
 
@@ -58,26 +74,50 @@ func (p *UpdateRequest) Validate() []domain.ErrorResponse {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid finished_at format", Field: "finished_at"})
 	}
 
+	if p.BudgetAmount < 0 {
+		errs = append(errs, domain.ErrorResponse{Message: "budget_amount must not be negative", Field: "budget_amount"})
+	}
+
+	if (p.BudgetAmount > 0) != (p.BudgetCurrency != "") {
+		errs = append(errs, domain.ErrorResponse{Message: "budget_amount and budget_currency must be set together", Field: "budget_currency"})
+	} else if p.BudgetCurrency != "" && !domain.IsValidCurrency(p.BudgetCurrency) {
+		errs = append(errs, domain.ErrorResponse{Message: "budget_currency must be a three-letter ISO 4217 code", Field: "budget_currency"})
+	}
+
 	return errs
 }
 
 type Response struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	FinishedAt  string `json:"finished_at"`
-	StartedAt   string `json:"started_at"`
-	ManagerID   string `json:"manager_id"`
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Description    string   `json:"description"`
+	FinishedAt     string   `json:"finished_at"`
+	StartedAt      string   `json:"started_at"`
+	ManagerID      string   `json:"manager_id"`
+	Status         string   `json:"status"`
+	BudgetAmount   float64  `json:"budget_amount"`
+	BudgetCurrency string   `json:"budget_currency"`
+	TeamID         string   `json:"team_id,omitempty"`
+	Health         string   `json:"health"`
+	HealthScore    int      `json:"health_score"`
+	HealthReasons  []string `json:"health_reasons"`
 }
 
 func ParseFromEntity(p Entity) Response {
 	return Response{
-		ID:          p.ID,
-		Title:       p.Title,
-		Description: p.Description,
-		FinishedAt:  p.FinishedAt.String(),
-		StartedAt:   p.StartedAt.String(),
-		ManagerID:   p.ManagerID,
+		ID:             p.ID,
+		Title:          p.Title,
+		Description:    p.Description,
+		FinishedAt:     p.FinishedAt.String(),
+		StartedAt:      p.StartedAt.String(),
+		ManagerID:      p.ManagerID,
+		Status:         p.Status,
+		BudgetAmount:   p.BudgetAmount,
+		BudgetCurrency: p.BudgetCurrency,
+		TeamID:         p.TeamID,
+		Health:         p.Health,
+		HealthScore:    p.HealthScore,
+		HealthReasons:  append([]string{}, p.HealthReasons...),
 	}
 }
 
@@ -1,6 +1,9 @@
 package project
 
-import "github.com/canyouhearthemusic/project-management/internal/domain"
+import (
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/lib/pq"
+)
 
 type Entity struct {
 	ID          string
@@ -9,6 +12,17 @@ type Entity struct {
 	StartedAt   domain.OnlyDate `db:"started_at"`
 	FinishedAt  domain.OnlyDate `db:"finished_at"`
 	ManagerID   string          `db:"manager_id"`
+	Status      string
+	// BudgetAmount is in BudgetCurrency; a project without a budget has
+	// an amount of zero and no currency.
+	BudgetAmount   float64 `db:"budget_amount"`
+	BudgetCurrency string  `db:"budget_currency"`
+	TeamID         string  `db:"team_id"`
+	// Health, HealthScore and HealthReasons are the last Assess of the
+	// project, kept up to date by the service.
+	Health        string         `db:"health"`
+	HealthScore   int            `db:"health_score"`
+	HealthReasons pq.StringArray `db:"health_reasons"`
 }
 
 var (
@@ -16,6 +30,10 @@ var (
 	ErrNotFound   = &ProjectError{"project not found"}
 	ErrSearch     = &ProjectError{"project search error"}
 	ErrBadRequest = &ProjectError{"project bad request"}
+
+	ErrArchived      = &ProjectError{"project is archived and read-only"}
+	ErrTransition    = &ProjectError{"transition is not allowed from the current project status"}
+	ErrUnknownAction = &ProjectError{"unknown project transition"}
 )
 
 func IsValidFilter(filter string) bool {
@@ -0,0 +1,161 @@
+package project
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+const (
+	HealthGreen = "green"
+	HealthAmber = "amber"
+	HealthRed   = "red"
+)
+
+// Scores at or above these thresholds are green or amber, anything below
+// amber is red.
+const (
+	greenScore = 75
+	amberScore = 50
+)
+
+// Signals are the task counts a project's health is assessed from.
+type Signals struct {
+	Total      int
+	Done       int
+	Overdue    int
+	High       int
+	HighActive int `db:"high_active"`
+}
+
+type Health struct {
+	Status  string
+	Score   int
+	Reasons []string
+}
+
+// Assess scores a project from 100 down to 0 by three risks:
+//   - the share of its open tasks that are overdue, weighted 0.4
+//   - how far completion lags behind the share of the schedule elapsed
+//     toward finished_at, weighted 0.4
+//   - the share of its high priority tasks that are still active, not yet
+//     started, weighted 0.2
+//
+// Reasons name every risk that took points off the score.
+func Assess(p Entity, s Signals, now time.Time) Health {
+	h := Health{Reasons: []string{}}
+
+	open := s.Total - s.Done
+
+	overdue := ratio(s.Overdue, open)
+	if s.Overdue > 0 {
+		h.Reasons = append(h.Reasons, fmt.Sprintf("%d of %d open tasks are overdue", s.Overdue, open))
+	}
+
+	completion := ratio(s.Done, s.Total)
+	elapsed := elapsed(p, now)
+	lag := 0.0
+	if s.Total > 0 && elapsed > completion {
+		lag = elapsed - completion
+		h.Reasons = append(h.Reasons, fmt.Sprintf("%.0f%% of tasks are done with %.0f%% of the schedule elapsed", completion*100, elapsed*100))
+	}
+
+	highActive := ratio(s.HighActive, s.High)
+	if s.HighActive > 0 {
+		h.Reasons = append(h.Reasons, fmt.Sprintf("%d of %d high priority tasks are not started", s.HighActive, s.High))
+	}
+
+	h.Score = int(math.Round(100 * (1 - 0.4*overdue - 0.4*lag - 0.2*highActive)))
+
+	switch {
+	case h.Score >= greenScore:
+		h.Status = HealthGreen
+	case h.Score >= amberScore:
+		h.Status = HealthAmber
+	default:
+		h.Status = HealthRed
+	}
+
+	return h
+}
+
+// elapsed is the share of the project's days from started_at through
+// finished_at that have passed by now, between 0 and 1.
+func elapsed(p Entity, now time.Time) float64 {
+	start, err := time.Parse(domain.DateLayout, p.StartedAt.String())
+	if err != nil {
+		return 0
+	}
+
+	finish, err := time.Parse(domain.DateLayout, p.FinishedAt.String())
+	if err != nil {
+		return 0
+	}
+
+	today, _ := time.Parse(domain.DateLayout, now.Format(domain.DateLayout))
+
+	days := finish.Sub(start).Hours()/24 + 1
+	if days <= 0 {
+		return 1
+	}
+
+	return math.Max(0, math.Min(1, today.Sub(start).Hours()/24/days))
+}
+
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+type HealthResponse struct {
+	ProjectID string   `json:"project_id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Health    string   `json:"health"`
+	Score     int      `json:"score"`
+	Reasons   []string `json:"reasons"`
+}
+
+type PortfolioResponse struct {
+	Green    int              `json:"green"`
+	Amber    int              `json:"amber"`
+	Red      int              `json:"red"`
+	Projects []HealthResponse `json:"projects"`
+}
+
+// ParseFromPortfolio counts the projects per health and lists them worst
+// score first.
+func ParseFromPortfolio(projects []Entity) PortfolioResponse {
+	res := PortfolioResponse{Projects: []HealthResponse{}}
+
+	for _, p := range projects {
+		switch p.Health {
+		case HealthGreen:
+			res.Green++
+		case HealthAmber:
+			res.Amber++
+		case HealthRed:
+			res.Red++
+		}
+
+		res.Projects = append(res.Projects, HealthResponse{
+			ProjectID: p.ID,
+			Title:     p.Title,
+			Status:    p.Status,
+			Health:    p.Health,
+			Score:     p.HealthScore,
+			Reasons:   append([]string{}, p.HealthReasons...),
+		})
+	}
+
+	sort.SliceStable(res.Projects, func(i, j int) bool {
+		return res.Projects[i].Score < res.Projects[j].Score
+	})
+
+	return res
+}
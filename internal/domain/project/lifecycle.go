@@ -0,0 +1,73 @@
+package project
+
+import (
+	"slices"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+const (
+	StatusPlanned   = "planned"
+	StatusActive    = "active"
+	StatusOnHold    = "on_hold"
+	StatusCompleted = "completed"
+	StatusArchived  = "archived"
+)
+
+const (
+	ActionStart     = "start"
+	ActionHold      = "hold"
+	ActionResume    = "resume"
+	ActionComplete  = "complete"
+	ActionReopen    = "reopen"
+	ActionArchive   = "archive"
+	ActionUnarchive = "unarchive"
+)
+
+type transition struct {
+	from []string
+	to   string
+}
+
+// Unarchiving brings a project back as completed; reopen it to work on it again.
+var transitions = map[string]transition{
+	ActionStart:     {from: []string{StatusPlanned}, to: StatusActive},
+	ActionHold:      {from: []string{StatusActive}, to: StatusOnHold},
+	ActionResume:    {from: []string{StatusOnHold}, to: StatusActive},
+	ActionComplete:  {from: []string{StatusActive, StatusOnHold}, to: StatusCompleted},
+	ActionReopen:    {from: []string{StatusCompleted}, to: StatusActive},
+	ActionArchive:   {from: []string{StatusPlanned, StatusActive, StatusOnHold, StatusCompleted}, to: StatusArchived},
+	ActionUnarchive: {from: []string{StatusArchived}, to: StatusCompleted},
+}
+
+func IsValidStatus(status string) bool {
+	switch status {
+	case StatusPlanned, StatusActive, StatusOnHold, StatusCompleted, StatusArchived:
+		return true
+	}
+	return false
+}
+
+// Transition returns the status a project in status moves to by action.
+func Transition(status, action string) (string, error) {
+	t, ok := transitions[action]
+	if !ok {
+		return "", ErrUnknownAction
+	}
+
+	if !slices.Contains(t.from, status) {
+		return "", ErrTransition
+	}
+
+	return t.to, nil
+}
+
+// InitialStatus is planned for projects starting after today and active otherwise.
+func InitialStatus(startedAt string, today time.Time) string {
+	start, err := time.Parse(domain.DateLayout, startedAt)
+	if err == nil && start.After(today) {
+		return StatusPlanned
+	}
+	return StatusActive
+}
@@ -5,8 +5,18 @@ import "context"
 type Repository interface {
 	Create(context.Context, Entity) (string, Entity, error)
 	Search(ctx context.Context, filter, value string) ([]Entity, error)
-	List(ctx context.Context) ([]Entity, error)
+	List(ctx context.Context, includeArchived bool) ([]Entity, error)
 	Get(ctx context.Context, id string) (Entity, error)
 	Update(ctx context.Context, id string, p Entity) error
 	Delete(ctx context.Context, id string) error
+	// SetStatus moves the project from one status to another and fails with
+	// ErrTransition when its status is no longer from.
+	SetStatus(ctx context.Context, id, from, to string) error
+	// Stats aggregates the project's tasks in the database.
+	Stats(ctx context.Context, id string) (Stats, error)
+	ListByTeam(ctx context.Context, teamID string) ([]Entity, error)
+	// SetTeam assigns the project to a team, an empty teamID unassigns it.
+	SetTeam(ctx context.Context, id, teamID string) error
+	Signals(ctx context.Context, id string) (Signals, error)
+	SetHealth(ctx context.Context, id string, h Health) error
 }
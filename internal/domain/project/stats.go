@@ -0,0 +1,93 @@
+package project
+
+import (
+	"math"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+// Count is the number of tasks sharing a status and priority.
+type Count struct {
+	Status   string
+	Priority string
+	Count    int
+	Overdue  int
+}
+
+// BurnPoint is the project's scope and completed work at the end of a day.
+type BurnPoint struct {
+	Day       domain.OnlyDate
+	Scope     int
+	Completed int
+}
+
+// Stats holds the aggregates the repository computes for a project. Burn
+// covers the days from the project start up to today or its finish date,
+// whichever comes first.
+type Stats struct {
+	Counts []Count
+	Burn   []BurnPoint
+	// Days is the length of the whole project window, used for the ideal line.
+	Days int
+}
+
+type BurnPointResponse struct {
+	Date           string  `json:"date"`
+	Scope          int     `json:"scope"`
+	Completed      int     `json:"completed"`
+	Remaining      int     `json:"remaining"`
+	IdealRemaining float64 `json:"ideal_remaining"`
+}
+
+type StatsResponse struct {
+	Total             int                 `json:"total"`
+	ByStatus          map[string]int      `json:"by_status"`
+	ByPriority        map[string]int      `json:"by_priority"`
+	CompletionPercent float64             `json:"completion_percent"`
+	Overdue           int                 `json:"overdue"`
+	Series            []BurnPointResponse `json:"series"`
+}
+
+// ParseFromStats totals the counts and adds the remaining work and an ideal
+// burndown, which goes linearly from the current scope to zero at the
+// project's finish date.
+func ParseFromStats(s Stats) StatsResponse {
+	res := StatsResponse{
+		ByStatus:   map[string]int{},
+		ByPriority: map[string]int{},
+		Series:     []BurnPointResponse{},
+	}
+
+	done := 0
+	for _, c := range s.Counts {
+		res.Total += c.Count
+		res.Overdue += c.Overdue
+		res.ByStatus[c.Status] += c.Count
+		res.ByPriority[c.Priority] += c.Count
+
+		if c.Status == "done" {
+			done += c.Count
+		}
+	}
+
+	if res.Total > 0 {
+		res.CompletionPercent = math.Round(float64(done)/float64(res.Total)*10000) / 100
+	}
+
+	for i, p := range s.Burn {
+		ideal := float64(res.Total)
+		if s.Days > 1 {
+			ideal = float64(res.Total) * float64(s.Days-1-i) / float64(s.Days-1)
+		}
+
+		res.Series = append(res.Series, BurnPointResponse{
+			Date:           p.Day.String(),
+			Scope:          p.Scope,
+			Completed:      p.Completed,
+			Remaining:      p.Scope - p.Completed,
+			IdealRemaining: math.Round(ideal*100) / 100,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,129 @@
+package projecttemplate
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+)
+
+// SaveRequest saves an existing project as a template.
+type SaveRequest struct {
+	Name string `json:"name"`
+	// Description defaults to the project's description.
+	Description string `json:"description,omitempty"`
+}
+
+// InstantiateRequest creates a project from a template or clones a project.
+// Task dates are shifted to the new start date.
+type InstantiateRequest struct {
+	Title string `json:"title"`
+	// Description defaults to the template's or source project's description.
+	Description string `json:"description,omitempty"`
+	ManagerID   string `json:"manager_id"`
+	StartedAt   string `json:"started_at"`
+}
+
+func (t *SaveRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if t.Name == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "name is required", Field: "name"})
+	}
+	if len(t.Name) > 100 {
+		errs = append(errs, domain.ErrorResponse{Message: "name must be less than 100 characters", Field: "name"})
+	}
+
+	if len(t.Description) > 200 {
+		errs = append(errs, domain.ErrorResponse{Message: "description must be less than 200 characters", Field: "description"})
+	}
+
+	return errs
+}
+
+func (t *InstantiateRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if t.Title == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "title is required", Field: "title"})
+	}
+	if len(t.Title) > 100 {
+		errs = append(errs, domain.ErrorResponse{Message: "title must be less than 100 characters", Field: "title"})
+	}
+
+	if len(t.Description) > 200 {
+		errs = append(errs, domain.ErrorResponse{Message: "description must be less than 200 characters", Field: "description"})
+	}
+
+	if t.ManagerID == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "manager_id is required", Field: "manager_id"})
+	}
+
+	if _, err := time.Parse(domain.DateLayout, t.StartedAt); err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid started_at format", Field: "started_at"})
+	}
+
+	return errs
+}
+
+type TaskResponse struct {
+	Position     int     `json:"position"`
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	Priority     string  `json:"priority"`
+	Status       string  `json:"status"`
+	StartOffset  int     `json:"start_offset_days"`
+	DueOffset    int     `json:"due_offset_days"`
+	Predecessors []int64 `json:"predecessors"`
+}
+
+type Response struct {
+	ID              string         `json:"id"`
+	Name            string         `json:"name"`
+	Description     string         `json:"description"`
+	DurationDays    int            `json:"duration_days"`
+	SourceProjectID string         `json:"source_project_id,omitempty"`
+	CreatedAt       string         `json:"created_at"`
+	Tasks           []TaskResponse `json:"tasks,omitempty"`
+}
+
+// ProjectResponse is the project created from a template with its tasks.
+type ProjectResponse struct {
+	Project project.Response `json:"project"`
+	Tasks   []task.Response  `json:"tasks"`
+}
+
+func ParseFromEntity(t Entity) Response {
+	res := Response{
+		ID:              t.ID,
+		Name:            t.Name,
+		Description:     t.Description,
+		DurationDays:    t.DurationDays,
+		SourceProjectID: t.SourceProjectID,
+		CreatedAt:       t.CreatedAt.String(),
+	}
+
+	for _, tt := range t.Tasks {
+		res.Tasks = append(res.Tasks, TaskResponse{
+			Position:     tt.Position,
+			Title:        tt.Title,
+			Description:  tt.Description,
+			Priority:     tt.Priority,
+			Status:       tt.Status,
+			StartOffset:  tt.StartOffset,
+			DueOffset:    tt.DueOffset,
+			Predecessors: append([]int64{}, tt.Predecessors...),
+		})
+	}
+
+	return res
+}
+
+func ParseFromEntities(templates []Entity) []Response {
+	res := []Response{}
+	for _, t := range templates {
+		res = append(res, ParseFromEntity(t))
+	}
+	return res
+}
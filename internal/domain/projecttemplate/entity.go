@@ -0,0 +1,151 @@
+package projecttemplate
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/lib/pq"
+)
+
+// Entity is a reusable project outline. Its tasks are scheduled relative to
+// the start of the project created from it.
+type Entity struct {
+	ID              string
+	Name            string
+	Description     string
+	DurationDays    int             `db:"duration_days"`
+	SourceProjectID string          `db:"source_project_id"`
+	CreatedAt       domain.OnlyDate `db:"created_at"`
+	Tasks           []Task          `db:"-"`
+}
+
+type Task struct {
+	TemplateID  string `db:"template_id"`
+	Position    int
+	Title       string
+	Description string
+	Priority    string
+	Status      string
+	StartOffset int `db:"start_offset"`
+	DueOffset   int `db:"due_offset"`
+	// Predecessors are positions of other tasks in the template.
+	Predecessors pq.Int64Array
+}
+
+// FromProject captures p and its tasks as a template, with task dates turned
+// into offsets from the project's start.
+func FromProject(p project.Entity, tasks []task.Entity) (Entity, error) {
+	sorted, err := task.TopoSort(tasks)
+	if err != nil {
+		return Entity{}, err
+	}
+
+	start := parseDate(p.StartedAt)
+
+	t := Entity{
+		Description:     p.Description,
+		DurationDays:    days(parseDate(p.FinishedAt).Sub(start)),
+		SourceProjectID: p.ID,
+	}
+
+	positions := make(map[string]int, len(sorted))
+	for i, s := range sorted {
+		positions[s.ID] = i
+	}
+
+	for i, s := range sorted {
+		tt := Task{
+			Position:     i,
+			Title:        s.Title,
+			Description:  s.Description,
+			Priority:     s.Priority,
+			Status:       s.Status,
+			StartOffset:  days(parseDate(s.StartAt).Sub(start)),
+			DueOffset:    days(parseDate(s.DoneAt).Sub(start)),
+			Predecessors: pq.Int64Array{},
+		}
+
+		for _, id := range s.PredecessorIDs {
+			if pos, ok := positions[id]; ok {
+				tt.Predecessors = append(tt.Predecessors, int64(pos))
+			}
+		}
+
+		t.Tasks = append(t.Tasks, tt)
+	}
+
+	return t, nil
+}
+
+// Instantiate schedules the template's tasks for a project starting at start.
+// newID supplies the IDs of the tasks so predecessors can be linked up.
+func (t Entity) Instantiate(projectID string, start time.Time, newID func() string) []task.Entity {
+	ids := make([]string, len(t.Tasks))
+	for i := range t.Tasks {
+		ids[i] = newID()
+	}
+
+	today := domain.OnlyDate(time.Now().Format(domain.DateLayout))
+
+	tasks := make([]task.Entity, len(t.Tasks))
+	for i, tt := range t.Tasks {
+		predecessors := []string{}
+		for _, pos := range tt.Predecessors {
+			if int(pos) < len(ids) && int(pos) != i {
+				predecessors = append(predecessors, ids[pos])
+			}
+		}
+
+		tasks[i] = task.Entity{
+			ID:             ids[i],
+			Title:          tt.Title,
+			Description:    tt.Description,
+			Priority:       tt.Priority,
+			Status:         tt.Status,
+			ProjectID:      projectID,
+			CreatedAt:      today,
+			StartAt:        shift(start, tt.StartOffset),
+			DoneAt:         shift(start, tt.DueOffset),
+			PredecessorIDs: predecessors,
+		}
+	}
+
+	return tasks
+}
+
+// FinishedAt is the finish date of a project created from t starting at start.
+func (t Entity) FinishedAt(start time.Time) string {
+	return string(shift(start, t.DurationDays))
+}
+
+func parseDate(d domain.OnlyDate) time.Time {
+	t, _ := time.Parse(domain.DateLayout, string(d))
+	return t
+}
+
+func shift(start time.Time, offset int) domain.OnlyDate {
+	return domain.OnlyDate(start.AddDate(0, 0, offset).Format(domain.DateLayout))
+}
+
+func days(d time.Duration) int {
+	return int(d.Hours() / 24)
+}
+
+var (
+	ErrNotFound   = &TemplateError{"project template not found"}
+	ErrBadRequest = &TemplateError{"project template bad request"}
+)
+
+type TemplateError struct {
+	message string
+}
+
+func (e *TemplateError) Error() string {
+	return e.message
+}
+
+func (e *TemplateError) Is(err error) bool {
+	return e == err
+}
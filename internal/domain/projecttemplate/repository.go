@@ -0,0 +1,12 @@
+package projecttemplate
+
+import "context"
+
+type Repository interface {
+	// Create stores the template together with its tasks.
+	Create(ctx context.Context, t Entity) (string, Entity, error)
+	// Get loads the template with its tasks.
+	Get(ctx context.Context, id string) (Entity, error)
+	List(ctx context.Context) ([]Entity, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,36 @@
+package session
+
+import "time"
+
+type Response struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	ExpiresAt  string `json:"expires_at"`
+	// Current is set for the session the request is made in.
+	Current bool `json:"current"`
+}
+
+func ParseFromEntity(s Session, current string) Response {
+	return Response{
+		ID:         s.ID,
+		UserAgent:  s.UserAgent,
+		IP:         s.IP,
+		CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+		LastSeenAt: s.LastSeenAt.Format(time.RFC3339),
+		ExpiresAt:  s.ExpiresAt.Format(time.RFC3339),
+		Current:    s.ID == current,
+	}
+}
+
+func ParseFromEntities(sessions []Session, current string) []Response {
+	res := []Response{}
+
+	for _, s := range sessions {
+		res = append(res, ParseFromEntity(s, current))
+	}
+
+	return res
+}
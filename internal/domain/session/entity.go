@@ -0,0 +1,51 @@
+package session
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Session is a sign-in of a user on a device. It lasts as long as its refresh
+// tokens and ends, with them and its access tokens, when revoked.
+type Session struct {
+	ID             string
+	UserID         string    `db:"user_id"`
+	UserAgent      string    `db:"user_agent"`
+	IP             string    `db:"ip"`
+	CreatedAt      time.Time `db:"created_at"`
+	LastSeenAt     time.Time `db:"last_seen_at"`
+	ExpiresAt      time.Time `db:"expires_at"`
+	OrganizationID string    `db:"organization_id"`
+}
+
+// SeenEvery is how often requests of a session move its last-seen time, so
+// that not every request writes.
+const SeenEvery = time.Minute
+
+// Device cuts a user agent to the length stored.
+func Device(userAgent string) string {
+	userAgent = strings.ToValidUTF8(userAgent, "")
+	for len(userAgent) > 255 {
+		_, size := utf8.DecodeLastRuneInString(userAgent)
+		userAgent = userAgent[:len(userAgent)-size]
+	}
+
+	return userAgent
+}
+
+var (
+	ErrNotFound = &SessionError{"session not found"}
+)
+
+type SessionError struct {
+	message string
+}
+
+func (e *SessionError) Error() string {
+	return e.message
+}
+
+func (e *SessionError) Is(err error) bool {
+	return e == err
+}
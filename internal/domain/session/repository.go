@@ -0,0 +1,15 @@
+package session
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, s Session) error
+	Get(ctx context.Context, id string) (Session, error)
+	// List returns the sessions of the user that have not expired.
+	List(ctx context.Context, userID string) ([]Session, error)
+	// Update records the last-seen time, IP and expiry of the session.
+	Update(ctx context.Context, s Session) error
+	// Delete revokes the session.
+	Delete(ctx context.Context, userID, id string) error
+	DeleteByUser(ctx context.Context, userID string) error
+}
@@ -0,0 +1,183 @@
+package sprint
+
+import (
+	"math"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+)
+
+type Request struct {
+	Name      string `json:"name"`
+	Goal      string `json:"goal"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type UpdateRequest struct {
+	Name      string `json:"name,omitempty"`
+	Goal      string `json:"goal,omitempty"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+type TasksRequest struct {
+	TaskIDs []string `json:"task_ids"`
+}
+
+// CompleteRequest names the sprint unfinished tasks roll over to; when empty
+// they go back to the backlog.
+type CompleteRequest struct {
+	NextSprintID string `json:"next_sprint_id,omitempty"`
+}
+
+func (s *Request) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if s.Name == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "name is required", Field: "name"})
+	}
+	if len(s.Name) > 100 {
+		errs = append(errs, domain.ErrorResponse{Message: "name must be less than 100 characters", Field: "name"})
+	}
+
+	if len(s.Goal) > 200 {
+		errs = append(errs, domain.ErrorResponse{Message: "goal must be less than 200 characters", Field: "goal"})
+	}
+
+	start, err := time.Parse(domain.DateLayout, s.StartDate)
+	if err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid start_date format", Field: "start_date"})
+	}
+
+	end, err := time.Parse(domain.DateLayout, s.EndDate)
+	if err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid end_date format", Field: "end_date"})
+	}
+
+	if end.Before(start) {
+		errs = append(errs, domain.ErrorResponse{Message: "end_date must not be before start_date", Field: "end_date"})
+	}
+
+	return errs
+}
+
+func (s *UpdateRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(s.Name) > 100 && s.Name != "" {
+		errs = append(errs, domain.ErrorResponse{Message: "name must be less than 100 characters", Field: "name"})
+	}
+
+	if len(s.Goal) > 200 && s.Goal != "" {
+		errs = append(errs, domain.ErrorResponse{Message: "goal must be less than 200 characters", Field: "goal"})
+	}
+
+	if _, err := time.Parse(domain.DateLayout, s.StartDate); s.StartDate != "" && err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid start_date format", Field: "start_date"})
+	}
+
+	if _, err := time.Parse(domain.DateLayout, s.EndDate); s.EndDate != "" && err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid end_date format", Field: "end_date"})
+	}
+
+	return errs
+}
+
+func (s *TasksRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(s.TaskIDs) == 0 {
+		errs = append(errs, domain.ErrorResponse{Message: "task_ids is required", Field: "task_ids"})
+	}
+
+	return errs
+}
+
+type Response struct {
+	ID          string `json:"id"`
+	ProjectID   string `json:"project_id"`
+	Name        string `json:"name"`
+	Goal        string `json:"goal"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+	State       string `json:"state"`
+	StartedAt   string `json:"started_at,omitempty"`
+	CompletedAt string `json:"completed_at,omitempty"`
+}
+
+func ParseFromEntity(s Entity) Response {
+	res := Response{
+		ID:        s.ID,
+		ProjectID: s.ProjectID,
+		Name:      s.Name,
+		Goal:      s.Goal,
+		StartDate: s.StartDate.String(),
+		EndDate:   s.EndDate.String(),
+		State:     s.State,
+	}
+
+	if s.StartedAt != nil {
+		res.StartedAt = s.StartedAt.Format(time.RFC3339)
+	}
+	if s.CompletedAt != nil {
+		res.CompletedAt = s.CompletedAt.Format(time.RFC3339)
+	}
+
+	return res
+}
+
+func ParseFromEntities(sprints []Entity) []Response {
+	var responses []Response
+	for _, s := range sprints {
+		responses = append(responses, ParseFromEntity(s))
+	}
+	return responses
+}
+
+type ItemResponse struct {
+	TaskID    string `json:"task_id"`
+	Status    string `json:"status"`
+	Committed bool   `json:"committed"`
+	Done      bool   `json:"done"`
+}
+
+func ParseFromItems(items []Item) []ItemResponse {
+	res := []ItemResponse{}
+	for _, i := range items {
+		res = append(res, ItemResponse{
+			TaskID:    i.TaskID,
+			Status:    i.Status,
+			Committed: i.Committed,
+			Done:      i.Done(),
+		})
+	}
+	return res
+}
+
+type SummaryResponse struct {
+	Sprint             Response `json:"sprint"`
+	Committed          int      `json:"committed"`
+	Added              int      `json:"added"`
+	Completed          int      `json:"completed"`
+	CommittedCompleted int      `json:"committed_completed"`
+	Remaining          int      `json:"remaining"`
+	CompletionRate     float64  `json:"completion_rate"`
+}
+
+func ParseFromSummary(s Entity, sum Summary) SummaryResponse {
+	res := SummaryResponse{
+		Sprint:             ParseFromEntity(s),
+		Committed:          sum.Committed,
+		Added:              sum.Added,
+		Completed:          sum.Completed,
+		CommittedCompleted: sum.CommittedCompleted,
+		Remaining:          sum.Remaining,
+	}
+
+	if sum.Committed > 0 {
+		res.CompletionRate = math.Round(float64(sum.CommittedCompleted)/float64(sum.Committed)*10000) / 100
+	}
+
+	return res
+}
@@ -0,0 +1,98 @@
+package sprint
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+)
+
+const (
+	StatePlanned   = "planned"
+	StateActive    = "active"
+	StateCompleted = "completed"
+)
+
+type Entity struct {
+	ID          string
+	ProjectID   string `db:"project_id"`
+	Name        string
+	Goal        string
+	StartDate   domain.OnlyDate `db:"start_date"`
+	EndDate     domain.OnlyDate `db:"end_date"`
+	State       string
+	StartedAt   *time.Time `db:"started_at"`
+	CompletedAt *time.Time `db:"completed_at"`
+}
+
+// Item is a task scheduled in a sprint. Committed tasks were in the sprint
+// when it started; Completed is set when the sprint is completed.
+type Item struct {
+	SprintID  string `db:"sprint_id"`
+	TaskID    string `db:"task_id"`
+	Committed bool
+	Completed *bool
+	Status    string
+}
+
+// Done reports whether the item counts as completed work of the sprint.
+func (i Item) Done() bool {
+	if i.Completed != nil {
+		return *i.Completed
+	}
+	return i.Status == task.StatusDone
+}
+
+type Summary struct {
+	Committed          int
+	Added              int
+	Completed          int
+	CommittedCompleted int
+	Remaining          int
+}
+
+// Summarize compares the work committed at sprint start with the work
+// completed in it.
+func Summarize(items []Item) Summary {
+	var s Summary
+	for _, i := range items {
+		if i.Committed {
+			s.Committed++
+		} else {
+			s.Added++
+		}
+
+		if !i.Done() {
+			s.Remaining++
+			continue
+		}
+
+		s.Completed++
+		if i.Committed {
+			s.CommittedCompleted++
+		}
+	}
+	return s
+}
+
+var (
+	ErrNotFound     = &SprintError{"sprint not found"}
+	ErrBadRequest   = &SprintError{"sprint bad request"}
+	ErrState        = &SprintError{"operation is not allowed in the current sprint state"}
+	ErrActiveExists = &SprintError{"project already has an active sprint"}
+	ErrTaskInSprint = &SprintError{"task is already scheduled in an open sprint"}
+	ErrTaskProject  = &SprintError{"task belongs to another project"}
+	ErrRollover     = &SprintError{"unfinished tasks can only roll over to an open sprint of the same project"}
+)
+
+type SprintError struct {
+	message string
+}
+
+func (e *SprintError) Error() string {
+	return e.message
+}
+
+func (e *SprintError) Is(err error) bool {
+	return e == err
+}
@@ -0,0 +1,23 @@
+package sprint
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, s Entity) (string, Entity, error)
+	Get(ctx context.Context, id string) (Entity, error)
+	ListByProject(ctx context.Context, projectID string) ([]Entity, error)
+	Update(ctx context.Context, id string, s Entity) error
+	Delete(ctx context.Context, id string) error
+
+	Items(ctx context.Context, id string) ([]Item, error)
+	AddTask(ctx context.Context, id, taskID string, committed bool) error
+	RemoveTask(ctx context.Context, id, taskID string) error
+	// OpenSprintOf returns the planned or active sprint the task is scheduled
+	// in. Within a transaction it locks the task until the transaction ends.
+	OpenSprintOf(ctx context.Context, taskID string) (Entity, error)
+
+	Start(ctx context.Context, id string) error
+	// Complete closes the sprint and, when nextID is set, schedules its
+	// unfinished tasks in that sprint.
+	Complete(ctx context.Context, id, nextID string) error
+}
@@ -1,6 +1,8 @@
 package task
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/canyouhearthemusic/project-management/internal/domain"
@@ -15,6 +17,12 @@ type Request struct {
 	ProjectID   string `json:"project_id"`
 	CreatedAt   string `json:"created_at"`
 	DoneAt      string `json:"done_at"`
+	MilestoneID string `json:"milestone_id,omitempty"`
+	EpicID      string `json:"epic_id,omitempty"`
+	TeamID      string `json:"team_id,omitempty"`
+	// StartAt defaults to CreatedAt.
+	StartAt        string   `json:"start_at,omitempty"`
+	PredecessorIDs []string `json:"predecessor_ids,omitempty"`
 }
 
 type UpdateRequest struct {
@@ -25,6 +33,13 @@ type UpdateRequest struct {
 	AuthorID    string `json:"author_id,omitempty"`
 	ProjectID   string `json:"project_id,omitempty"`
 	DoneAt      string `json:"done_at,omitempty"`
+	MilestoneID string `json:"milestone_id,omitempty"`
+	EpicID      string `json:"epic_id,omitempty"`
+	TeamID      string `json:"team_id,omitempty"`
+	StartAt     string `json:"start_at,omitempty"`
+	// PredecessorIDs replaces the task's predecessors when present, an empty
+	// list removes them all.
+	PredecessorIDs []string `json:"predecessor_ids"`
 }
 
 func (t *Request) Validate() []domain.ErrorResponse {
@@ -52,18 +67,26 @@ func (t *Request) Validate() []domain.ErrorResponse {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid done_at format", Field: "done_at"})
 	}
 
-	if !isValidPriority(t.Priority) {
+	if t.StartAt != "" {
+		if _, err := time.Parse(domain.DateLayout, t.StartAt); err != nil {
+			errs = append(errs, domain.ErrorResponse{Message: "invalid start_at format", Field: "start_at"})
+		} else if t.StartAt > t.DoneAt {
+			errs = append(errs, domain.ErrorResponse{Message: "start_at must not be after done_at", Field: "start_at"})
+		}
+	}
+
+	if !IsValidPriority(t.Priority) {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid priority value", Field: "priority"})
 	}
 
-	if !isValidStatus(t.Status) {
+	if !IsValidStatus(t.Status) {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid status value", Field: "status"})
 	}
 
 	return errs
 }
 
-func isValidPriority(priority string) bool {
+func IsValidPriority(priority string) bool {
 	allowedPriorities := map[string]bool{
 		"low":    true,
 		"medium": true,
@@ -72,7 +95,7 @@ func isValidPriority(priority string) bool {
 	return allowedPriorities[priority]
 }
 
-func isValidStatus(status string) bool {
+func IsValidStatus(status string) bool {
 	allowedStatuses := map[string]bool{
 		"active":      true,
 		"in_progress": true,
@@ -96,11 +119,15 @@ func (t *UpdateRequest) Validate() []domain.ErrorResponse {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid done_at format", Field: "done_at"})
 	}
 
-	if t.Priority != "" && !isValidPriority(t.Priority) {
+	if _, err := time.Parse(domain.DateLayout, t.StartAt); t.StartAt != "" && err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid start_at format", Field: "start_at"})
+	}
+
+	if t.Priority != "" && !IsValidPriority(t.Priority) {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid priority value", Field: "priority"})
 	}
 
-	if t.Status != "" && !isValidStatus(t.Status) {
+	if t.Status != "" && !IsValidStatus(t.Status) {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid status value", Field: "status"})
 	}
 
@@ -108,28 +135,38 @@ func (t *UpdateRequest) Validate() []domain.ErrorResponse {
 }
 
 type Response struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Priority    string `json:"priority"`
-	Status      string `json:"status"`
-	AuthorID    string `json:"author_id"`
-	ProjectID   string `json:"project_id"`
-	CreatedAt   string `json:"created_at"`
-	DoneAt      string `json:"done_at"`
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Description    string   `json:"description"`
+	Priority       string   `json:"priority"`
+	Status         string   `json:"status"`
+	AuthorID       string   `json:"author_id"`
+	ProjectID      string   `json:"project_id"`
+	CreatedAt      string   `json:"created_at"`
+	DoneAt         string   `json:"done_at"`
+	MilestoneID    string   `json:"milestone_id,omitempty"`
+	EpicID         string   `json:"epic_id,omitempty"`
+	TeamID         string   `json:"team_id,omitempty"`
+	StartAt        string   `json:"start_at"`
+	PredecessorIDs []string `json:"predecessor_ids"`
 }
 
 func ParseFromEntity(t Entity) Response {
 	return Response{
-		ID:          t.ID,
-		Title:       t.Title,
-		Description: t.Description,
-		Priority:    t.Priority,
-		Status:      t.Status,
-		AuthorID:    t.AuthorID,
-		ProjectID:   t.ProjectID,
-		CreatedAt:   t.CreatedAt.String(),
-		DoneAt:      t.DoneAt.String(),
+		ID:             t.ID,
+		Title:          t.Title,
+		Description:    t.Description,
+		Priority:       t.Priority,
+		Status:         t.Status,
+		AuthorID:       t.AuthorID,
+		ProjectID:      t.ProjectID,
+		CreatedAt:      t.CreatedAt.String(),
+		DoneAt:         t.DoneAt.String(),
+		MilestoneID:    t.MilestoneID,
+		EpicID:         t.EpicID,
+		TeamID:         t.TeamID,
+		StartAt:        t.StartAt.String(),
+		PredecessorIDs: append([]string{}, t.PredecessorIDs...),
 	}
 }
 
@@ -140,3 +177,183 @@ func ParseFromEntities(tasks []Entity) []Response {
 	}
 	return responses
 }
+
+type MoveRequest struct {
+	ProjectID string `json:"project_id"`
+}
+
+// CloneRequest copies a task; an empty ProjectID keeps the clone in the
+// source task's project.
+type CloneRequest struct {
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+func (t *MoveRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if t.ProjectID == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "project_id is required", Field: "project_id"})
+	}
+
+	return errs
+}
+
+type BulkFilter struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+// BulkRequest selects tasks either by IDs or by a search filter and applies
+// a single operation to all of them.
+type BulkRequest struct {
+	IDs       []string    `json:"ids,omitempty"`
+	Filter    *BulkFilter `json:"filter,omitempty"`
+	Operation string      `json:"operation"`
+	Value     string      `json:"value,omitempty"`
+}
+
+func (t *BulkRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(t.IDs) == 0 && t.Filter == nil {
+		errs = append(errs, domain.ErrorResponse{Message: "either ids or filter is required", Field: "ids"})
+	}
+	if len(t.IDs) > 0 && t.Filter != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "ids and filter are mutually exclusive", Field: "filter"})
+	}
+	if len(t.IDs) > MaxBulkItems {
+		errs = append(errs, domain.ErrorResponse{Message: fmt.Sprintf("at most %d ids are allowed", MaxBulkItems), Field: "ids"})
+	}
+
+	if t.Filter != nil && (t.Filter.Value == "" || !IsValidFilter(t.Filter.Field)) {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid filter", Field: "filter"})
+	}
+
+	if !IsValidBulkOperation(t.Operation) {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid operation value", Field: "operation"})
+	}
+
+	switch t.Operation {
+	case BulkStatus:
+		if !IsValidStatus(t.Value) {
+			errs = append(errs, domain.ErrorResponse{Message: "invalid status value", Field: "value"})
+		}
+	case BulkPriority:
+		if !IsValidPriority(t.Value) {
+			errs = append(errs, domain.ErrorResponse{Message: "invalid priority value", Field: "value"})
+		}
+	case BulkProject, BulkAssignee:
+		if t.Value == "" {
+			errs = append(errs, domain.ErrorResponse{Message: "value is required", Field: "value"})
+		}
+	}
+
+	return errs
+}
+
+// Selection returns the tasks the request applies to.
+func (t *BulkRequest) Selection() Selection {
+	if t.Filter != nil {
+		return Selection{Field: t.Filter.Field, Value: t.Filter.Value}
+	}
+
+	return Selection{IDs: t.IDs}
+}
+
+type BulkItemResponse struct {
+	ID      string `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BulkResponse struct {
+	Operation string             `json:"operation"`
+	Committed bool               `json:"committed"`
+	Results   []BulkItemResponse `json:"results"`
+	// Warnings name the board columns left over their non-strict WIP limit.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func ParseFromBulkResults(op string, results []BulkResult) BulkResponse {
+	res := BulkResponse{
+		Operation: op,
+		Committed: true,
+	}
+
+	for _, r := range results {
+		item := BulkItemResponse{ID: r.ID, Success: r.Err == nil}
+		if r.Err != nil {
+			item.Error = r.Err.Error()
+			res.Committed = false
+		}
+		res.Results = append(res.Results, item)
+	}
+
+	return res
+}
+
+type StatusChangeResponse struct {
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	ActorID    string `json:"actor_id,omitempty"`
+	ChangedAt  string `json:"changed_at"`
+}
+
+func ParseFromHistory(history []StatusChange) []StatusChangeResponse {
+	res := []StatusChangeResponse{}
+	for _, c := range history {
+		res = append(res, StatusChangeResponse{
+			FromStatus: c.FromStatus,
+			ToStatus:   c.ToStatus,
+			ActorID:    c.ActorID,
+			ChangedAt:  c.ChangedAt.Format(time.RFC3339),
+		})
+	}
+	return res
+}
+
+type MetricsResponse struct {
+	Completed         bool               `json:"completed"`
+	LeadTimeHours     float64            `json:"lead_time_hours"`
+	CycleTimeHours    float64            `json:"cycle_time_hours"`
+	TimeInStatusHours map[string]float64 `json:"time_in_status_hours"`
+}
+
+func ParseFromMetrics(m Metrics) MetricsResponse {
+	return MetricsResponse{
+		Completed:         m.Completed,
+		LeadTimeHours:     hours(m.LeadTime),
+		CycleTimeHours:    hours(m.CycleTime),
+		TimeInStatusHours: hoursByStatus(m.TimeInStatus),
+	}
+}
+
+type ProjectMetricsResponse struct {
+	Tasks                int                `json:"tasks"`
+	Completed            int                `json:"completed"`
+	AvgLeadTimeHours     float64            `json:"avg_lead_time_hours"`
+	AvgCycleTimeHours    float64            `json:"avg_cycle_time_hours"`
+	AvgTimeInStatusHours map[string]float64 `json:"avg_time_in_status_hours"`
+}
+
+func ParseFromProjectMetrics(m ProjectMetrics) ProjectMetricsResponse {
+	return ProjectMetricsResponse{
+		Tasks:                m.Tasks,
+		Completed:            m.Completed,
+		AvgLeadTimeHours:     hours(m.AvgLeadTime),
+		AvgCycleTimeHours:    hours(m.AvgCycleTime),
+		AvgTimeInStatusHours: hoursByStatus(m.AvgTimeInStatus),
+	}
+}
+
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
+
+func hoursByStatus(m map[string]time.Duration) map[string]float64 {
+	res := make(map[string]float64, len(m))
+	for status, d := range m {
+		res[status] = hours(d)
+	}
+	return res
+}
@@ -1,6 +1,11 @@
 package task
 
-import "github.com/canyouhearthemusic/project-management/internal/domain"
+import (
+	"fmt"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/lib/pq"
+)
 
 type Entity struct {
 	ID          string
@@ -12,6 +17,13 @@ type Entity struct {
 	ProjectID   string          `db:"project_id"`
 	CreatedAt   domain.OnlyDate `db:"created_at"`
 	DoneAt      domain.OnlyDate `db:"done_at"`
+	MilestoneID string          `db:"milestone_id"`
+	EpicID      string          `db:"epic_id"`
+	TeamID      string          `db:"team_id"`
+	StartAt     domain.OnlyDate `db:"start_at"`
+	// PredecessorIDs are tasks of the same project that have to finish
+	// before this one starts. nil leaves them unchanged on update.
+	PredecessorIDs pq.StringArray `db:"predecessor_ids"`
 }
 
 var (
@@ -19,16 +31,59 @@ var (
 	ErrNotFound   = &TaskError{"task not found"}
 	ErrSearch     = &TaskError{"task search error"}
 	ErrBadRequest = &TaskError{"task bad request"}
+	ErrBulkFailed = &TaskError{"bulk operation failed, no changes were applied"}
+	ErrRolledBack = &TaskError{"change rolled back"}
+	ErrNoActor    = &TaskError{"status changes are recorded with the user making them, sign in"}
+	ErrTooMany    = &TaskError{fmt.Sprintf("a bulk operation applies to at most %d tasks", MaxBulkItems)}
+
+	ErrSchedule    = &TaskError{"start_at must not be after done_at"}
+	ErrPredecessor = &TaskError{"predecessors must be other tasks of the same project"}
+	ErrCycle       = &TaskError{"predecessors would form a dependency cycle"}
 )
 
+const (
+	BulkStatus   = "status"
+	BulkPriority = "priority"
+	BulkProject  = "project"
+	BulkAssignee = "assignee"
+	BulkDelete   = "delete"
+)
+
+// MaxBulkItems caps the tasks of a bulk operation, whether named by IDs or
+// matched by a filter.
+const MaxBulkItems = 500
+
+// Selection picks the tasks of a bulk operation, either by IDs or by the
+// value of a filter field.
+type Selection struct {
+	IDs   []string
+	Field string
+	Value string
+}
+
+// BulkResult is the outcome of a bulk operation for a single task.
+type BulkResult struct {
+	ID  string
+	Err error
+}
+
 func IsValidFilter(filter string) bool {
-	if filter == "" && filter != "title" && filter != "priority" && filter != "status" && filter != "author_id" && filter != "project_id" {
+	if filter != "title" && filter != "priority" && filter != "status" && filter != "author_id" && filter != "assignee" && filter != "project_id" {
 		return false
 	}
 
 	return true
 }
 
+func IsValidBulkOperation(op string) bool {
+	switch op {
+	case BulkStatus, BulkPriority, BulkProject, BulkAssignee, BulkDelete:
+		return true
+	default:
+		return false
+	}
+}
+
 type TaskError struct {
 	message string
 }
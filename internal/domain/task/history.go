@@ -0,0 +1,117 @@
+package task
+
+import "time"
+
+const (
+	StatusActive     = "active"
+	StatusInProgress = "in_progress"
+	StatusDone       = "done"
+)
+
+// StatusChange is a single transition of a task's status. FromStatus is
+// empty for the status the task was created with.
+type StatusChange struct {
+	ID         int64
+	TaskID     string    `db:"task_id"`
+	FromStatus string    `db:"from_status"`
+	ToStatus   string    `db:"to_status"`
+	ActorID    string    `db:"actor_id"`
+	ChangedAt  time.Time `db:"changed_at"`
+}
+
+type Metrics struct {
+	Completed    bool
+	LeadTime     time.Duration
+	CycleTime    time.Duration
+	TimeInStatus map[string]time.Duration
+}
+
+// ComputeMetrics derives timing metrics from a task's history ordered by
+// ChangedAt. Lead time runs from creation and cycle time from the first
+// start of work to the final transition to done; both stay zero while the
+// task is not done. The current status accumulates time until now.
+func ComputeMetrics(history []StatusChange, now time.Time) Metrics {
+	m := Metrics{TimeInStatus: map[string]time.Duration{}}
+	if len(history) == 0 {
+		return m
+	}
+
+	var started, done time.Time
+	for i, c := range history {
+		end := now
+		if i+1 < len(history) {
+			end = history[i+1].ChangedAt
+		}
+		m.TimeInStatus[c.ToStatus] += end.Sub(c.ChangedAt)
+
+		if c.ToStatus == StatusInProgress && started.IsZero() {
+			started = c.ChangedAt
+		}
+		if c.ToStatus == StatusDone {
+			done = c.ChangedAt
+		}
+	}
+
+	last := history[len(history)-1]
+	if last.ToStatus != StatusDone {
+		return m
+	}
+
+	m.Completed = true
+	m.LeadTime = done.Sub(history[0].ChangedAt)
+	if !started.IsZero() {
+		m.CycleTime = done.Sub(started)
+	}
+
+	return m
+}
+
+type ProjectMetrics struct {
+	Tasks           int
+	Completed       int
+	AvgLeadTime     time.Duration
+	AvgCycleTime    time.Duration
+	AvgTimeInStatus map[string]time.Duration
+}
+
+// AggregateMetrics averages task metrics of a project. Lead and cycle times
+// are averaged over completed tasks only.
+func AggregateMetrics(histories map[string][]StatusChange, now time.Time) ProjectMetrics {
+	pm := ProjectMetrics{AvgTimeInStatus: map[string]time.Duration{}}
+
+	var lead, cycle time.Duration
+	var cycled int
+	for _, h := range histories {
+		m := ComputeMetrics(h, now)
+		pm.Tasks++
+
+		for status, d := range m.TimeInStatus {
+			pm.AvgTimeInStatus[status] += d
+		}
+
+		if !m.Completed {
+			continue
+		}
+
+		pm.Completed++
+		lead += m.LeadTime
+		if m.CycleTime > 0 {
+			cycle += m.CycleTime
+			cycled++
+		}
+	}
+
+	if pm.Tasks > 0 {
+		for status, d := range pm.AvgTimeInStatus {
+			pm.AvgTimeInStatus[status] = d / time.Duration(pm.Tasks)
+		}
+	}
+	if pm.Completed > 0 {
+		pm.AvgLeadTime = lead / time.Duration(pm.Completed)
+	}
+	if cycled > 0 {
+		pm.AvgCycleTime = cycle / time.Duration(cycled)
+	}
+
+	return pm
+}
@@ -7,6 +7,31 @@ type Repository interface {
 	Search(ctx context.Context, filter, value string) ([]Entity, error)
 	Get(ctx context.Context, id string) (Entity, error)
 	Create(ctx context.Context, Entity Entity) (string, Entity, error)
+	// Update and UpdateMany unlink tasks they move to another project from
+	// the milestone and the sprints of the old one.
 	Update(ctx context.Context, id string, Entity Entity) error
 	Delete(ctx context.Context, id string) error
+	// UpdateMany and DeleteMany select and lock the tasks of sel in one
+	// transaction, hand the ones found to check, which aborts the batch by
+	// failing, and then change them all or none.
+	UpdateMany(ctx context.Context, sel Selection, Entity Entity, check func([]Entity) error) ([]BulkResult, error)
+	DeleteMany(ctx context.Context, sel Selection, check func([]Entity) error) ([]BulkResult, error)
+	ListByProject(ctx context.Context, projectID string) ([]Entity, error)
+	ListByMilestone(ctx context.Context, milestoneID string) ([]Entity, error)
+	// SetMilestone links the task to a milestone, an empty milestoneID unlinks it.
+	SetMilestone(ctx context.Context, id, milestoneID string) error
+	ListByEpic(ctx context.Context, epicID string) ([]Entity, error)
+	// SetEpic adds the tasks to an epic, an empty epicID removes them. Either
+	// every task changes or, when one is missing, none does.
+	SetEpic(ctx context.Context, ids []string, epicID string) error
+	// ListByTeam returns the tasks assigned to the team, directly or through
+	// their project when they are not assigned to another team themselves.
+	ListByTeam(ctx context.Context, teamID string) ([]Entity, error)
+	// SetTeam assigns the task to a team, an empty teamID unassigns it.
+	SetTeam(ctx context.Context, id, teamID string) error
+	// ListDue returns the tasks that are not done and due on or before until.
+	ListDue(ctx context.Context, until string) ([]Entity, error)
+	CountByStatus(ctx context.Context, projectID, status string) (int, error)
+	History(ctx context.Context, id string) ([]StatusChange, error)
+	ProjectHistory(ctx context.Context, projectID string) ([]StatusChange, error)
 }
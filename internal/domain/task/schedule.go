@@ -0,0 +1,63 @@
+package task
+
+import "sort"
+
+// TopoSort orders tasks so that every task comes after its predecessors.
+// Predecessors outside the given set are ignored. Ties are broken by start
+// date and ID to keep the order stable. It fails with ErrCycle when the
+// dependencies are circular.
+func TopoSort(tasks []Entity) ([]Entity, error) {
+	byID := make(map[string]Entity, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+
+	indegree := map[string]int{}
+	successors := map[string][]string{}
+	for _, t := range tasks {
+		indegree[t.ID] += 0
+		for _, p := range t.PredecessorIDs {
+			if _, ok := byID[p]; !ok || p == t.ID {
+				continue
+			}
+			indegree[t.ID]++
+			successors[p] = append(successors[p], t.ID)
+		}
+	}
+
+	less := func(a, b string) bool {
+		if byID[a].StartAt != byID[b].StartAt {
+			return byID[a].StartAt < byID[b].StartAt
+		}
+		return a < b
+	}
+
+	var ready []string
+	for id, n := range indegree {
+		if n == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	sorted := make([]Entity, 0, len(tasks))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return less(ready[i], ready[j]) })
+
+		id := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, byID[id])
+
+		for _, s := range successors[id] {
+			indegree[s]--
+			if indegree[s] == 0 {
+				ready = append(ready, s)
+			}
+		}
+	}
+
+	if len(sorted) != len(byID) {
+		return nil, ErrCycle
+	}
+
+	return sorted, nil
+}
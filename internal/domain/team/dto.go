@@ -0,0 +1,178 @@
+package team
+
+import "github.com/canyouhearthemusic/project-management/internal/domain"
+
+type Request struct {
+	Name   string `json:"name"`
+	LeadID string `json:"lead_id"`
+	// MemberIDs are added to the team besides the lead.
+	MemberIDs []string `json:"member_ids,omitempty"`
+}
+
+type UpdateRequest struct {
+	Name   string `json:"name,omitempty"`
+	LeadID string `json:"lead_id,omitempty"`
+}
+
+type MemberRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type ProjectsRequest struct {
+	ProjectIDs []string `json:"project_ids"`
+}
+
+type TasksRequest struct {
+	TaskIDs []string `json:"task_ids"`
+}
+
+func (t *Request) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if t.Name == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "name is required", Field: "name"})
+	}
+	if len(t.Name) > 100 {
+		errs = append(errs, domain.ErrorResponse{Message: "name must be less than 100 characters", Field: "name"})
+	}
+
+	if t.LeadID == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "lead_id is required", Field: "lead_id"})
+	}
+
+	return errs
+}
+
+func (t *UpdateRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(t.Name) > 100 && t.Name != "" {
+		errs = append(errs, domain.ErrorResponse{Message: "name must be less than 100 characters", Field: "name"})
+	}
+
+	return errs
+}
+
+func (t *MemberRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if t.UserID == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "user_id is required", Field: "user_id"})
+	}
+
+	return errs
+}
+
+func (t *ProjectsRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(t.ProjectIDs) == 0 {
+		errs = append(errs, domain.ErrorResponse{Message: "project_ids is required", Field: "project_ids"})
+	}
+
+	return errs
+}
+
+func (t *TasksRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(t.TaskIDs) == 0 {
+		errs = append(errs, domain.ErrorResponse{Message: "task_ids is required", Field: "task_ids"})
+	}
+
+	return errs
+}
+
+type MemberResponse struct {
+	UserID  string `json:"user_id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	AddedAt string `json:"added_at"`
+}
+
+type Response struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	LeadID    string           `json:"lead_id"`
+	CreatedAt string           `json:"created_at"`
+	Members   []MemberResponse `json:"members"`
+}
+
+type LoadResponse struct {
+	UserID     string `json:"user_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Open       int    `json:"open"`
+	InProgress int    `json:"in_progress"`
+	Overdue    int    `json:"overdue"`
+	High       int    `json:"high_priority"`
+	Done       int    `json:"done"`
+}
+
+type WorkloadResponse struct {
+	TeamID     string         `json:"team_id"`
+	Total      int            `json:"total"`
+	Done       int            `json:"done"`
+	Members    []LoadResponse `json:"members"`
+	Unassigned LoadResponse   `json:"unassigned"`
+}
+
+func ParseFromEntity(t Entity, members []Member) Response {
+	res := Response{
+		ID:        t.ID,
+		Name:      t.Name,
+		LeadID:    t.LeadID,
+		CreatedAt: t.CreatedAt.String(),
+		Members:   []MemberResponse{},
+	}
+
+	for _, m := range members {
+		res.Members = append(res.Members, ParseFromMember(m))
+	}
+
+	return res
+}
+
+func ParseFromMember(m Member) MemberResponse {
+	return MemberResponse{
+		UserID:  m.UserID,
+		Name:    m.Name,
+		Email:   m.Email,
+		AddedAt: m.AddedAt.String(),
+	}
+}
+
+func ParseFromMembers(members []Member) []MemberResponse {
+	res := []MemberResponse{}
+	for _, m := range members {
+		res = append(res, ParseFromMember(m))
+	}
+	return res
+}
+
+func ParseFromWorkload(teamID string, w Workload) WorkloadResponse {
+	res := WorkloadResponse{
+		TeamID:     teamID,
+		Total:      w.Total,
+		Done:       w.Done,
+		Members:    []LoadResponse{},
+		Unassigned: parseLoad(w.Unassigned),
+	}
+
+	for _, l := range w.Members {
+		res.Members = append(res.Members, parseLoad(l))
+	}
+
+	return res
+}
+
+func parseLoad(l Load) LoadResponse {
+	return LoadResponse{
+		UserID:     l.UserID,
+		Name:       l.Name,
+		Open:       l.Open,
+		InProgress: l.InProgress,
+		Overdue:    l.Overdue,
+		High:       l.High,
+		Done:       l.Done,
+	}
+}
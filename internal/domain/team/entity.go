@@ -0,0 +1,113 @@
+package team
+
+import (
+	"sort"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+)
+
+type Entity struct {
+	ID        string
+	Name      string
+	LeadID    string          `db:"lead_id"`
+	CreatedAt domain.OnlyDate `db:"created_at"`
+}
+
+type Member struct {
+	TeamID  string          `db:"team_id"`
+	UserID  string          `db:"user_id"`
+	AddedAt domain.OnlyDate `db:"added_at"`
+	Name    string          `db:"name"`
+	Email   string          `db:"email"`
+}
+
+// Load counts the tasks of a team assigned to one of its members, or to
+// nobody in the team when UserID is empty.
+type Load struct {
+	UserID     string
+	Name       string
+	Open       int
+	InProgress int
+	Overdue    int
+	High       int
+	Done       int
+}
+
+type Workload struct {
+	Total      int
+	Done       int
+	Members    []Load
+	Unassigned Load
+}
+
+// Balance spreads the team's tasks over its members by assignee. Tasks
+// assigned outside the team count as unassigned; a task is overdue when it
+// is not done and its due date is before today.
+func Balance(members []Member, tasks []task.Entity, today domain.OnlyDate) Workload {
+	w := Workload{}
+
+	loads := map[string]*Load{}
+	for _, m := range members {
+		loads[m.UserID] = &Load{UserID: m.UserID, Name: m.Name}
+	}
+
+	for _, t := range tasks {
+		l, ok := loads[t.AuthorID]
+		if !ok {
+			l = &w.Unassigned
+		}
+
+		w.Total++
+		if t.Status == task.StatusDone {
+			w.Done++
+			l.Done++
+			continue
+		}
+
+		l.Open++
+		if t.Status == task.StatusInProgress {
+			l.InProgress++
+		}
+		if t.DoneAt < today {
+			l.Overdue++
+		}
+		if t.Priority == "high" {
+			l.High++
+		}
+	}
+
+	for _, l := range loads {
+		w.Members = append(w.Members, *l)
+	}
+	sort.Slice(w.Members, func(i, j int) bool {
+		if w.Members[i].Open != w.Members[j].Open {
+			return w.Members[i].Open > w.Members[j].Open
+		}
+		return w.Members[i].Name < w.Members[j].Name
+	})
+
+	return w
+}
+
+var (
+	ErrNotFound       = &TeamError{"team not found"}
+	ErrExists         = &TeamError{"team already exists"}
+	ErrBadRequest     = &TeamError{"team bad request"}
+	ErrMemberExists   = &TeamError{"user is already a member of the team"}
+	ErrMemberNotFound = &TeamError{"team member not found"}
+	ErrLead           = &TeamError{"the team lead cannot leave the team, appoint another lead first"}
+	ErrNotAssigned    = &TeamError{"not assigned to the team"}
+)
+
+type TeamError struct {
+	message string
+}
+
+func (e *TeamError) Error() string {
+	return e.message
+}
+
+func (e *TeamError) Is(err error) bool {
+	return e == err
+}
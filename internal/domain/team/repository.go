@@ -0,0 +1,15 @@
+package team
+
+import "context"
+
+type Repository interface {
+	// Create and Update make the lead a member of the team.
+	Create(ctx context.Context, t Entity, memberIDs []string) error
+	Get(ctx context.Context, id string) (Entity, error)
+	List(ctx context.Context) ([]Entity, error)
+	Update(ctx context.Context, id string, t Entity) error
+	Delete(ctx context.Context, id string) error
+	ListMembers(ctx context.Context, id string) ([]Member, error)
+	AddMember(ctx context.Context, id, userID string) error
+	RemoveMember(ctx context.Context, id, userID string) error
+}
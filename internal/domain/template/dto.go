@@ -0,0 +1,123 @@
+package template
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+)
+
+type Request struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Priority    string `json:"priority"`
+	Status      string `json:"status"`
+	AuthorID    string `json:"author_id"`
+	ProjectID   string `json:"project_id,omitempty"`
+}
+
+type UpdateRequest struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+	Status      string `json:"status,omitempty"`
+	AuthorID    string `json:"author_id,omitempty"`
+}
+
+// InstantiateRequest carries the values substituted into a template when
+// a task is created from it.
+type InstantiateRequest struct {
+	Variables map[string]string `json:"variables"`
+	AuthorID  string            `json:"author_id,omitempty"`
+	DoneAt    string            `json:"done_at"`
+}
+
+func (t *Request) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if t.Title == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "title is required", Field: "title"})
+	}
+	if len(t.Title) > 100 {
+		errs = append(errs, domain.ErrorResponse{Message: "title must be less than 100 characters", Field: "title"})
+	}
+
+	if t.Description == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "description is required", Field: "description"})
+	}
+	if len(t.Description) >= 200 {
+		errs = append(errs, domain.ErrorResponse{Message: "description must be less than 200 characters", Field: "description"})
+	}
+
+	if !task.IsValidPriority(t.Priority) {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid priority value", Field: "priority"})
+	}
+
+	if !task.IsValidStatus(t.Status) {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid status value", Field: "status"})
+	}
+
+	return errs
+}
+
+func (t *UpdateRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(t.Title) > 100 && t.Title != "" {
+		errs = append(errs, domain.ErrorResponse{Message: "title must be less than 100 characters", Field: "title"})
+	}
+
+	if len(t.Description) >= 200 && t.Description != "" {
+		errs = append(errs, domain.ErrorResponse{Message: "description must be less than 200 characters", Field: "description"})
+	}
+
+	if t.Priority != "" && !task.IsValidPriority(t.Priority) {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid priority value", Field: "priority"})
+	}
+
+	if t.Status != "" && !task.IsValidStatus(t.Status) {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid status value", Field: "status"})
+	}
+
+	return errs
+}
+
+func (t *InstantiateRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if _, err := time.Parse(domain.DateLayout, t.DoneAt); err != nil {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid done_at format", Field: "done_at"})
+	}
+
+	return errs
+}
+
+type Response struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Priority    string `json:"priority"`
+	Status      string `json:"status"`
+	AuthorID    string `json:"author_id"`
+	ProjectID   string `json:"project_id,omitempty"`
+}
+
+func ParseFromEntity(t Entity) Response {
+	return Response{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Priority:    t.Priority,
+		Status:      t.Status,
+		AuthorID:    t.AuthorID,
+		ProjectID:   t.ProjectID,
+	}
+}
+
+func ParseFromEntities(templates []Entity) []Response {
+	var responses []Response
+	for _, t := range templates {
+		responses = append(responses, ParseFromEntity(t))
+	}
+	return responses
+}
@@ -0,0 +1,68 @@
+package template
+
+import (
+	"regexp"
+	"strings"
+)
+
+type Entity struct {
+	ID          string
+	Title       string
+	Description string
+	Priority    string
+	Status      string
+	AuthorID    string `db:"author_id"`
+	ProjectID   string `db:"project_id"`
+}
+
+var (
+	ErrExists     = &TemplateError{"template already exists"}
+	ErrNotFound   = &TemplateError{"template not found"}
+	ErrBadRequest = &TemplateError{"template bad request"}
+	ErrScope      = &TemplateError{"template does not belong to project"}
+	ErrVariables  = &TemplateError{"template variables are missing"}
+)
+
+// IsGlobal reports whether the template can be used in any project.
+func (e Entity) IsGlobal() bool {
+	return e.ProjectID == ""
+}
+
+// AvailableIn reports whether the template can be instantiated in the project.
+func (e Entity) AvailableIn(projectID string) bool {
+	return e.IsGlobal() || e.ProjectID == projectID
+}
+
+var placeholder = regexp.MustCompile(`{{\s*(\w+)\s*}}`)
+
+// Render replaces {{name}} placeholders in s with values from vars and
+// returns the names of placeholders that have no value.
+func Render(s string, vars map[string]string) (string, []string) {
+	var missing []string
+
+	res := placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		name := strings.TrimSpace(m[2 : len(m)-2])
+
+		v, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return m
+		}
+
+		return v
+	})
+
+	return res, missing
+}
+
+type TemplateError struct {
+	message string
+}
+
+func (e *TemplateError) Error() string {
+	return e.message
+}
+
+func (e *TemplateError) Is(err error) bool {
+	return e == err
+}
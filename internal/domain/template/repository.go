@@ -0,0 +1,12 @@
+package template
+
+import "context"
+
+type Repository interface {
+	Create(ctx context.Context, t Entity) (string, Entity, error)
+	Get(ctx context.Context, id string) (Entity, error)
+	List(ctx context.Context) ([]Entity, error)
+	ListByProject(ctx context.Context, projectID string) ([]Entity, error)
+	Update(ctx context.Context, id string, t Entity) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,53 @@
+package timeline
+
+import "github.com/canyouhearthemusic/project-management/internal/domain"
+
+type ItemResponse struct {
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Status         string   `json:"status"`
+	Start          string   `json:"start"`
+	End            string   `json:"end"`
+	DurationDays   int      `json:"duration_days"`
+	PredecessorIDs []string `json:"predecessor_ids"`
+	SlackDays      int      `json:"slack_days"`
+	Critical       bool     `json:"critical"`
+	OutsideWindow  bool     `json:"outside_window"`
+	Conflict       bool     `json:"dependency_conflict"`
+}
+
+type Response struct {
+	ProjectID    string         `json:"project_id"`
+	Start        string         `json:"start"`
+	Finish       string         `json:"finish"`
+	CriticalPath []string       `json:"critical_path"`
+	Tasks        []ItemResponse `json:"tasks"`
+}
+
+func ParseFromTimeline(t Timeline) Response {
+	res := Response{
+		ProjectID:    t.Project.ID,
+		Start:        t.Project.StartedAt.String(),
+		Finish:       t.Project.FinishedAt.String(),
+		CriticalPath: t.CriticalPath,
+		Tasks:        []ItemResponse{},
+	}
+
+	for _, it := range t.Items {
+		res.Tasks = append(res.Tasks, ItemResponse{
+			ID:             it.Task.ID,
+			Title:          it.Task.Title,
+			Status:         it.Task.Status,
+			Start:          it.Start.Format(domain.DateLayout),
+			End:            it.End.Format(domain.DateLayout),
+			DurationDays:   days(it.End.Sub(it.Start)) + 1,
+			PredecessorIDs: append([]string{}, it.Task.PredecessorIDs...),
+			SlackDays:      it.Slack,
+			Critical:       it.Critical,
+			OutsideWindow:  it.OutsideWindow,
+			Conflict:       it.Conflict,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,117 @@
+package timeline
+
+import (
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+)
+
+// Item is a scheduled task with the results of the critical path analysis.
+type Item struct {
+	Task  task.Entity
+	Start time.Time
+	End   time.Time
+	// Slack is how many days the task can slip without delaying the end of
+	// the project or a successor's scheduled start. It is negative when the
+	// schedule already violates a dependency.
+	Slack    int
+	Critical bool
+	// OutsideWindow is set when the task starts before the project or is
+	// due after it finishes.
+	OutsideWindow bool
+	// Conflict is set when the task starts before a predecessor is due.
+	Conflict bool
+}
+
+type Timeline struct {
+	Project project.Entity
+	Items   []Item
+	// CriticalPath lists the IDs of the critical tasks in dependency order.
+	CriticalPath []string
+}
+
+// Build schedules the tasks of p by their start_at and done_at dates. Slack
+// comes from a backward pass over the dependencies, starting at the latest
+// due date of all tasks; tasks without slack form the critical path.
+// Predecessors that are not among tasks are ignored.
+func Build(p project.Entity, tasks []task.Entity) (Timeline, error) {
+	sorted, err := task.TopoSort(tasks)
+	if err != nil {
+		return Timeline{}, err
+	}
+
+	projectStart, _ := time.Parse(domain.DateLayout, p.StartedAt.String())
+	projectEnd, _ := time.Parse(domain.DateLayout, p.FinishedAt.String())
+
+	items := make([]Item, len(sorted))
+	index := make(map[string]int, len(sorted))
+	var end time.Time
+	for i, t := range sorted {
+		start, _ := time.Parse(domain.DateLayout, t.StartAt.String())
+		due, _ := time.Parse(domain.DateLayout, t.DoneAt.String())
+		if due.Before(start) {
+			due = start
+		}
+
+		items[i] = Item{
+			Task:          t,
+			Start:         start,
+			End:           due,
+			OutsideWindow: start.Before(projectStart) || due.After(projectEnd),
+		}
+		index[t.ID] = i
+
+		if due.After(end) {
+			end = due
+		}
+	}
+
+	successors := map[string][]int{}
+	for i, it := range items {
+		for _, pid := range it.Task.PredecessorIDs {
+			j, ok := index[pid]
+			if !ok || pid == it.Task.ID {
+				continue
+			}
+			successors[pid] = append(successors[pid], i)
+
+			if !items[j].End.Before(it.Start) {
+				items[i].Conflict = true
+			}
+		}
+	}
+
+	// latest finish: the day before the earliest latest start of the successors
+	latestStart := make([]time.Time, len(items))
+	for i := len(items) - 1; i >= 0; i-- {
+		it := items[i]
+
+		latestFinish := end
+		for _, s := range successors[it.Task.ID] {
+			if ls := latestStart[s].AddDate(0, 0, -1); ls.Before(latestFinish) {
+				latestFinish = ls
+			}
+		}
+
+		duration := it.End.Sub(it.Start)
+		latestStart[i] = latestFinish.Add(-duration)
+
+		items[i].Slack = days(latestFinish.Sub(it.End))
+		items[i].Critical = items[i].Slack <= 0
+	}
+
+	res := Timeline{Project: p, Items: items, CriticalPath: []string{}}
+	for _, it := range items {
+		if it.Critical {
+			res.CriticalPath = append(res.CriticalPath, it.Task.ID)
+		}
+	}
+
+	return res, nil
+}
+
+func days(d time.Duration) int {
+	return int(d.Hours() / 24)
+}
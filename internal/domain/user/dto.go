@@ -12,12 +12,23 @@ type Request struct {
 	Email            string `json:"email"`
 	Role             string `json:"role"`
 	RegistrationDate string `json:"registration_date"`
+	// Password lets the user sign in, users without one cannot.
+	Password string `json:"password,omitempty"`
+}
+
+// BootstrapRequest creates the first user of an organization, who becomes
+// its admin.
+type BootstrapRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
 }
 
 type UpdateRequest struct {
-	Name  string `json:"name,omitempty"`
-	Email string `json:"email,omitempty"`
-	Role  string `json:"role,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Role     string `json:"role,omitempty"`
+	Password string `json:"password,omitempty"`
 }
 
 func (u *Request) Validate() []domain.ErrorResponse {
@@ -31,7 +42,7 @@ func (u *Request) Validate() []domain.ErrorResponse {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid email address", Field: "email"})
 	}
 
-	if u.Role != "admin" && u.Role != "manager" {
+	if !IsValidRole(u.Role) {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid role", Field: "role"})
 	}
 
@@ -39,6 +50,30 @@ func (u *Request) Validate() []domain.ErrorResponse {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid registration_date format", Field: "registration_date"})
 	}
 
+	if u.Password != "" {
+		errs = append(errs, validatePassword(u.Password)...)
+	}
+
+	return errs
+}
+
+func (b *BootstrapRequest) Validate() []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if b.Name == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "name is required", Field: "name"})
+	}
+
+	if ok, _ := regexp.MatchString(`^[\w-\.]+@([\w-]+\.)+[\w-]{2,4}$`, b.Email); !ok {
+		errs = append(errs, domain.ErrorResponse{Message: "invalid email address", Field: "email"})
+	}
+
+	if b.Password == "" {
+		errs = append(errs, domain.ErrorResponse{Message: "password is required", Field: "password"})
+	} else {
+		errs = append(errs, validatePassword(b.Password)...)
+	}
+
 	return errs
 }
 
@@ -49,10 +84,28 @@ func (u *UpdateRequest) Validate() []domain.ErrorResponse {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid email address", Field: "email"})
 	}
 
-	if u.Role != "" && u.Role != "admin" && u.Role != "manager" {
+	if u.Role != "" && !IsValidRole(u.Role) {
 		errs = append(errs, domain.ErrorResponse{Message: "invalid role", Field: "role"})
 	}
 
+	if u.Password != "" {
+		errs = append(errs, validatePassword(u.Password)...)
+	}
+
+	return errs
+}
+
+// validatePassword keeps passwords within what bcrypt hashes, 72 bytes.
+func validatePassword(password string) []domain.ErrorResponse {
+	var errs []domain.ErrorResponse
+
+	if len(password) < 8 {
+		errs = append(errs, domain.ErrorResponse{Message: "password must be at least 8 characters", Field: "password"})
+	}
+	if len(password) > 72 {
+		errs = append(errs, domain.ErrorResponse{Message: "password must be at most 72 bytes", Field: "password"})
+	}
+
 	return errs
 }
 
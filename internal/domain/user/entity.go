@@ -2,12 +2,24 @@ package user
 
 import "github.com/canyouhearthemusic/project-management/internal/domain"
 
+const (
+	RoleAdmin     = "admin"
+	RoleManager   = "manager"
+	RoleDeveloper = "developer"
+)
+
+// DefaultRole is given to users created without anybody choosing a role for
+// them, such as those a project import brings along.
+const DefaultRole = RoleDeveloper
+
 type Entity struct {
 	ID               string
 	Name             string
 	Email            string
 	RegistrationDate domain.OnlyDate `db:"registration_date"`
 	Role             string
+	// PasswordHash is empty for users who cannot sign in with a password.
+	PasswordHash string `db:"password_hash"`
 }
 
 var (
@@ -15,8 +27,14 @@ var (
 	ErrNotFound   = &UserError{"user not found"}
 	ErrSearch     = &UserError{"user search error"}
 	ErrBadRequest = &UserError{"user bad request"}
+	ErrForbidden  = &UserError{"passwords are only changed signed in, not with an access token"}
+	ErrHasUsers   = &UserError{"the organization already has users"}
 )
 
+func IsValidRole(role string) bool {
+	return role == RoleAdmin || role == RoleManager || role == RoleDeveloper
+}
+
 func IsValidFilter(filter string) bool {
 	if filter == "" && filter != "name" && filter != "email" {
 		return false
@@ -6,6 +6,9 @@ type Repository interface {
 	List(ctx context.Context) ([]Entity, error)
 	Search(ctx context.Context, filter, value string) ([]Entity, error)
 	Create(context.Context, Entity) (string, Entity, error)
+	// CreateFirst creates u as the first user of the organization, failing
+	// with ErrHasUsers when it already has any.
+	CreateFirst(ctx context.Context, u Entity) error
 	Get(ctx context.Context, id string) (Entity, error)
 	Update(ctx context.Context, id string, u Entity) error
 	Delete(ctx context.Context, id string) error
@@ -3,7 +3,10 @@ package handler
 import (
 	netHttp "net/http"
 
+	"github.com/canyouhearthemusic/project-management/config"
 	_ "github.com/canyouhearthemusic/project-management/docs"
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/export"
 	"github.com/canyouhearthemusic/project-management/internal/handler/http"
 	"github.com/canyouhearthemusic/project-management/internal/service/management"
 	"github.com/canyouhearthemusic/project-management/pkg/router"
@@ -40,29 +43,75 @@ func New(deps Dependencies, cfgs ...Configuration) Handler {
 // @title Project Management API
 // @BasePath /api/v1/
 // @version 1.0.0
+// @securityDefinitions.apikey Bearer
+// @in header
+// @name Authorization
+// @description Access token from /auth/login or a personal access token as "Bearer <token>"
+// @securityDefinitions.apikey Organization
+// @in header
+// @name X-Organization
 
 // @Summary Health-Check
 // @Tags Heartbeat
 // @Success 200 {string} string
 // @Router /heartbeat [get]
-func WithHTTPHandler() Configuration {
+func WithHTTPHandler(tenant config.Tenant, auth config.Auth, imp config.Import) Configuration {
 	return func(h *Handler) error {
 		h.Mux = router.New()
 
 		userHandler := http.NewUserHandler(h.deps.ManagementService)
 		taskHandler := http.NewTaskHandler(h.deps.ManagementService)
-		projecthandler := http.NewProjectHandler(h.deps.ManagementService)
+		projecthandler := http.NewProjectHandler(h.deps.ManagementService, export.Limits{MaxEntries: imp.MaxEntries, MaxSize: imp.MaxSize})
+		templateHandler := http.NewTemplateHandler(h.deps.ManagementService)
+		sprintHandler := http.NewSprintHandler(h.deps.ManagementService)
+		epicHandler := http.NewEpicHandler(h.deps.ManagementService)
+		projectTemplateHandler := http.NewProjectTemplateHandler(h.deps.ManagementService)
+		organizationHandler := http.NewOrganizationHandler(h.deps.ManagementService)
+		teamHandler := http.NewTeamHandler(h.deps.ManagementService)
+		calendarHandler := http.NewCalendarHandler(h.deps.ManagementService)
+		authHandler := http.NewAuthHandler(h.deps.ManagementService)
 
 		h.Mux.Get("/swagger/*", httpSwagger.WrapHandler)
 
 		h.Mux.Route("/api/v1", func(r chi.Router) {
+			r.Use(router.Client(domain.WithClient))
+
 			r.Get("/heartbeat", func(w netHttp.ResponseWriter, r *netHttp.Request) {
 				render.Status(r, netHttp.StatusOK)
 				render.PlainText(w, r, "OK")
 			})
-			r.Mount("/users", userHandler.Routes())
-			r.Mount("/tasks", taskHandler.Routes())
-			r.Mount("/projects", projecthandler.Routes())
+
+			// calendar apps cannot name an organization, feed tokens do
+			r.Mount("/calendar", calendarHandler.Routes())
+			// nor can identity providers, the sign-in they return to does
+			r.Mount("/oidc", authHandler.CallbackRoutes())
+
+			r.Group(func(r chi.Router) {
+				r.Use(router.Tenant(tenant.BaseDomain, h.deps.ManagementService.ResolveTenant, http.TenantError))
+
+				r.Mount("/auth", authHandler.Routes())
+
+				r.Group(func(r chi.Router) {
+					r.Use(router.Authenticate(auth.Required, h.deps.ManagementService.Authenticate, http.AuthError))
+
+					// personal access tokens are limited to the scopes of
+					// these resources, templates count as tasks and sprints,
+					// epics and project templates as projects
+					scope := func(resource string) func(netHttp.Handler) netHttp.Handler {
+						return router.Scope(resource, h.deps.ManagementService.Allows, http.AuthError)
+					}
+
+					r.With(scope("users")).Mount("/users", userHandler.Routes())
+					r.With(scope("tasks")).Mount("/tasks", taskHandler.Routes())
+					r.With(scope("projects")).Mount("/projects", projecthandler.Routes())
+					r.With(scope("tasks")).Mount("/templates", templateHandler.Routes())
+					r.With(scope("projects")).Mount("/sprints", sprintHandler.Routes())
+					r.With(scope("projects")).Mount("/epics", epicHandler.Routes())
+					r.With(scope("projects")).Mount("/project-templates", projectTemplateHandler.Routes())
+					r.With(scope("organizations")).Mount("/organizations", organizationHandler.Routes())
+					r.With(scope("teams")).Mount("/teams", teamHandler.Routes())
+				})
+			})
 		})
 
 		return nil
@@ -0,0 +1,470 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/config"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/epic"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/organization"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/session"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/team"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/handler"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+)
+
+const orgID = "5b0d7c1e-8f3a-4e2b-9c6d-1a2b3c4d5e6f"
+
+// TestRoutesByRole sends requests through the router as every role and
+// checks the status each gets. The developer owns the project and the
+// manager only views it, so the organization role and the project role are
+// both in play. Anonymous requests are refused before any of the routes.
+func TestRoutesByRole(t *testing.T) {
+	cases := []struct {
+		method, path, body string
+		// admin, manager and developer are the statuses for the roles.
+		admin, manager, developer int
+	}{
+		{method: "GET", path: "/users", admin: 200, manager: 200, developer: 200},
+		{method: "DELETE", path: "/users/developer", admin: 200, manager: 403, developer: 403},
+
+		{method: "DELETE", path: "/projects/apollo", admin: 200, manager: 403, developer: 200},
+
+		{method: "GET", path: "/teams", admin: 200, manager: 200, developer: 200},
+		{method: "POST", path: "/teams", body: `{"name":"Core","lead_id":"developer"}`, admin: 201, manager: 201, developer: 403},
+		{method: "POST", path: "/teams/core/projects", body: `{"project_ids":["apollo"]}`, admin: 200, manager: 403, developer: 403},
+
+		{method: "POST", path: "/epics/launch/tasks", body: `{"task_ids":["liftoff"]}`, admin: 200, manager: 403, developer: 403},
+
+		{method: "GET", path: "/organizations", admin: 200, manager: 403, developer: 403},
+		{method: "POST", path: "/organizations", body: `{"name":"Globex","slug":"globex"}`, admin: 201, manager: 403, developer: 403},
+	}
+
+	for _, c := range cases {
+		roles := map[string]int{
+			user.RoleAdmin:     c.admin,
+			user.RoleManager:   c.manager,
+			user.RoleDeveloper: c.developer,
+			"":                 http.StatusUnauthorized,
+		}
+
+		for role, want := range roles {
+			name := c.method + " " + c.path + " as " + role
+			if role == "" {
+				name = c.method + " " + c.path + " anonymously"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				f := newRouteFixture(t)
+
+				token := ""
+				if role != "" {
+					token = f.token(t, role)
+				}
+
+				if rec := f.do(c.method, c.path, c.body, token); rec.Code != want {
+					t.Errorf("status = %d, want %d: %s", rec.Code, want, rec.Body)
+				}
+			})
+		}
+	}
+}
+
+// TestBootstrap creates the first admin of an organization without signing
+// in, which works once and only while the organization has no users.
+func TestBootstrap(t *testing.T) {
+	f := newRouteFixture(t)
+	f.users.users = map[string]user.Entity{}
+
+	body := `{"name":"Ada","email":"ada@example.com","password":"correct horse"}`
+
+	rec := f.do("POST", "/auth/bootstrap", body, "")
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("first bootstrap status = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+	}
+
+	res := struct {
+		Data struct {
+			AccessToken string `json:"access_token"`
+		} `json:"data"`
+	}{}
+	if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	// the first user is an admin, who may delete users
+	if rec := f.do("DELETE", "/users/nobody", "", res.Data.AccessToken); rec.Code != http.StatusNotFound {
+		t.Errorf("deleting as the first user: status = %d, want %d: %s", rec.Code, http.StatusNotFound, rec.Body)
+	}
+
+	if rec := f.do("POST", "/auth/bootstrap", body, ""); rec.Code != http.StatusConflict {
+		t.Errorf("second bootstrap status = %d, want %d: %s", rec.Code, http.StatusConflict, rec.Body)
+	}
+}
+
+type routeFixture struct {
+	signer  *auth.Signer
+	users   *fakeUserRepository
+	handler handler.Handler
+}
+
+// newRouteFixture signs every role in, as the user named after it, to an
+// organization holding the project apollo with its task liftoff, the epic
+// launch and the team core.
+func newRouteFixture(t *testing.T) *routeFixture {
+	t.Helper()
+
+	sessions := &fakeSessionRepository{sessions: map[string]session.Session{}}
+	for _, id := range []string{user.RoleAdmin, user.RoleManager, user.RoleDeveloper} {
+		sessions.sessions[id] = session.Session{ID: id, UserID: id, LastSeenAt: time.Now().UTC()}
+	}
+
+	f := &routeFixture{
+		signer: auth.NewSigner("secret", time.Minute, time.Hour),
+		users: &fakeUserRepository{users: map[string]user.Entity{
+			user.RoleAdmin:     {ID: user.RoleAdmin, Role: user.RoleAdmin},
+			user.RoleManager:   {ID: user.RoleManager, Role: user.RoleManager},
+			user.RoleDeveloper: {ID: user.RoleDeveloper, Role: user.RoleDeveloper},
+		}},
+	}
+
+	svc := management.New(
+		management.WithSigner(f.signer),
+		management.WithSessionRepository(sessions),
+		management.WithOrganizationRepository(&fakeOrganizationRepository{
+			orgs: []organization.Entity{{ID: orgID, Name: "Default", Slug: organization.DefaultSlug}},
+		}),
+		management.WithAuthRepository(&fakeAuthRepository{}),
+		management.WithUserRepository(f.users),
+		management.WithProjectRepository(&fakeProjectRepository{projects: map[string]project.Entity{
+			"apollo": {ID: "apollo", Status: project.StatusActive},
+		}}),
+		management.WithMemberRepository(&fakeMemberRepository{members: []member.Entity{
+			{ProjectID: "apollo", UserID: user.RoleDeveloper, Role: member.RoleOwner},
+			{ProjectID: "apollo", UserID: user.RoleManager, Role: member.RoleViewer},
+		}}),
+		management.WithTaskRepository(&fakeTaskRepository{tasks: map[string]task.Entity{
+			"liftoff": {ID: "liftoff", ProjectID: "apollo"},
+		}}),
+		management.WithEpicRepository(&fakeEpicRepository{epics: map[string]epic.Entity{
+			"launch": {ID: "launch"},
+		}}),
+		management.WithTeamRepository(&fakeTeamRepository{teams: map[string]team.Entity{
+			"core": {ID: "core", LeadID: user.RoleManager},
+		}}),
+	)
+
+	f.handler = handler.New(
+		handler.Dependencies{ManagementService: svc},
+		handler.WithHTTPHandler(config.Tenant{}, config.Auth{Required: true}, config.Import{}),
+	)
+
+	return f
+}
+
+// do sends a request to the path under /api/v1 in the organization, with
+// the access token unless it is empty.
+func (f *routeFixture) do(method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1"+path, strings.NewReader(body))
+	req.Header.Set("X-Organization", organization.DefaultSlug)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	f.handler.Mux.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// token is an access token of the session of the user with role.
+func (f *routeFixture) token(t *testing.T, role string) string {
+	t.Helper()
+
+	token, err := f.signer.Sign(role, orgID, role, time.Now())
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	return token
+}
+
+// The fakes keep the organization the routes act on in memory. Methods the
+// routes do not reach are left to the embedded interfaces and panic.
+
+type fakeAuthRepository struct {
+	auth.Repository
+}
+
+func (r *fakeAuthRepository) Create(ctx context.Context, t auth.RefreshToken) error {
+	return nil
+}
+
+type fakeSessionRepository struct {
+	session.Repository
+
+	mu       sync.Mutex
+	sessions map[string]session.Session
+}
+
+func (r *fakeSessionRepository) Create(ctx context.Context, s session.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[s.ID] = s
+
+	return nil
+}
+
+func (r *fakeSessionRepository) Get(ctx context.Context, id string) (session.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.sessions[id]
+	if !ok {
+		return session.Session{}, session.ErrNotFound
+	}
+
+	return s, nil
+}
+
+type fakeOrganizationRepository struct {
+	organization.Repository
+
+	mu   sync.Mutex
+	orgs []organization.Entity
+}
+
+func (r *fakeOrganizationRepository) Create(ctx context.Context, o organization.Entity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.orgs = append(r.orgs, o)
+
+	return nil
+}
+
+func (r *fakeOrganizationRepository) Get(ctx context.Context, id string) (organization.Entity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, o := range r.orgs {
+		if o.ID == id {
+			return o, nil
+		}
+	}
+
+	return organization.Entity{}, organization.ErrNotFound
+}
+
+func (r *fakeOrganizationRepository) GetBySlug(ctx context.Context, slug string) (organization.Entity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, o := range r.orgs {
+		if o.Slug == slug {
+			return o, nil
+		}
+	}
+
+	return organization.Entity{}, organization.ErrNotFound
+}
+
+func (r *fakeOrganizationRepository) List(ctx context.Context) ([]organization.Entity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]organization.Entity{}, r.orgs...), nil
+}
+
+type fakeUserRepository struct {
+	user.Repository
+
+	mu    sync.Mutex
+	users map[string]user.Entity
+}
+
+func (r *fakeUserRepository) Get(ctx context.Context, id string) (user.Entity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[id]
+	if !ok {
+		return user.Entity{}, user.ErrNotFound
+	}
+
+	return u, nil
+}
+
+func (r *fakeUserRepository) CreateFirst(ctx context.Context, u user.Entity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.users) > 0 {
+		return user.ErrHasUsers
+	}
+	r.users[u.ID] = u
+
+	return nil
+}
+
+func (r *fakeUserRepository) List(ctx context.Context) ([]user.Entity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := []user.Entity{}
+	for _, u := range r.users {
+		res = append(res, u)
+	}
+
+	return res, nil
+}
+
+func (r *fakeUserRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return user.ErrNotFound
+	}
+	delete(r.users, id)
+
+	return nil
+}
+
+type fakeProjectRepository struct {
+	project.Repository
+
+	mu       sync.Mutex
+	projects map[string]project.Entity
+}
+
+func (r *fakeProjectRepository) Get(ctx context.Context, id string) (project.Entity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.projects[id]
+	if !ok {
+		return project.Entity{}, project.ErrNotFound
+	}
+
+	return p, nil
+}
+
+func (r *fakeProjectRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.projects, id)
+
+	return nil
+}
+
+func (r *fakeProjectRepository) SetTeam(ctx context.Context, id, teamID string) error {
+	return nil
+}
+
+type fakeMemberRepository struct {
+	member.Repository
+
+	members []member.Entity
+}
+
+func (r *fakeMemberRepository) Get(ctx context.Context, projectID, userID string) (member.Entity, error) {
+	for _, m := range r.members {
+		if m.ProjectID == projectID && m.UserID == userID {
+			return m, nil
+		}
+	}
+
+	return member.Entity{}, member.ErrNotFound
+}
+
+type fakeTaskRepository struct {
+	task.Repository
+
+	tasks map[string]task.Entity
+}
+
+func (r *fakeTaskRepository) Get(ctx context.Context, id string) (task.Entity, error) {
+	t, ok := r.tasks[id]
+	if !ok {
+		return task.Entity{}, task.ErrNotFound
+	}
+
+	return t, nil
+}
+
+func (r *fakeTaskRepository) SetEpic(ctx context.Context, ids []string, epicID string) error {
+	return nil
+}
+
+type fakeEpicRepository struct {
+	epic.Repository
+
+	epics map[string]epic.Entity
+}
+
+func (r *fakeEpicRepository) Get(ctx context.Context, id string) (epic.Entity, error) {
+	e, ok := r.epics[id]
+	if !ok {
+		return epic.Entity{}, epic.ErrNotFound
+	}
+
+	return e, nil
+}
+
+type fakeTeamRepository struct {
+	team.Repository
+
+	mu    sync.Mutex
+	teams map[string]team.Entity
+}
+
+func (r *fakeTeamRepository) Create(ctx context.Context, t team.Entity, memberIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.teams[t.ID] = t
+
+	return nil
+}
+
+func (r *fakeTeamRepository) Get(ctx context.Context, id string) (team.Entity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.teams[id]
+	if !ok {
+		return team.Entity{}, team.ErrNotFound
+	}
+
+	return t, nil
+}
+
+func (r *fakeTeamRepository) List(ctx context.Context) ([]team.Entity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := []team.Entity{}
+	for _, t := range r.teams {
+		res = append(res, t)
+	}
+
+	return res, nil
+}
+
+func (r *fakeTeamRepository) ListMembers(ctx context.Context, id string) ([]team.Member, error) {
+	return []team.Member{}, nil
+}
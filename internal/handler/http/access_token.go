@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/accesstoken"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// AccessTokenHandler serves the personal access tokens of the user in the
+// parent route.
+type AccessTokenHandler struct {
+	managementService *management.Service
+}
+
+func NewAccessTokenHandler(service *management.Service) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		managementService: service,
+	}
+}
+
+func (h *AccessTokenHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.create)
+	r.Delete("/{tokenId}", h.revoke)
+
+	return r
+}
+
+// list godoc
+// @Summary List the personal access tokens of a user
+// @Tags Access token endpoints
+// @Param id path string true "User ID"
+// @Success 200 {array} accesstoken.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /users/{id}/tokens [get]
+func (h *AccessTokenHandler) list(w http.ResponseWriter, r *http.Request) {
+	data, err := h.managementService.ListAccessTokens(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// create godoc
+// @Summary Create a personal access token
+// @Description The token is only shown in this response. It is sent as "Bearer <token>" and limited to its scopes, "<resource>:<level>" with resource one of users, tasks, projects, teams and organizations and level one of read, write and admin, each including the ones before it.
+// @Tags Access token endpoints
+// @Accept json
+// @Param id path string true "User ID"
+// @Param body body accesstoken.Request true "Token request"
+// @Success 201 {object} accesstoken.CreatedResponse
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /users/{id}/tokens [post]
+func (h *AccessTokenHandler) create(w http.ResponseWriter, r *http.Request) {
+	req := accesstoken.Request{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, accesstoken.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	data, err := h.managementService.CreateAccessToken(r.Context(), chi.URLParam(r, "id"), req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, "access token has been created", data)
+}
+
+// revoke godoc
+// @Summary Revoke a personal access token
+// @Tags Access token endpoints
+// @Param id path string true "User ID"
+// @Param tokenId path string true "Token ID"
+// @Success 200 {string} string "Token revoked"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Security Bearer
+// @Router /users/{id}/tokens/{tokenId} [delete]
+func (h *AccessTokenHandler) revoke(w http.ResponseWriter, r *http.Request) {
+	err := h.managementService.RevokeAccessToken(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "tokenId"))
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *AccessTokenHandler) respondError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, accesstoken.ErrNotOwner), errors.Is(err, accesstoken.ErrSignInRequired):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, accesstoken.ErrNotFound), errors.Is(err, user.ErrNotFound):
+		response.NotFound(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
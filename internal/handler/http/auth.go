@@ -0,0 +1,263 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/accesstoken"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/oidc"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/canyouhearthemusic/project-management/pkg/router"
+	"github.com/go-chi/chi/v5"
+)
+
+// AuthHandler signs users in and out of the organization of the request.
+type AuthHandler struct {
+	managementService *management.Service
+}
+
+func NewAuthHandler(service *management.Service) *AuthHandler {
+	return &AuthHandler{
+		managementService: service,
+	}
+}
+
+// Routes are to be mounted outside of authentication, they are how a token
+// is obtained.
+func (h *AuthHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/bootstrap", h.bootstrap)
+	r.Post("/login", h.login)
+	r.Post("/refresh", h.refresh)
+	r.Post("/logout", h.logout)
+	r.Get("/oidc/login", h.ssoLogin)
+
+	return r
+}
+
+// CallbackRoutes are where the identity provider sends users back to. They
+// are to be mounted outside of tenant resolution, the sign-in names the
+// organization.
+func (h *AuthHandler) CallbackRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/callback", h.ssoCallback)
+
+	return r
+}
+
+// bootstrap godoc
+// @Summary Create the first user of the organization
+// @Description Open only while the organization has no users. The user becomes its admin and is signed in.
+// @Tags Auth endpoints
+// @Accept json
+// @Param body body user.BootstrapRequest true "First user"
+// @Success 201 {object} auth.TokenResponse
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 409 {object} response.Response "The organization already has users"
+// @Security Organization
+// @Router /auth/bootstrap [post]
+func (h *AuthHandler) bootstrap(w http.ResponseWriter, r *http.Request) {
+	req := user.BootstrapRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, user.ErrBadRequest, nil)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, nil)
+		return
+	}
+
+	data, err := h.managementService.Bootstrap(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, user.ErrHasUsers) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		AuthError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, "first user has been created", data)
+}
+
+// login godoc
+// @Summary Sign in with email and password
+// @Tags Auth endpoints
+// @Accept json
+// @Param body body auth.LoginRequest true "Credentials"
+// @Success 200 {object} auth.TokenResponse
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 401 {object} response.Response "Invalid email or password"
+// @Security Organization
+// @Router /auth/login [post]
+func (h *AuthHandler) login(w http.ResponseWriter, r *http.Request) {
+	req := auth.LoginRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, auth.ErrBadRequest, nil)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, nil)
+		return
+	}
+
+	data, err := h.managementService.Login(r.Context(), req)
+	if err != nil {
+		AuthError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// refresh godoc
+// @Summary Trade a refresh token for new tokens
+// @Description The refresh token is spent, use the one in the response next.
+// @Tags Auth endpoints
+// @Accept json
+// @Param body body auth.RefreshRequest true "Refresh token"
+// @Success 200 {object} auth.TokenResponse
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 401 {object} response.Response "Invalid or expired token"
+// @Security Organization
+// @Router /auth/refresh [post]
+func (h *AuthHandler) refresh(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decodeRefresh(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := h.managementService.Refresh(r.Context(), req)
+	if err != nil {
+		AuthError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// logout godoc
+// @Summary Sign out
+// @Description Ends the session of the refresh token, its access tokens stop working too.
+// @Tags Auth endpoints
+// @Accept json
+// @Param body body auth.RefreshRequest true "Refresh token"
+// @Success 200 {string} string "Signed out"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Security Organization
+// @Router /auth/logout [post]
+func (h *AuthHandler) logout(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decodeRefresh(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.managementService.Logout(r.Context(), req); err != nil {
+		AuthError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// ssoLogin godoc
+// @Summary Sign in through the identity provider
+// @Description Redirects to the OpenID Connect provider, which sends the user back to /oidc/callback.
+// @Tags Auth endpoints
+// @Success 302 {string} string "Redirect to the provider"
+// @Failure 404 {object} response.Response "Single sign-on is not configured"
+// @Security Organization
+// @Router /auth/oidc/login [get]
+func (h *AuthHandler) ssoLogin(w http.ResponseWriter, r *http.Request) {
+	url, err := h.managementService.SSOLoginURL(r.Context())
+	if err != nil {
+		AuthError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+// ssoCallback godoc
+// @Summary Finish signing in through the identity provider
+// @Description Users are matched by email and created when missing, with the role their groups map to.
+// @Tags Auth endpoints
+// @Param state query string true "State sent to the provider"
+// @Param code query string true "Authorization code"
+// @Success 200 {object} auth.TokenResponse
+// @Failure 401 {object} response.Response "Sign-in rejected or expired"
+// @Failure 404 {object} response.Response "Single sign-on is not configured"
+// @Router /oidc/callback [get]
+func (h *AuthHandler) ssoCallback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("error") != "" || q.Get("code") == "" {
+		AuthError(w, r, auth.ErrSSORejected)
+		return
+	}
+
+	data, err := h.managementService.SSOCallback(r.Context(), q.Get("state"), q.Get("code"))
+	if err != nil {
+		AuthError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+func (h *AuthHandler) decodeRefresh(w http.ResponseWriter, r *http.Request) (auth.RefreshRequest, bool) {
+	req := auth.RefreshRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, auth.ErrBadRequest, nil)
+		return req, false
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, nil)
+		return req, false
+	}
+
+	return req, true
+}
+
+// AuthError answers requests that fail to authenticate or lack the scope.
+func AuthError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, router.ErrNoToken), errors.Is(err, auth.ErrInvalidToken), errors.Is(err, auth.ErrInvalidCredentials),
+		errors.Is(err, accesstoken.ErrInvalidToken):
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		response.Unauthorized(w, r, err)
+	case errors.Is(err, router.ErrScope):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, auth.ErrInvalidLogin), errors.Is(err, auth.ErrSSORejected), errors.Is(err, auth.ErrEmailUnverified),
+		errors.Is(err, oidc.ErrToken), errors.Is(err, oidc.ErrVerify):
+		response.Unauthorized(w, r, err)
+	case errors.Is(err, auth.ErrSSODisabled):
+		response.NotFound(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
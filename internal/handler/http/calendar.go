@@ -0,0 +1,191 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/calendar"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/ical"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// CalendarHandler serves the iCalendar feeds and the feed tokens of the user
+// in the parent route.
+type CalendarHandler struct {
+	managementService *management.Service
+}
+
+func NewCalendarHandler(service *management.Service) *CalendarHandler {
+	return &CalendarHandler{
+		managementService: service,
+	}
+}
+
+// Routes are authenticated by the token query parameter alone, so they
+// are to be mounted outside of tenant resolution.
+func (h *CalendarHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/users/{id}.ics", h.userFeed)
+	r.Get("/projects/{id}.ics", h.projectFeed)
+
+	return r
+}
+
+func (h *CalendarHandler) TokenRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.listTokens)
+	r.Post("/", h.createToken)
+	r.Delete("/{tokenId}", h.revokeToken)
+
+	return r
+}
+
+// userFeed godoc
+// @Summary Calendar feed of a user
+// @Description Tasks assigned to the user as to-dos on their due date and the projects the user is a member of as events from start to finish
+// @Tags Calendar endpoints
+// @Produce text/calendar
+// @Param id path string true "User ID"
+// @Param token query string true "Feed token of the user"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 401 {object} response.Response "Invalid or revoked token"
+// @Failure 403 {object} response.Response "Token of another user"
+// @Router /calendar/users/{id}.ics [get]
+func (h *CalendarHandler) userFeed(w http.ResponseWriter, r *http.Request) {
+	cal, err := h.managementService.UserCalendar(r.Context(), r.URL.Query().Get("token"), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	h.write(w, cal)
+}
+
+// projectFeed godoc
+// @Summary Calendar feed of a project
+// @Description The project as an event from start to finish and its tasks as to-dos on their due date
+// @Tags Calendar endpoints
+// @Produce text/calendar
+// @Param id path string true "Project ID"
+// @Param token query string true "Feed token of a project member"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 401 {object} response.Response "Invalid or revoked token"
+// @Failure 403 {object} response.Response "Not a project member"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /calendar/projects/{id}.ics [get]
+func (h *CalendarHandler) projectFeed(w http.ResponseWriter, r *http.Request) {
+	cal, err := h.managementService.ProjectCalendar(r.Context(), r.URL.Query().Get("token"), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	h.write(w, cal)
+}
+
+func (h *CalendarHandler) write(w http.ResponseWriter, cal ical.Calendar) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	cal.Write(w)
+}
+
+// listTokens godoc
+// @Summary List the calendar feed tokens of a user
+// @Tags Calendar endpoints
+// @Param id path string true "User ID"
+// @Success 200 {array} calendar.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /users/{id}/calendar-tokens [get]
+func (h *CalendarHandler) listTokens(w http.ResponseWriter, r *http.Request) {
+	data, err := h.managementService.ListCalendarTokens(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// createToken godoc
+// @Summary Create a calendar feed token
+// @Description The token is only shown in this response. It reads the user's feed and the feeds of the projects the user is a member of.
+// @Tags Calendar endpoints
+// @Accept json
+// @Param id path string true "User ID"
+// @Param body body calendar.Request true "Token request"
+// @Success 201 {object} calendar.CreatedResponse
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /users/{id}/calendar-tokens [post]
+func (h *CalendarHandler) createToken(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+
+	req := calendar.Request{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, calendar.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	data, err := h.managementService.CreateCalendarToken(r.Context(), userID, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	data.FeedURL = fmt.Sprintf("/api/v1/calendar/users/%s.ics?token=%s", userID, data.Token)
+
+	response.Created(w, r, "calendar token has been created", data)
+}
+
+// revokeToken godoc
+// @Summary Revoke a calendar feed token
+// @Tags Calendar endpoints
+// @Param id path string true "User ID"
+// @Param tokenId path string true "Token ID"
+// @Success 200 {string} string "Token revoked"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Security Bearer
+// @Router /users/{id}/calendar-tokens/{tokenId} [delete]
+func (h *CalendarHandler) revokeToken(w http.ResponseWriter, r *http.Request) {
+	err := h.managementService.RevokeCalendarToken(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "tokenId"))
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *CalendarHandler) respondError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, calendar.ErrInvalidToken):
+		response.Unauthorized(w, r, err)
+	case errors.Is(err, calendar.ErrForbidden), errors.Is(err, calendar.ErrNotOwner):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, calendar.ErrNotFound), errors.Is(err, project.ErrNotFound), errors.Is(err, user.ErrNotFound):
+		response.NotFound(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,231 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/budget"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// CostHandler serves the time entries and expenses of the task in the
+// parent route.
+type CostHandler struct {
+	managementService *management.Service
+}
+
+func NewCostHandler(service *management.Service) *CostHandler {
+	return &CostHandler{
+		managementService: service,
+	}
+}
+
+func (h *CostHandler) TimeEntryRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.listTimeEntries)
+	r.Post("/", h.logTime)
+	r.Delete("/{entryId}", h.deleteTimeEntry)
+
+	return r
+}
+
+func (h *CostHandler) ExpenseRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.listExpenses)
+	r.Post("/", h.addExpense)
+	r.Delete("/{expenseId}", h.deleteExpense)
+
+	return r
+}
+
+// listTimeEntries godoc
+// @Summary List hours logged on a task
+// @Tags Cost endpoints
+// @Param id path string true "Task ID"
+// @Success 200 {array} budget.TimeEntryResponse
+// @Failure 403 {object} response.Response "Not a project member"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /tasks/{id}/time-entries [get]
+func (h *CostHandler) listTimeEntries(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListTimeEntries(r.Context(), taskID)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// logTime godoc
+// @Summary Log hours on a task
+// @Description Hours are priced at the user's rate in effect on spent_on. Logging hours for another user needs a maintainer role.
+// @Tags Cost endpoints
+// @Accept json
+// @Param id path string true "Task ID"
+// @Param body body budget.TimeEntryRequest true "Time entry"
+// @Success 201 {object} budget.TimeEntryResponse
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Project archived"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /tasks/{id}/time-entries [post]
+func (h *CostHandler) logTime(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	req := budget.TimeEntryRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, budget.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	data, err := h.managementService.LogTime(r.Context(), taskID, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, "time entry has been added", data)
+}
+
+// deleteTimeEntry godoc
+// @Summary Delete a time entry
+// @Description Contributors delete their own hours; deleting someone else's takes a maintainer
+// @Tags Cost endpoints
+// @Param id path string true "Task ID"
+// @Param entryId path string true "Time entry ID"
+// @Success 200 {string} string "Time entry deleted"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Project archived"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /tasks/{id}/time-entries/{entryId} [delete]
+func (h *CostHandler) deleteTimeEntry(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	entryID := chi.URLParam(r, "entryId")
+
+	if err := h.managementService.DeleteTimeEntry(r.Context(), taskID, entryID); err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// listExpenses godoc
+// @Summary List expenses of a task
+// @Tags Cost endpoints
+// @Param id path string true "Task ID"
+// @Success 200 {array} budget.ExpenseResponse
+// @Failure 403 {object} response.Response "Not a project member"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /tasks/{id}/expenses [get]
+func (h *CostHandler) listExpenses(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListExpenses(r.Context(), taskID)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// addExpense godoc
+// @Summary Record an expense on a task
+// @Tags Cost endpoints
+// @Accept json
+// @Param id path string true "Task ID"
+// @Param body body budget.ExpenseRequest true "Expense"
+// @Success 201 {object} budget.ExpenseResponse
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Project archived"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /tasks/{id}/expenses [post]
+func (h *CostHandler) addExpense(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	req := budget.ExpenseRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, budget.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	data, err := h.managementService.AddExpense(r.Context(), taskID, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, "expense has been added", data)
+}
+
+// deleteExpense godoc
+// @Summary Delete an expense
+// @Tags Cost endpoints
+// @Param id path string true "Task ID"
+// @Param expenseId path string true "Expense ID"
+// @Success 200 {string} string "Expense deleted"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Project archived"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /tasks/{id}/expenses/{expenseId} [delete]
+func (h *CostHandler) deleteExpense(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	expenseID := chi.URLParam(r, "expenseId")
+
+	if err := h.managementService.DeleteExpense(r.Context(), taskID, expenseID); err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *CostHandler) respondError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, member.ErrForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, budget.ErrNotFound), errors.Is(err, task.ErrNotFound), errors.Is(err, user.ErrNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, project.ErrArchived):
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
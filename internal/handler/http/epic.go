@@ -0,0 +1,286 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/epic"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+type EpicHandler struct {
+	managementService *management.Service
+}
+
+func NewEpicHandler(service *management.Service) *EpicHandler {
+	return &EpicHandler{
+		managementService: service,
+	}
+}
+
+func (h *EpicHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.create)
+	r.Get("/", h.list)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+		r.Get("/tasks", h.listTasks)
+		r.Post("/tasks", h.addTasks)
+		r.Delete("/tasks/{taskId}", h.removeTask)
+	})
+
+	return r
+}
+
+// create godoc
+// @Summary Create an epic
+// @Tags Epic endpoints
+// @Accept json
+// @Param body body epic.Request true "Epic request"
+// @Success 201 {object} response.Response "Response"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Router /epics [post]
+func (h *EpicHandler) create(w http.ResponseWriter, r *http.Request) {
+	req := epic.Request{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, epic.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	msg, data, err := h.managementService.CreateEpic(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	response.Created(w, r, msg, data)
+}
+
+// list godoc
+// @Summary All epics with their progress
+// @Tags Epic endpoints
+// @Success 200 {array} epic.Response
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Router /epics [get]
+func (h *EpicHandler) list(w http.ResponseWriter, r *http.Request) {
+	data, err := h.managementService.ListEpics(r.Context())
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.BadRequest(w, r, err, err.Error())
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// get godoc
+// @Summary Get an epic with its progress roll-up
+// @Tags Epic endpoints
+// @Param id path string true "Epic UUID"
+// @Success 200 {object} epic.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /epics/{id} [get]
+func (h *EpicHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetEpic(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// update godoc
+// @Summary Update an epic
+// @Tags Epic endpoints
+// @Accept json
+// @Param id path string true "Epic UUID"
+// @Param body body epic.UpdateRequest true "Epic update request"
+// @Success 200 {string} string "Epic updated"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /epics/{id} [put]
+func (h *EpicHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := epic.UpdateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, epic.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.UpdateEpic(r.Context(), id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// delete godoc
+// @Summary Delete an epic
+// @Description Tasks of the epic are kept
+// @Tags Epic endpoints
+// @Param id path string true "Epic UUID"
+// @Success 200 {string} string "Epic deleted"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /epics/{id} [delete]
+func (h *EpicHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.managementService.DeleteEpic(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// listTasks godoc
+// @Summary Tasks of an epic across all projects
+// @Tags Epic endpoints
+// @Param id path string true "Epic UUID"
+// @Success 200 {array} task.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /epics/{id}/tasks [get]
+func (h *EpicHandler) listTasks(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListEpicTasks(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// addTasks godoc
+// @Summary Add tasks to an epic
+// @Description Tasks may belong to any project; a task is part of at most one epic
+// @Tags Epic endpoints
+// @Accept json
+// @Param id path string true "Epic UUID"
+// @Param body body epic.TasksRequest true "Task IDs"
+// @Success 200 {string} string "Tasks added"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Not a contributor of a task's project"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Task's project is archived"
+// @Router /epics/{id}/tasks [post]
+func (h *EpicHandler) addTasks(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := epic.TasksRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, epic.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.AddEpicTasks(r.Context(), id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// removeTask godoc
+// @Summary Remove a task from an epic
+// @Tags Epic endpoints
+// @Param id path string true "Epic UUID"
+// @Param taskId path string true "Task UUID"
+// @Success 200 {string} string "Task removed"
+// @Failure 403 {object} response.Response "Not a contributor of a task's project"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Task's project is archived"
+// @Router /epics/{id}/tasks/{taskId} [delete]
+func (h *EpicHandler) removeTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	taskID := chi.URLParam(r, "taskId")
+
+	err := h.managementService.RemoveEpicTask(r.Context(), id, taskID)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *EpicHandler) respondError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, member.ErrForbidden), errors.Is(err, policy.ErrForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, epic.ErrNotFound), errors.Is(err, epic.ErrNotLinked), errors.Is(err, task.ErrNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, project.ErrArchived):
+		response.Conflict(w, r, err)
+	case errors.Is(err, user.ErrNotFound):
+		response.BadRequest(w, r, err, nil)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
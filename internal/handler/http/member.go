@@ -0,0 +1,181 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// MemberHandler serves the members of the project in the parent route.
+type MemberHandler struct {
+	managementService *management.Service
+}
+
+func NewMemberHandler(service *management.Service) *MemberHandler {
+	return &MemberHandler{
+		managementService: service,
+	}
+}
+
+func (h *MemberHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{userId}", func(r chi.Router) {
+		r.Put("/", h.update)
+		r.Delete("/", h.remove)
+	})
+
+	return r
+}
+
+// list godoc
+// @Summary List members of a project
+// @Tags Member endpoints
+// @Param id path string true "Project ID"
+// @Success 200 {array} member.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/members [get]
+func (h *MemberHandler) list(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListProjectMembers(r.Context(), projectID)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// add godoc
+// @Summary Add a member to a project
+// @Description Needs a maintainer role, or owner to add another owner
+// @Tags Member endpoints
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param body body member.Request true "Member request"
+// @Success 201 {object} response.Response "Response"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Already a member"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /projects/{id}/members [post]
+func (h *MemberHandler) add(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+
+	req := member.Request{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, member.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	if err := h.managementService.AddProjectMember(r.Context(), projectID, req); err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, "member has been added", req)
+}
+
+// update godoc
+// @Summary Change the role of a project member
+// @Description Needs a maintainer role, or owner when an owner is involved
+// @Tags Member endpoints
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param userId path string true "User ID"
+// @Param body body member.UpdateRequest true "Member update request"
+// @Success 200 {string} string "Member updated"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Last owner"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /projects/{id}/members/{userId} [put]
+func (h *MemberHandler) update(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "userId")
+
+	req := member.UpdateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, member.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	if err := h.managementService.UpdateProjectMember(r.Context(), projectID, userID, req); err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// remove godoc
+// @Summary Remove a member from a project
+// @Description Members may leave on their own; removing others needs a maintainer role, or owner for owners
+// @Tags Member endpoints
+// @Param id path string true "Project ID"
+// @Param userId path string true "User ID"
+// @Success 200 {string} string "Member removed"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Last owner"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /projects/{id}/members/{userId} [delete]
+func (h *MemberHandler) remove(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "userId")
+
+	if err := h.managementService.RemoveProjectMember(r.Context(), projectID, userID); err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *MemberHandler) respondError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, member.ErrForbidden), errors.Is(err, policy.ErrForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, member.ErrNotFound), errors.Is(err, project.ErrNotFound), errors.Is(err, user.ErrNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, member.ErrExists), errors.Is(err, member.ErrLastOwner), errors.Is(err, project.ErrArchived):
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,301 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/milestone"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// MilestoneHandler serves the milestones of the project in the parent route.
+type MilestoneHandler struct {
+	managementService *management.Service
+}
+
+func NewMilestoneHandler(service *management.Service) *MilestoneHandler {
+	return &MilestoneHandler{
+		managementService: service,
+	}
+}
+
+func (h *MilestoneHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.create)
+	r.Get("/", h.list)
+
+	r.Route("/{milestoneId}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+		r.Post("/tasks", h.linkTasks)
+		r.Delete("/tasks/{taskId}", h.unlinkTask)
+		r.Get("/release-notes", h.releaseNotes)
+	})
+
+	return r
+}
+
+// create godoc
+// @Summary Create a milestone
+// @Tags Milestone endpoints
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param body body milestone.Request true "Milestone request"
+// @Success 201 {object} response.Response "Response"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Not a project maintainer"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/milestones [post]
+func (h *MilestoneHandler) create(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+
+	req := milestone.Request{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, milestone.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	msg, data, err := h.managementService.CreateMilestone(r.Context(), projectID, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, msg, data)
+}
+
+// list godoc
+// @Summary List milestones of a project
+// @Tags Milestone endpoints
+// @Param id path string true "Project ID"
+// @Success 200 {array} milestone.Response
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Router /projects/{id}/milestones [get]
+func (h *MilestoneHandler) list(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListMilestones(r.Context(), projectID)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.BadRequest(w, r, err, projectID)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// get godoc
+// @Summary Get a milestone with its progress
+// @Tags Milestone endpoints
+// @Param id path string true "Project ID"
+// @Param milestoneId path string true "Milestone ID"
+// @Success 200 {object} milestone.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/milestones/{milestoneId} [get]
+func (h *MilestoneHandler) get(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "milestoneId")
+
+	data, err := h.managementService.GetMilestone(r.Context(), projectID, id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// update godoc
+// @Summary Update a milestone
+// @Tags Milestone endpoints
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param milestoneId path string true "Milestone ID"
+// @Param body body milestone.UpdateRequest true "Milestone update request"
+// @Success 200 {string} string "Milestone updated"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Not a project maintainer"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/milestones/{milestoneId} [put]
+func (h *MilestoneHandler) update(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "milestoneId")
+
+	req := milestone.UpdateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, milestone.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.UpdateMilestone(r.Context(), projectID, id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// delete godoc
+// @Summary Delete a milestone
+// @Description Linked tasks are kept and unlinked
+// @Tags Milestone endpoints
+// @Param id path string true "Project ID"
+// @Param milestoneId path string true "Milestone ID"
+// @Success 200 {string} string "Milestone deleted"
+// @Failure 403 {object} response.Response "Not a project maintainer"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/milestones/{milestoneId} [delete]
+func (h *MilestoneHandler) delete(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "milestoneId")
+
+	err := h.managementService.DeleteMilestone(r.Context(), projectID, id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// linkTasks godoc
+// @Summary Link tasks to a milestone
+// @Tags Milestone endpoints
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param milestoneId path string true "Milestone ID"
+// @Param body body milestone.TasksRequest true "Task IDs"
+// @Success 200 {string} string "Tasks linked"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Not a project contributor"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/milestones/{milestoneId}/tasks [post]
+func (h *MilestoneHandler) linkTasks(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "milestoneId")
+
+	req := milestone.TasksRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, milestone.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.LinkMilestoneTasks(r.Context(), projectID, id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// unlinkTask godoc
+// @Summary Unlink a task from a milestone
+// @Tags Milestone endpoints
+// @Param id path string true "Project ID"
+// @Param milestoneId path string true "Milestone ID"
+// @Param taskId path string true "Task ID"
+// @Success 200 {string} string "Task unlinked"
+// @Failure 403 {object} response.Response "Not a project contributor"
+// @Failure 404 {object} response.Response "Milestone, task or link not found"
+// @Router /projects/{id}/milestones/{milestoneId}/tasks/{taskId} [delete]
+func (h *MilestoneHandler) unlinkTask(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "milestoneId")
+	taskID := chi.URLParam(r, "taskId")
+
+	err := h.managementService.UnlinkMilestoneTask(r.Context(), projectID, id, taskID)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// releaseNotes godoc
+// @Summary Release notes of a milestone
+// @Description Completed tasks of the milestone as Markdown
+// @Tags Milestone endpoints
+// @Produce text/markdown
+// @Param id path string true "Project ID"
+// @Param milestoneId path string true "Milestone ID"
+// @Success 200 {string} string "Release notes"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/milestones/{milestoneId}/release-notes [get]
+func (h *MilestoneHandler) releaseNotes(w http.ResponseWriter, r *http.Request) {
+	projectID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "milestoneId")
+
+	notes, err := h.managementService.MilestoneReleaseNotes(r.Context(), projectID, id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(notes))
+}
+
+func (h *MilestoneHandler) respondError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, member.ErrForbidden), errors.Is(err, policy.ErrForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, milestone.ErrNotFound), errors.Is(err, project.ErrNotFound), errors.Is(err, task.ErrNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, project.ErrArchived):
+		response.Conflict(w, r, err)
+	case errors.Is(err, milestone.ErrTaskProject):
+		response.BadRequest(w, r, err, nil)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/organization"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// OrganizationHandler serves the organizations the data is partitioned by.
+type OrganizationHandler struct {
+	managementService *management.Service
+}
+
+func NewOrganizationHandler(service *management.Service) *OrganizationHandler {
+	return &OrganizationHandler{
+		managementService: service,
+	}
+}
+
+func (h *OrganizationHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.create)
+	r.Get("/", h.list)
+	r.Get("/{id}", h.get)
+
+	return r
+}
+
+// create godoc
+// @Summary Create an organization
+// @Description Open to the admins of the default organization, who run the instance
+// @Tags Organization endpoints
+// @Accept json
+// @Param body body organization.Request true "Organization request"
+// @Success 201 {object} organization.Response
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Slug taken"
+// @Router /organizations [post]
+func (h *OrganizationHandler) create(w http.ResponseWriter, r *http.Request) {
+	req := organization.Request{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, organization.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	data, err := h.managementService.CreateOrganization(r.Context(), req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, "Organization created", data)
+}
+
+// list godoc
+// @Summary List organizations
+// @Description Open to the admins of the default organization, who run the instance
+// @Tags Organization endpoints
+// @Success 200 {array} organization.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Router /organizations [get]
+func (h *OrganizationHandler) list(w http.ResponseWriter, r *http.Request) {
+	data, err := h.managementService.ListOrganizations(r.Context())
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// get godoc
+// @Summary Get an organization
+// @Description The organization of the request, or any to the admins of the default organization
+// @Tags Organization endpoints
+// @Param id path string true "Organization ID"
+// @Success 200 {object} organization.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /organizations/{id} [get]
+func (h *OrganizationHandler) get(w http.ResponseWriter, r *http.Request) {
+	data, err := h.managementService.GetOrganization(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+func (h *OrganizationHandler) respondError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, policy.ErrForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, organization.ErrNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, organization.ErrExists):
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
+
+// TenantError answers requests naming an organization that cannot be resolved.
+func TenantError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, organization.ErrNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, auth.ErrInvalidToken):
+		response.Unauthorized(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -1,12 +1,22 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"net/http"
 
+	"github.com/canyouhearthemusic/project-management/internal/domain/board"
+	"github.com/canyouhearthemusic/project-management/internal/domain/export"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
 	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/projecttemplate"
+	"github.com/canyouhearthemusic/project-management/internal/domain/sprint"
+	"github.com/canyouhearthemusic/project-management/internal/domain/team"
+	"github.com/canyouhearthemusic/project-management/internal/domain/template"
 	"github.com/canyouhearthemusic/project-management/internal/service/management"
 	"github.com/canyouhearthemusic/project-management/pkg/response"
 	"github.com/go-chi/chi/v5"
@@ -15,11 +25,13 @@ import (
 
 type ProjectHandler struct {
 	managementService *management.Service
+	importLimits      export.Limits
 }
 
-func NewProjectHandler(service *management.Service) *ProjectHandler {
+func NewProjectHandler(service *management.Service, importLimits export.Limits) *ProjectHandler {
 	return &ProjectHandler{
 		managementService: service,
+		importLimits:      importLimits,
 	}
 }
 
@@ -28,12 +40,31 @@ func (h *ProjectHandler) Routes() chi.Router {
 
 	r.Post("/", h.create)
 	r.Get("/", h.list)
+	r.Get("/health", h.health)
+	r.Post("/import", h.importProject)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
 		r.Delete("/", h.delete)
+		r.Post("/transitions/{action}", h.transition)
 		r.Get("/tasks", h.listTasks)
+		r.Get("/templates", h.listTemplates)
+		r.Get("/metrics", h.metrics)
+		r.Get("/stats", h.stats)
+		r.Get("/budget", h.budget)
+		r.Get("/timeline", h.timeline)
+		r.Post("/save-as-template", h.saveAsTemplate)
+		r.Post("/clone", h.clone)
+		r.Get("/export", h.export)
+		r.Get("/board", h.board)
+		r.Get("/board/limits", h.boardLimits)
+		r.Put("/board/limits", h.setBoardLimits)
+		r.Get("/sprints", h.listSprints)
+		r.Post("/sprints", h.createSprint)
+		r.Mount("/milestones", NewMilestoneHandler(h.managementService).Routes())
+		r.Mount("/members", NewMemberHandler(h.managementService).Routes())
+		r.Post("/tasks/from-template/{templateId}", h.createTaskFromTemplate)
 	})
 
 	r.Get("/search", h.search)
@@ -48,6 +79,8 @@ func (h *ProjectHandler) Routes() chi.Router {
 // @Param body body project.Request true "Project request"
 // @Success 201 {object} response.Response "Response"
 // @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /projects [post]
 func (h *ProjectHandler) create(w http.ResponseWriter, r *http.Request) {
 	req := project.Request{}
@@ -69,6 +102,11 @@ func (h *ProjectHandler) create(w http.ResponseWriter, r *http.Request) {
 
 	msg, data, err := h.managementService.CreateProject(r.Context(), req)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		response.BadRequest(w, r, err, data)
 		return
 	}
@@ -83,12 +121,19 @@ func (h *ProjectHandler) create(w http.ResponseWriter, r *http.Request) {
 // @Param id path string true "Project UUID"
 // @Success 200 {object} project.Response
 // @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /projects/{id} [post]
 func (h *ProjectHandler) get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	data, err := h.managementService.GetProject(r.Context(), id)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		response.NotFound(w, r, err)
 		return
 	}
@@ -98,13 +143,24 @@ func (h *ProjectHandler) get(w http.ResponseWriter, r *http.Request) {
 
 // list godoc
 // @Summary All projects
+// @Description Archived projects are left out unless include_archived is set
 // @Tags Project endpoints
+// @Param include_archived query bool false "Include archived projects"
 // @Success 200 {array} project.Response
 // @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /projects [get]
 func (h *ProjectHandler) list(w http.ResponseWriter, r *http.Request) {
-	data, err := h.managementService.ListProjects(r.Context())
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+
+	data, err := h.managementService.ListProjects(r.Context(), includeArchived)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		response.BadRequest(w, r, err, err.Error())
 		return
 	}
@@ -112,6 +168,29 @@ func (h *ProjectHandler) list(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, r, data)
 }
 
+// health godoc
+// @Summary Portfolio health
+// @Description Health of every project that is not archived, worst score first. Scores start at 100 and lose up to 40 points for overdue open tasks, up to 40 for completion lagging behind the elapsed schedule and up to 20 for high priority tasks not started; 75 and above is green, 50 and above amber, anything lower red.
+// @Tags Project endpoints
+// @Success 200 {object} project.PortfolioResponse
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /projects/health [get]
+func (h *ProjectHandler) health(w http.ResponseWriter, r *http.Request) {
+	data, err := h.managementService.GetPortfolioHealth(r.Context())
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
 // @Summary Update a project
 // @Tags Project endpoints
 // @Accept json
@@ -119,6 +198,9 @@ func (h *ProjectHandler) list(w http.ResponseWriter, r *http.Request) {
 // @Param body body project.UpdateRequest true "Project update request"
 // @Success 200 {string} string "Project updated"
 // @Failure 400 {object} []string "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Project archived"
+// @Security Bearer
 // @Router /projects/{id} [put]
 func (h *ProjectHandler) update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -131,12 +213,31 @@ func (h *ProjectHandler) update(w http.ResponseWriter, r *http.Request) {
 
 	if errs := req.Validate(); errs != nil {
 		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
 		response.BadRequests(w, r, errors, req)
 		return
 	}
 
 	err := h.managementService.UpdateProject(r.Context(), id, req)
 	if err != nil {
+		if errors.Is(err, member.ErrForbidden) || errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrArchived) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, team.ErrNotFound) {
+			response.BadRequest(w, r, err, req)
+			return
+		}
+
 		response.NotFound(w, r, err)
 		return
 	}
@@ -149,12 +250,19 @@ func (h *ProjectHandler) update(w http.ResponseWriter, r *http.Request) {
 // @Param id path string true "Project ID"
 // @Success 200 {string} string "Project deleted"
 // @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /projects/{id} [delete]
 func (h *ProjectHandler) delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	err := h.managementService.DeleteProject(r.Context(), id)
 	if err != nil {
+		if errors.Is(err, member.ErrForbidden) || errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		response.NotFound(w, r, err)
 		return
 	}
@@ -162,6 +270,44 @@ func (h *ProjectHandler) delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// @Summary Change the lifecycle status of a project
+// @Description start: planned → active, hold: active → on_hold, resume: on_hold → active,
+// @Description complete: active/on_hold → completed, reopen: completed → active,
+// @Description archive: any → archived (read-only), unarchive: archived → completed
+// @Tags Project endpoints
+// @Param id path string true "Project ID"
+// @Param action path string true "Transition" Enums(start, hold, resume, complete, reopen, archive, unarchive)
+// @Success 200 {object} project.Response
+// @Failure 400 {object} response.Response "Unknown transition"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Transition not allowed"
+// @Security Bearer
+// @Router /projects/{id}/transitions/{action} [post]
+func (h *ProjectHandler) transition(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	action := chi.URLParam(r, "action")
+
+	data, err := h.managementService.TransitionProject(r.Context(), id, action)
+	if err != nil {
+		switch {
+		case errors.Is(err, member.ErrForbidden), errors.Is(err, policy.ErrForbidden):
+			response.Forbidden(w, r, err)
+		case errors.Is(err, project.ErrNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, project.ErrUnknownAction):
+			response.BadRequest(w, r, err, action)
+		case errors.Is(err, project.ErrTransition):
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
 // @Summary Search projects
 // @Description Use either name or email query string
 // @Tags Project endpoints
@@ -170,6 +316,8 @@ func (h *ProjectHandler) delete(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {array} project.Response
 // @Failure 400 {string} string "Bad request"
 // @Failure 404 {string} string "Not found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /projects/search [get]
 func (h *ProjectHandler) search(w http.ResponseWriter, r *http.Request) {
 	var filter, val string
@@ -180,6 +328,11 @@ func (h *ProjectHandler) search(w http.ResponseWriter, r *http.Request) {
 
 	projects, err := h.managementService.SearchProjects(r.Context(), filter, val)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		if errors.Is(err, project.ErrSearch) {
 			response.BadRequest(w, r, err, val)
 			return
@@ -202,15 +355,606 @@ func (h *ProjectHandler) search(w http.ResponseWriter, r *http.Request) {
 // @Param id path string true "Project ID"
 // @Success 200 {array} task.Response
 // @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /projects/{id}/tasks [get]
 func (h *ProjectHandler) listTasks(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	tasks, err := h.managementService.SearchTasks(r.Context(), "project_id", id)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		response.NotFound(w, r, err)
 		return
 	}
 
 	render.JSON(w, r, tasks)
 }
+
+// @Summary List templates available in a project
+// @Description Returns the project's own templates together with the global ones
+// @Tags Project endpoints
+// @Param id path string true "Project ID"
+// @Success 200 {array} template.Response
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Router /projects/{id}/templates [get]
+func (h *ProjectHandler) listTemplates(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	templates, err := h.managementService.ListProjectTemplates(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.BadRequest(w, r, err, id)
+		return
+	}
+
+	response.OK(w, r, templates)
+}
+
+// @Summary Create a task from a template
+// @Description Substitutes {{variable}} placeholders; {{project}} and {{date}} are filled automatically
+// @Tags Project endpoints
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param templateId path string true "Template ID"
+// @Param body body template.InstantiateRequest true "Template variables"
+// @Success 201 {object} response.Response "Response"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Project archived or strict WIP limit reached"
+// @Security Bearer
+// @Router /projects/{id}/tasks/from-template/{templateId} [post]
+func (h *ProjectHandler) createTaskFromTemplate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	templateID := chi.URLParam(r, "templateId")
+
+	req := template.InstantiateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, template.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	msg, data, err := h.managementService.CreateTaskFromTemplate(r.Context(), id, templateID, req)
+	if err != nil {
+		if errors.Is(err, member.ErrForbidden) || errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrArchived) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, board.ErrWIPLimit) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) || errors.Is(err, template.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	response.Created(w, r, msg, data)
+}
+
+// @Summary Aggregated task metrics of a project
+// @Description Average lead time and cycle time of completed tasks and average time spent per status
+// @Tags Project endpoints
+// @Param id path string true "Project ID"
+// @Success 200 {object} task.ProjectMetricsResponse
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /projects/{id}/metrics [get]
+func (h *ProjectHandler) metrics(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetProjectMetrics(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// @Summary Progress statistics of a project
+// @Description Task counts by status and priority, completion percentage, overdue tasks and a daily
+// @Description burndown/burnup series from started_at up to today or finished_at
+// @Tags Project endpoints
+// @Param id path string true "Project ID"
+// @Success 200 {object} project.StatsResponse
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /projects/{id}/stats [get]
+func (h *ProjectHandler) stats(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetProjectStats(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// @Summary Budget report of a project
+// @Description Labour priced at each user's rate on the day it was logged plus expenses, against the project budget.
+// @Description The forecast extends the average daily burn since started_at to finished_at; exhausted_on is the day the
+// @Description budget runs out at that pace. Costs in other currencies are listed separately and not converted.
+// @Tags Project endpoints
+// @Param id path string true "Project ID"
+// @Success 200 {object} budget.ReportResponse
+// @Failure 403 {object} response.Response "Not a project member"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/budget [get]
+func (h *ProjectHandler) budget(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetProjectBudget(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, member.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// @Summary Gantt timeline of a project
+// @Description Tasks with start and due dates, predecessors, slack and the critical path. Tasks outside the
+// @Description project's started_at–finished_at window and tasks starting before a predecessor is due are flagged.
+// @Tags Project endpoints
+// @Param id path string true "Project ID"
+// @Success 200 {object} timeline.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/timeline [get]
+func (h *ProjectHandler) timeline(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetProjectTimeline(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// @Summary Save a project as a template
+// @Description Keeps the description and the tasks with priorities, statuses, predecessors and dates relative to started_at
+// @Tags Project endpoints
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param body body projecttemplate.SaveRequest true "Template name"
+// @Success 201 {object} projecttemplate.Response
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/save-as-template [post]
+func (h *ProjectHandler) saveAsTemplate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := projecttemplate.SaveRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, projecttemplate.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	msg, data, err := h.managementService.SaveProjectAsTemplate(r.Context(), id, req)
+	if err != nil {
+		respondProjectTemplateError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, msg, data)
+}
+
+// @Summary Deep-clone a project
+// @Description Copies the project and its tasks into a new project, shifting task dates to the new started_at
+// @Tags Project endpoints
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param body body projecttemplate.InstantiateRequest true "New project"
+// @Success 201 {object} projecttemplate.ProjectResponse
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /projects/{id}/clone [post]
+func (h *ProjectHandler) clone(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := projecttemplate.InstantiateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, projecttemplate.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	data, err := h.managementService.CloneProject(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		respondProjectTemplateError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, "project has been cloned", data)
+}
+
+// maxImportSize bounds the uploaded archive of POST /projects/import.
+const maxImportSize = 32 << 20
+
+// @Summary Export a project
+// @Description Versioned zip archive with a JSON manifest of the project, its tasks, members and referenced users
+// @Tags Project endpoints
+// @Produce application/zip
+// @Param id path string true "Project ID"
+// @Success 200 {file} file
+// @Failure 403 {object} response.Response "Not a project maintainer"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/export [get]
+func (h *ProjectHandler) export(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ExportProject(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, member.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	buf := bytes.Buffer{}
+	if err = export.Write(&buf, data); err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"project-%s.zip\"", id))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// @Summary Import a project
+// @Description Recreates a project exported by GET /projects/{id}/export with fresh IDs. Users are matched
+// @Description by email or, by those who may manage users, created with the default role. Responds with the
+// @Description mapping from old to new IDs.
+// @Tags Project endpoints
+// @Accept multipart/form-data
+// @Param archive formData file true "Exported archive"
+// @Success 201 {object} export.Report
+// @Failure 400 {object} response.Response "Invalid or too large archive"
+// @Security Bearer
+// @Failure 403 {object} response.Response "Forbidden"
+// @Router /projects/import [post]
+func (h *ProjectHandler) importProject(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	file, header, err := r.FormFile("archive")
+	if err != nil {
+		response.BadRequest(w, r, export.ErrInvalid, nil)
+		return
+	}
+	defer file.Close()
+
+	archive, err := export.Read(file, header.Size, h.importLimits)
+	if err != nil {
+		if errors.Is(err, export.ErrInvalid) || errors.Is(err, export.ErrVersion) || errors.Is(err, export.ErrTooLarge) {
+			response.BadRequest(w, r, err, nil)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	data, err := h.managementService.ImportProject(r.Context(), archive)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, export.ErrInvalid) {
+			response.BadRequest(w, r, err, nil)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, "project has been imported", data)
+}
+
+// @Summary Kanban board of a project
+// @Description Tasks grouped into columns by status together with the column WIP limits
+// @Tags Project endpoints
+// @Param id path string true "Project ID"
+// @Success 200 {object} board.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/board [get]
+func (h *ProjectHandler) board(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetBoard(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// @Summary WIP limits of a project board
+// @Tags Project endpoints
+// @Param id path string true "Project ID"
+// @Success 200 {array} board.LimitResponse
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/board/limits [get]
+func (h *ProjectHandler) boardLimits(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetBoardLimits(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// @Summary Set WIP limits of a project board
+// @Description Replaces all limits. Strict limits reject task moves into a full column, others only warn.
+// @Tags Project endpoints
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param body body board.LimitsRequest true "WIP limits"
+// @Success 200 {string} string "Limits updated"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Project archived"
+// @Security Bearer
+// @Router /projects/{id}/board/limits [put]
+func (h *ProjectHandler) setBoardLimits(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := board.LimitsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, board.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.SetBoardLimits(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, member.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrArchived) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// @Summary Create a sprint in a project
+// @Tags Project endpoints
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param body body sprint.Request true "Sprint request"
+// @Success 201 {object} response.Response "Response"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Not a project maintainer"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/sprints [post]
+func (h *ProjectHandler) createSprint(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := sprint.Request{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, sprint.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	msg, data, err := h.managementService.CreateSprint(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, member.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+		if errors.Is(err, project.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	response.Created(w, r, msg, data)
+}
+
+// @Summary List sprints of a project
+// @Tags Project endpoints
+// @Param id path string true "Project ID"
+// @Success 200 {array} sprint.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /projects/{id}/sprints [get]
+func (h *ProjectHandler) listSprints(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListProjectSprints(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
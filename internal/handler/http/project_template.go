@@ -0,0 +1,163 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/projecttemplate"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+type ProjectTemplateHandler struct {
+	managementService *management.Service
+}
+
+func NewProjectTemplateHandler(service *management.Service) *ProjectTemplateHandler {
+	return &ProjectTemplateHandler{
+		managementService: service,
+	}
+}
+
+func (h *ProjectTemplateHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Delete("/", h.delete)
+		r.Post("/projects", h.createProject)
+	})
+
+	return r
+}
+
+// list godoc
+// @Summary All project templates
+// @Tags Project template endpoints
+// @Success 200 {array} projecttemplate.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /project-templates [get]
+func (h *ProjectTemplateHandler) list(w http.ResponseWriter, r *http.Request) {
+	data, err := h.managementService.ListAllProjectTemplates(r.Context())
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// get godoc
+// @Summary Get a project template with its tasks
+// @Tags Project template endpoints
+// @Param id path string true "Project template ID"
+// @Success 200 {object} projecttemplate.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /project-templates/{id} [get]
+func (h *ProjectTemplateHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetProjectTemplate(r.Context(), id)
+	if err != nil {
+		respondProjectTemplateError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// delete godoc
+// @Summary Delete a project template
+// @Tags Project template endpoints
+// @Param id path string true "Project template ID"
+// @Success 200 {string} string "Project template deleted"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /project-templates/{id} [delete]
+func (h *ProjectTemplateHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.managementService.DeleteProjectTemplate(r.Context(), id)
+	if err != nil {
+		respondProjectTemplateError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// createProject godoc
+// @Summary Create a project from a template
+// @Description Task dates are shifted so they keep their distance to the new started_at
+// @Tags Project template endpoints
+// @Accept json
+// @Param id path string true "Project template ID"
+// @Param body body projecttemplate.InstantiateRequest true "New project"
+// @Success 201 {object} projecttemplate.ProjectResponse
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /project-templates/{id}/projects [post]
+func (h *ProjectTemplateHandler) createProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := projecttemplate.InstantiateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, projecttemplate.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	data, err := h.managementService.CreateProjectFromTemplate(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		respondProjectTemplateError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, "project has been created", data)
+}
+
+// respondProjectTemplateError is shared with the project routes that save
+// and clone projects.
+func respondProjectTemplateError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, policy.ErrForbidden), errors.Is(err, member.ErrForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, projecttemplate.ErrNotFound), errors.Is(err, project.ErrNotFound), errors.Is(err, user.ErrNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, project.ErrExists):
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/session"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// SessionHandler serves the sign-in sessions of the user in the parent route.
+type SessionHandler struct {
+	managementService *management.Service
+}
+
+func NewSessionHandler(service *management.Service) *SessionHandler {
+	return &SessionHandler{
+		managementService: service,
+	}
+}
+
+func (h *SessionHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Delete("/", h.revokeAll)
+	r.Delete("/{sessionId}", h.revoke)
+
+	return r
+}
+
+// list godoc
+// @Summary List the active sessions of a user
+// @Description Each sign-in on a device is a session, with the user agent and IP address it was last seen from. Users see their own sessions, admins those of everyone.
+// @Tags Session endpoints
+// @Param id path string true "User ID"
+// @Success 200 {array} session.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /users/{id}/sessions [get]
+func (h *SessionHandler) list(w http.ResponseWriter, r *http.Request) {
+	data, err := h.managementService.ListSessions(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// revoke godoc
+// @Summary Revoke a session
+// @Description Its refresh and access tokens stop working.
+// @Tags Session endpoints
+// @Param id path string true "User ID"
+// @Param sessionId path string true "Session ID"
+// @Success 200 {string} string "Session revoked"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Security Bearer
+// @Router /users/{id}/sessions/{sessionId} [delete]
+func (h *SessionHandler) revoke(w http.ResponseWriter, r *http.Request) {
+	err := h.managementService.RevokeSession(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "sessionId"))
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// revokeAll godoc
+// @Summary Revoke all sessions of a user
+// @Description Signs the user out everywhere. Personal access tokens are revoked separately.
+// @Tags Session endpoints
+// @Param id path string true "User ID"
+// @Success 200 {string} string "Sessions revoked"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Security Bearer
+// @Router /users/{id}/sessions [delete]
+func (h *SessionHandler) revokeAll(w http.ResponseWriter, r *http.Request) {
+	err := h.managementService.RevokeSessions(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *SessionHandler) respondError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, policy.ErrForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, session.ErrNotFound), errors.Is(err, user.ErrNotFound):
+		response.NotFound(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,301 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/sprint"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+type SprintHandler struct {
+	managementService *management.Service
+}
+
+func NewSprintHandler(service *management.Service) *SprintHandler {
+	return &SprintHandler{
+		managementService: service,
+	}
+}
+
+func (h *SprintHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+		r.Get("/tasks", h.listTasks)
+		r.Post("/tasks", h.addTasks)
+		r.Delete("/tasks/{taskId}", h.removeTask)
+		r.Post("/start", h.start)
+		r.Post("/complete", h.complete)
+		r.Get("/summary", h.summary)
+	})
+
+	return r
+}
+
+// get godoc
+// @Summary Get a sprint
+// @Tags Sprint endpoints
+// @Param id path string true "Sprint UUID"
+// @Success 200 {object} sprint.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /sprints/{id} [get]
+func (h *SprintHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetSprint(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// update godoc
+// @Summary Update a sprint
+// @Tags Sprint endpoints
+// @Accept json
+// @Param id path string true "Sprint UUID"
+// @Param body body sprint.UpdateRequest true "Sprint update request"
+// @Success 200 {string} string "Sprint updated"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Not a project maintainer"
+// @Failure 409 {object} response.Response "Sprint is completed"
+// @Router /sprints/{id} [put]
+func (h *SprintHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := sprint.UpdateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, sprint.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.UpdateSprint(r.Context(), id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// delete godoc
+// @Summary Delete a planned sprint
+// @Tags Sprint endpoints
+// @Param id path string true "Sprint UUID"
+// @Success 200 {string} string "Sprint deleted"
+// @Failure 403 {object} response.Response "Not a project maintainer"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Sprint already started"
+// @Router /sprints/{id} [delete]
+func (h *SprintHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.managementService.DeleteSprint(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// listTasks godoc
+// @Summary Tasks scheduled in a sprint
+// @Tags Sprint endpoints
+// @Param id path string true "Sprint UUID"
+// @Success 200 {array} sprint.ItemResponse
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /sprints/{id}/tasks [get]
+func (h *SprintHandler) listTasks(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListSprintTasks(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// addTasks godoc
+// @Summary Add tasks to a sprint
+// @Tags Sprint endpoints
+// @Accept json
+// @Param id path string true "Sprint UUID"
+// @Param body body sprint.TasksRequest true "Task IDs"
+// @Success 200 {string} string "Tasks added"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Not a project contributor"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Task already scheduled"
+// @Router /sprints/{id}/tasks [post]
+func (h *SprintHandler) addTasks(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := sprint.TasksRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, sprint.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.AddSprintTasks(r.Context(), id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// removeTask godoc
+// @Summary Remove a task from a sprint
+// @Tags Sprint endpoints
+// @Param id path string true "Sprint UUID"
+// @Param taskId path string true "Task UUID"
+// @Success 200 {string} string "Task removed"
+// @Failure 403 {object} response.Response "Not a project contributor"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /sprints/{id}/tasks/{taskId} [delete]
+func (h *SprintHandler) removeTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	taskID := chi.URLParam(r, "taskId")
+
+	err := h.managementService.RemoveSprintTask(r.Context(), id, taskID)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// start godoc
+// @Summary Start a sprint
+// @Description Tasks in the sprint become its committed scope
+// @Tags Sprint endpoints
+// @Param id path string true "Sprint UUID"
+// @Success 200 {string} string "Sprint started"
+// @Failure 403 {object} response.Response "Not a project maintainer"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Sprint is not planned or project has an active sprint"
+// @Router /sprints/{id}/start [post]
+func (h *SprintHandler) start(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.managementService.StartSprint(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// complete godoc
+// @Summary Complete a sprint
+// @Description Unfinished tasks roll over to next_sprint_id or go back to the backlog when it is empty
+// @Tags Sprint endpoints
+// @Accept json
+// @Param id path string true "Sprint UUID"
+// @Param body body sprint.CompleteRequest false "Rollover target"
+// @Success 200 {object} sprint.SummaryResponse
+// @Failure 400 {object} response.Response "Invalid rollover target"
+// @Failure 403 {object} response.Response "Not a project maintainer"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Sprint is not active"
+// @Router /sprints/{id}/complete [post]
+func (h *SprintHandler) complete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := sprint.CompleteRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		response.BadRequest(w, r, sprint.ErrBadRequest, req)
+		return
+	}
+
+	data, err := h.managementService.CompleteSprint(r.Context(), id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// summary godoc
+// @Summary Sprint summary
+// @Description Committed versus completed work of the sprint
+// @Tags Sprint endpoints
+// @Param id path string true "Sprint UUID"
+// @Success 200 {object} sprint.SummaryResponse
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /sprints/{id}/summary [get]
+func (h *SprintHandler) summary(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetSprintSummary(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+func (h *SprintHandler) respondError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, member.ErrForbidden), errors.Is(err, policy.ErrForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, sprint.ErrNotFound), errors.Is(err, task.ErrNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, sprint.ErrState), errors.Is(err, sprint.ErrActiveExists), errors.Is(err, sprint.ErrTaskInSprint), errors.Is(err, project.ErrArchived):
+		response.Conflict(w, r, err)
+	case errors.Is(err, sprint.ErrTaskProject), errors.Is(err, sprint.ErrRollover):
+		response.BadRequest(w, r, err, nil)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -3,9 +3,18 @@ package http
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 
+	"github.com/canyouhearthemusic/project-management/internal/domain/board"
+	"github.com/canyouhearthemusic/project-management/internal/domain/epic"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/milestone"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
 	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/team"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
 	"github.com/canyouhearthemusic/project-management/internal/service/management"
 	"github.com/canyouhearthemusic/project-management/pkg/response"
 	"github.com/go-chi/chi/v5"
@@ -27,11 +36,18 @@ func (h *TaskHandler) Routes() chi.Router {
 
 	r.Post("/", h.create)
 	r.Get("/", h.list)
+	r.Post("/bulk", h.bulk)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
 		r.Delete("/", h.delete)
+		r.Post("/move", h.move)
+		r.Post("/clone", h.clone)
+		r.Get("/history", h.history)
+		r.Get("/metrics", h.metrics)
+		r.Mount("/time-entries", NewCostHandler(h.managementService).TimeEntryRoutes())
+		r.Mount("/expenses", NewCostHandler(h.managementService).ExpenseRoutes())
 	})
 
 	r.Get("/search", h.search)
@@ -46,6 +62,9 @@ func (h *TaskHandler) Routes() chi.Router {
 // @Param body body task.Request true "Task request"
 // @Success 201 {object} response.Response "Response"
 // @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Project archived or strict WIP limit reached"
+// @Security Bearer
 // @Router /tasks [post]
 func (h *TaskHandler) create(w http.ResponseWriter, r *http.Request) {
 	req := task.Request{}
@@ -67,6 +86,21 @@ func (h *TaskHandler) create(w http.ResponseWriter, r *http.Request) {
 
 	msg, data, err := h.managementService.CreateTask(r.Context(), req)
 	if err != nil {
+		if errors.Is(err, member.ErrForbidden) || errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrArchived) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, board.ErrWIPLimit) {
+			response.Conflict(w, r, err)
+			return
+		}
+
 		response.BadRequest(w, r, err, data)
 		return
 	}
@@ -81,12 +115,19 @@ func (h *TaskHandler) create(w http.ResponseWriter, r *http.Request) {
 // @Param id path string true "Task UUID"
 // @Success 201 {object} task.Response "Response"
 // @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /tasks/{id} [get]
 func (h *TaskHandler) get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	data, err := h.managementService.GetTask(r.Context(), id)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		response.NotFound(w, r, err)
 		return
 	}
@@ -99,10 +140,17 @@ func (h *TaskHandler) get(w http.ResponseWriter, r *http.Request) {
 // @Tags Task endpoints
 // @Success 200 {array} task.Response
 // @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /tasks [get]
 func (h *TaskHandler) list(w http.ResponseWriter, r *http.Request) {
 	data, err := h.managementService.ListTasks(r.Context())
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		response.BadRequest(w, r, err, err.Error())
 		return
 	}
@@ -117,7 +165,12 @@ func (h *TaskHandler) list(w http.ResponseWriter, r *http.Request) {
 // @Param id path string true "Task UUID"
 // @Param body body task.UpdateRequest true "Task update request"
 // @Success 200 {string} string "Task updated"
+// @Success 200 {object} response.Response "Task updated over a column WIP limit"
 // @Failure 400 {object} []string "Validation errors"
+// @Failure 409 {object} response.Response "Strict WIP limit reached"
+// @Failure 401 {object} response.Response "Status changed without signing in"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /tasks/{id} [put]
 func (h *TaskHandler) update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -130,16 +183,52 @@ func (h *TaskHandler) update(w http.ResponseWriter, r *http.Request) {
 
 	if errs := req.Validate(); errs != nil {
 		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
 		response.BadRequests(w, r, errors, req)
 		return
 	}
 
-	err := h.managementService.UpdateTask(r.Context(), id, req)
+	warning, err := h.managementService.UpdateTask(r.Context(), id, req)
 	if err != nil {
+		if errors.Is(err, task.ErrNoActor) {
+			response.Unauthorized(w, r, err)
+			return
+		}
+
+		if errors.Is(err, member.ErrForbidden) || errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrArchived) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, board.ErrWIPLimit) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) || errors.Is(err, milestone.ErrNotFound) || errors.Is(err, milestone.ErrTaskProject) || errors.Is(err, epic.ErrNotFound) ||
+			errors.Is(err, task.ErrSchedule) || errors.Is(err, task.ErrPredecessor) || errors.Is(err, task.ErrCycle) ||
+			errors.Is(err, user.ErrNotFound) || errors.Is(err, team.ErrNotFound) {
+			response.BadRequest(w, r, err, req)
+			return
+		}
+
 		response.NotFound(w, r, err)
 		return
 	}
 
+	if warning != "" {
+		response.Warning(w, r, warning)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -149,12 +238,25 @@ func (h *TaskHandler) update(w http.ResponseWriter, r *http.Request) {
 // @Param id path string true "Task UUID"
 // @Success 200 {string} string "Task Deleted"
 // @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Project archived"
+// @Security Bearer
 // @Router /tasks/{id} [delete]
 func (h *TaskHandler) delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	err := h.managementService.DeleteTask(r.Context(), id)
 	if err != nil {
+		if errors.Is(err, member.ErrForbidden) || errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrArchived) {
+			response.Conflict(w, r, err)
+			return
+		}
+
 		response.NotFound(w, r, err)
 		return
 	}
@@ -174,6 +276,8 @@ func (h *TaskHandler) delete(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {array} task.Response
 // @Failure 400 {string} string "Bad request"
 // @Failure 404 {string} string "Not found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /tasks/search [get]
 func (h *TaskHandler) search(w http.ResponseWriter, r *http.Request) {
 	var filter, val string
@@ -184,6 +288,11 @@ func (h *TaskHandler) search(w http.ResponseWriter, r *http.Request) {
 
 	tasks, err := h.managementService.SearchTasks(r.Context(), filter, val)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		if errors.Is(err, task.ErrSearch) {
 			response.BadRequest(w, r, err, val)
 			return
@@ -200,3 +309,257 @@ func (h *TaskHandler) search(w http.ResponseWriter, r *http.Request) {
 
 	render.JSON(w, r, tasks)
 }
+
+// bulk godoc
+// @Summary Bulk task operation
+// @Description Selects tasks by ids or by filter (title, priority, status, author_id, project_id), at most 500 either way, and applies one operation: status, priority, project, assignee or delete. The tasks are selected and changed in a single transaction.
+// @Tags Task endpoints
+// @Accept json
+// @Param body body task.BulkRequest true "Bulk request"
+// @Success 200 {object} task.BulkResponse
+// @Failure 400 {object} response.Response "Validation errors or per-item report"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Project archived or strict WIP limit reached"
+// @Security Bearer
+// @Router /tasks/bulk [post]
+func (h *TaskHandler) bulk(w http.ResponseWriter, r *http.Request) {
+	req := task.BulkRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, task.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	data, err := h.managementService.BulkTasks(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, member.ErrForbidden) || errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrArchived) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, board.ErrWIPLimit) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, task.ErrBulkFailed) {
+			response.BadRequest(w, r, err, data)
+			return
+		}
+
+		if errors.Is(err, task.ErrTooMany) {
+			response.BadRequest(w, r, err, req)
+			return
+		}
+
+		if errors.Is(err, task.ErrNotFound) || errors.Is(err, project.ErrNotFound) || errors.Is(err, user.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// move godoc
+// @Summary Move a task to another project
+// @Tags Task endpoints
+// @Accept json
+// @Param id path string true "Task UUID"
+// @Param body body task.MoveRequest true "Target project"
+// @Success 200 {string} string "Task moved"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Project archived or strict WIP limit reached"
+// @Security Bearer
+// @Router /tasks/{id}/move [post]
+func (h *TaskHandler) move(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := task.MoveRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, task.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	warning, err := h.managementService.MoveTask(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, member.ErrForbidden) || errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrArchived) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, board.ErrWIPLimit) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.BadRequest(w, r, err, req)
+			return
+		}
+
+		response.NotFound(w, r, err)
+		return
+	}
+
+	if warning != "" {
+		response.Warning(w, r, warning)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// clone godoc
+// @Summary Clone a task
+// @Description Creates a copy of the task, optionally in another project
+// @Tags Task endpoints
+// @Accept json
+// @Param id path string true "Task UUID"
+// @Param body body task.CloneRequest false "Target project"
+// @Success 201 {object} response.Response "Response"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Project archived or strict WIP limit reached"
+// @Security Bearer
+// @Router /tasks/{id}/clone [post]
+func (h *TaskHandler) clone(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := task.CloneRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		response.BadRequest(w, r, task.ErrBadRequest, req)
+		return
+	}
+
+	msg, data, err := h.managementService.CloneTask(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, member.ErrForbidden) || errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrArchived) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, board.ErrWIPLimit) {
+			response.Conflict(w, r, err)
+			return
+		}
+
+		if errors.Is(err, project.ErrNotFound) {
+			response.BadRequest(w, r, err, req)
+			return
+		}
+
+		if errors.Is(err, task.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, msg, data)
+}
+
+// history godoc
+// @Summary Task status history
+// @Tags Task endpoints
+// @Param id path string true "Task UUID"
+// @Success 200 {array} task.StatusChangeResponse
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /tasks/{id}/history [get]
+func (h *TaskHandler) history(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetTaskHistory(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, task.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// metrics godoc
+// @Summary Task lead time, cycle time and time spent per status
+// @Tags Task endpoints
+// @Param id path string true "Task UUID"
+// @Success 200 {object} task.MetricsResponse
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
+// @Router /tasks/{id}/metrics [get]
+func (h *TaskHandler) metrics(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetTaskMetrics(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, task.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
@@ -0,0 +1,481 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/team"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+type TeamHandler struct {
+	managementService *management.Service
+}
+
+func NewTeamHandler(service *management.Service) *TeamHandler {
+	return &TeamHandler{
+		managementService: service,
+	}
+}
+
+func (h *TeamHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.create)
+	r.Get("/", h.list)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+		r.Get("/members", h.listMembers)
+		r.Post("/members", h.addMember)
+		r.Delete("/members/{userId}", h.removeMember)
+		r.Get("/projects", h.listProjects)
+		r.Post("/projects", h.addProjects)
+		r.Delete("/projects/{projectId}", h.removeProject)
+		r.Get("/tasks", h.listTasks)
+		r.Post("/tasks", h.addTasks)
+		r.Delete("/tasks/{taskId}", h.removeTask)
+		r.Get("/workload", h.workload)
+	})
+
+	return r
+}
+
+// create godoc
+// @Summary Create a team
+// @Description The lead becomes a member of the team
+// @Tags Team endpoints
+// @Accept json
+// @Param body body team.Request true "Team request"
+// @Success 201 {object} team.Response
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 409 {object} response.Response "Name taken"
+// @Router /teams [post]
+func (h *TeamHandler) create(w http.ResponseWriter, r *http.Request) {
+	req := team.Request{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, team.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	data, err := h.managementService.CreateTeam(r.Context(), req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.Created(w, r, "team has been created", data)
+}
+
+// list godoc
+// @Summary All teams with their members
+// @Tags Team endpoints
+// @Success 200 {array} team.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Router /teams [get]
+func (h *TeamHandler) list(w http.ResponseWriter, r *http.Request) {
+	data, err := h.managementService.ListTeams(r.Context())
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// get godoc
+// @Summary Get a team with its members
+// @Tags Team endpoints
+// @Param id path string true "Team UUID"
+// @Success 200 {object} team.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /teams/{id} [get]
+func (h *TeamHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetTeam(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// update godoc
+// @Summary Update a team
+// @Description A new lead becomes a member of the team
+// @Tags Team endpoints
+// @Accept json
+// @Param id path string true "Team UUID"
+// @Param body body team.UpdateRequest true "Team update request"
+// @Success 200 {string} string "Team updated"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Name taken"
+// @Router /teams/{id} [put]
+func (h *TeamHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := team.UpdateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, team.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.UpdateTeam(r.Context(), id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// delete godoc
+// @Summary Delete a team
+// @Description Projects and tasks of the team are kept unassigned
+// @Tags Team endpoints
+// @Param id path string true "Team UUID"
+// @Success 200 {string} string "Team deleted"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /teams/{id} [delete]
+func (h *TeamHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.managementService.DeleteTeam(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// listMembers godoc
+// @Summary Members of a team
+// @Tags Team endpoints
+// @Param id path string true "Team UUID"
+// @Success 200 {array} team.MemberResponse
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /teams/{id}/members [get]
+func (h *TeamHandler) listMembers(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListTeamMembers(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// addMember godoc
+// @Summary Add a member to a team
+// @Tags Team endpoints
+// @Accept json
+// @Param id path string true "Team UUID"
+// @Param body body team.MemberRequest true "Member request"
+// @Success 200 {string} string "Member added"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Already a member"
+// @Router /teams/{id}/members [post]
+func (h *TeamHandler) addMember(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := team.MemberRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, team.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.AddTeamMember(r.Context(), id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// removeMember godoc
+// @Summary Remove a member from a team
+// @Tags Team endpoints
+// @Param id path string true "Team UUID"
+// @Param userId path string true "User UUID"
+// @Success 200 {string} string "Member removed"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Member is the team lead"
+// @Router /teams/{id}/members/{userId} [delete]
+func (h *TeamHandler) removeMember(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "userId")
+
+	err := h.managementService.RemoveTeamMember(r.Context(), id, userID)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// listProjects godoc
+// @Summary Projects assigned to a team
+// @Tags Team endpoints
+// @Param id path string true "Team UUID"
+// @Success 200 {array} project.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /teams/{id}/projects [get]
+func (h *TeamHandler) listProjects(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListTeamProjects(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// addProjects godoc
+// @Summary Assign projects to a team
+// @Description Requires a maintainer role in every project; a project is assigned to at most one team
+// @Tags Team endpoints
+// @Accept json
+// @Param id path string true "Team UUID"
+// @Param body body team.ProjectsRequest true "Project IDs"
+// @Success 200 {string} string "Projects assigned"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Project archived"
+// @Security Bearer
+// @Router /teams/{id}/projects [post]
+func (h *TeamHandler) addProjects(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := team.ProjectsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, team.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.AddTeamProjects(r.Context(), id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// removeProject godoc
+// @Summary Unassign a project from a team
+// @Tags Team endpoints
+// @Param id path string true "Team UUID"
+// @Param projectId path string true "Project UUID"
+// @Success 200 {string} string "Project unassigned"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Project archived"
+// @Security Bearer
+// @Router /teams/{id}/projects/{projectId} [delete]
+func (h *TeamHandler) removeProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	projectID := chi.URLParam(r, "projectId")
+
+	err := h.managementService.RemoveTeamProject(r.Context(), id, projectID)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// listTasks godoc
+// @Summary Tasks of a team
+// @Description Tasks assigned to the team and tasks of its projects that are not assigned to another team
+// @Tags Team endpoints
+// @Param id path string true "Team UUID"
+// @Success 200 {array} task.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /teams/{id}/tasks [get]
+func (h *TeamHandler) listTasks(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListTeamTasks(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// addTasks godoc
+// @Summary Assign tasks to a team
+// @Description Requires a contributor role in the projects of the tasks; a task is assigned to at most one team
+// @Tags Team endpoints
+// @Accept json
+// @Param id path string true "Team UUID"
+// @Param body body team.TasksRequest true "Task IDs"
+// @Success 200 {string} string "Tasks assigned"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Project archived"
+// @Security Bearer
+// @Router /teams/{id}/tasks [post]
+func (h *TeamHandler) addTasks(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := team.TasksRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, team.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.AddTeamTasks(r.Context(), id, req)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// removeTask godoc
+// @Summary Unassign a task from a team
+// @Tags Team endpoints
+// @Param id path string true "Team UUID"
+// @Param taskId path string true "Task UUID"
+// @Success 200 {string} string "Task unassigned"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Failure 409 {object} response.Response "Project archived"
+// @Security Bearer
+// @Router /teams/{id}/tasks/{taskId} [delete]
+func (h *TeamHandler) removeTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	taskID := chi.URLParam(r, "taskId")
+
+	err := h.managementService.RemoveTeamTask(r.Context(), id, taskID)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// workload godoc
+// @Summary Workload of a team
+// @Description Open, in progress, overdue and high priority tasks of the team per member; tasks assigned outside the team are counted as unassigned
+// @Tags Team endpoints
+// @Param id path string true "Team UUID"
+// @Success 200 {object} team.WorkloadResponse
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /teams/{id}/workload [get]
+func (h *TeamHandler) workload(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetTeamWorkload(r.Context(), id)
+	if err != nil {
+		h.respondError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+func (h *TeamHandler) respondError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, team.ErrNotFound), errors.Is(err, team.ErrMemberNotFound), errors.Is(err, team.ErrNotAssigned),
+		errors.Is(err, project.ErrNotFound), errors.Is(err, task.ErrNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, team.ErrExists), errors.Is(err, team.ErrMemberExists), errors.Is(err, team.ErrLead),
+		errors.Is(err, project.ErrArchived):
+		response.Conflict(w, r, err)
+	case errors.Is(err, member.ErrForbidden), errors.Is(err, policy.ErrForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, user.ErrNotFound):
+		response.BadRequest(w, r, err, nil)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
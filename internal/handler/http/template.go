@@ -0,0 +1,201 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/template"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/response"
+	"github.com/go-chi/chi/v5"
+)
+
+type TemplateHandler struct {
+	managementService *management.Service
+}
+
+func NewTemplateHandler(service *management.Service) *TemplateHandler {
+	return &TemplateHandler{
+		managementService: service,
+	}
+}
+
+func (h *TemplateHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.create)
+	r.Get("/", h.list)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+	})
+
+	return r
+}
+
+// create godoc
+// @Summary Create a task template
+// @Description Title and description may contain {{variable}} placeholders. Leave project_id empty for a global template.
+// @Tags Template endpoints
+// @Accept json
+// @Param body body template.Request true "Template request"
+// @Success 201 {object} response.Response "Response"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Router /templates [post]
+func (h *TemplateHandler) create(w http.ResponseWriter, r *http.Request) {
+	req := template.Request{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		err = template.ErrBadRequest
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	msg, data, err := h.managementService.CreateTemplate(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.BadRequest(w, r, err, data)
+		return
+	}
+
+	response.Created(w, r, msg, data)
+}
+
+// get godoc
+// @Summary Get a task template
+// @Tags Template endpoints
+// @Param id path string true "Template UUID"
+// @Success 200 {object} template.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /templates/{id} [get]
+func (h *TemplateHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.GetTemplate(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// list godoc
+// @Summary All task templates
+// @Tags Template endpoints
+// @Success 200 {array} template.Response
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Router /templates [get]
+func (h *TemplateHandler) list(w http.ResponseWriter, r *http.Request) {
+	data, err := h.managementService.ListTemplates(r.Context())
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.BadRequest(w, r, err, err.Error())
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// update godoc
+// @Summary Update a task template
+// @Tags Template endpoints
+// @Accept json
+// @Param id path string true "Template UUID"
+// @Param body body template.UpdateRequest true "Template update request"
+// @Success 200 {string} string "Template updated"
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Router /templates/{id} [put]
+func (h *TemplateHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := template.UpdateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, template.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	err := h.managementService.UpdateTemplate(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, template.ErrNotFound) || errors.Is(err, user.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// delete godoc
+// @Summary Delete a task template
+// @Tags Template endpoints
+// @Param id path string true "Template UUID"
+// @Success 200 {string} string "Template deleted"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {object} response.Response "Not Found"
+// @Router /templates/{id} [delete]
+func (h *TemplateHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.managementService.DeleteTemplate(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		response.NotFound(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
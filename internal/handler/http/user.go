@@ -5,6 +5,9 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/canyouhearthemusic/project-management/internal/domain/budget"
+	"github.com/canyouhearthemusic/project-management/internal/domain/notification"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
 	"github.com/canyouhearthemusic/project-management/internal/domain/user"
 	"github.com/canyouhearthemusic/project-management/internal/service/management"
 	"github.com/canyouhearthemusic/project-management/pkg/response"
@@ -33,6 +36,13 @@ func (h *UserHandler) Routes() chi.Router {
 		r.Put("/", h.update)
 		r.Delete("/", h.delete)
 		r.Get("/tasks", h.listTasks)
+		r.Get("/notifications", h.listNotifications)
+		r.Post("/notifications/{notificationId}/read", h.readNotification)
+		r.Get("/rates", h.listRates)
+		r.Post("/rates", h.setRate)
+		r.Mount("/calendar-tokens", NewCalendarHandler(h.managementService).TokenRoutes())
+		r.Mount("/tokens", NewAccessTokenHandler(h.managementService).Routes())
+		r.Mount("/sessions", NewSessionHandler(h.managementService).Routes())
 	})
 
 	return r
@@ -43,10 +53,17 @@ func (h *UserHandler) Routes() chi.Router {
 // @Tags User endpoints
 // @Success 200 {array} user.Response
 // @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /users [get]
 func (h *UserHandler) list(w http.ResponseWriter, r *http.Request) {
 	data, err := h.managementService.ListUsers(r.Context())
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
@@ -61,6 +78,8 @@ func (h *UserHandler) list(w http.ResponseWriter, r *http.Request) {
 // @Param body body user.Request true "User request"
 // @Success 201 {object} user.Response "Response"
 // @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /users [post]
 func (h *UserHandler) create(w http.ResponseWriter, r *http.Request) {
 	req := user.Request{}
@@ -78,6 +97,11 @@ func (h *UserHandler) create(w http.ResponseWriter, r *http.Request) {
 
 	msg, data, err := h.managementService.CreateUser(r.Context(), req)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -92,12 +116,19 @@ func (h *UserHandler) create(w http.ResponseWriter, r *http.Request) {
 // @Param id path string true "User UUID"
 // @Success 201 {object} user.Response "Response"
 // @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /users/{id} [get]
 func (h *UserHandler) get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	data, err := h.managementService.GetUser(r.Context(), id)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		if errors.Is(err, user.ErrNotFound) {
 			w.WriteHeader(http.StatusNotFound)
 		}
@@ -117,6 +148,8 @@ func (h *UserHandler) get(w http.ResponseWriter, r *http.Request) {
 // @Param body body user.UpdateRequest true "User update request"
 // @Success 200 {string} string "User updated"
 // @Failure 400 {object} []string "Validation errors"
+// @Failure 403 {object} response.Response "Password of another user"
+// @Security Bearer
 // @Router /users/{id} [put]
 func (h *UserHandler) update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -129,12 +162,17 @@ func (h *UserHandler) update(w http.ResponseWriter, r *http.Request) {
 
 	if errs := req.Validate(); errs != nil {
 		errors := make([]error, len(errs))
+		req.Password = ""
 		response.BadRequests(w, r, errors, req)
 		return
 	}
 
 	err := h.managementService.UpdateUser(r.Context(), id, req)
 	if err != nil {
+		if errors.Is(err, user.ErrForbidden) || errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
 		if errors.Is(err, user.ErrNotFound) {
 			response.NotFound(w, r, err)
 		}
@@ -152,12 +190,19 @@ func (h *UserHandler) update(w http.ResponseWriter, r *http.Request) {
 // @Param id path string true "User UUID"
 // @Success 200 {string} string "User Deleted"
 // @Failure 404 {object} response.Response "Not Found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /users/{id} [delete]
 func (h *UserHandler) delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	err := h.managementService.DeleteUser(r.Context(), id)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		if errors.Is(err, user.ErrNotFound) {
 			response.NotFound(w, r, err)
 			return
@@ -174,12 +219,19 @@ func (h *UserHandler) delete(w http.ResponseWriter, r *http.Request) {
 // @Param id path string true "User UUID"
 // @Success 200 {array} task.Response
 // @Failure 400 {string} string "Bad request"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /users/{id}/tasks [get]
 func (h *UserHandler) listTasks(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	tasks, err := h.managementService.SearchTasks(r.Context(), "user_id", id)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		if errors.Is(err, user.ErrNotFound) {
 			response.NotFound(w, r, err)
 		}
@@ -200,6 +252,8 @@ func (h *UserHandler) listTasks(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {array} user.Response
 // @Failure 400 {string} string "Bad request"
 // @Failure 404 {string} string "Not found"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Security Bearer
 // @Router /users/search [get]
 func (h *UserHandler) search(w http.ResponseWriter, r *http.Request) {
 	var filter, val string
@@ -210,6 +264,11 @@ func (h *UserHandler) search(w http.ResponseWriter, r *http.Request) {
 
 	users, err := h.managementService.SearchUsers(r.Context(), filter, val)
 	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
 		if errors.Is(err, user.ErrSearch) {
 			response.BadRequest(w, r, err, val)
 			return
@@ -226,3 +285,152 @@ func (h *UserHandler) search(w http.ResponseWriter, r *http.Request) {
 
 	render.JSON(w, r, users)
 }
+
+// listNotifications godoc
+// @Summary Notifications of user
+// @Description Due-date reminders and overdue escalations raised for the user, newest first
+// @Tags User endpoints
+// @Param id path string true "User UUID"
+// @Param unread query bool false "Only unread notifications"
+// @Success 200 {array} notification.Response
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /users/{id}/notifications [get]
+func (h *UserHandler) listNotifications(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	unread := r.URL.Query().Get("unread") == "true"
+
+	data, err := h.managementService.ListUserNotifications(r.Context(), id, unread)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, user.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// readNotification godoc
+// @Summary Mark a notification as read
+// @Tags User endpoints
+// @Param id path string true "User UUID"
+// @Param notificationId path string true "Notification UUID"
+// @Success 200 {string} string "OK"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /users/{id}/notifications/{notificationId}/read [post]
+func (h *UserHandler) readNotification(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	notificationID := chi.URLParam(r, "notificationId")
+
+	if err := h.managementService.ReadNotification(r.Context(), id, notificationID); err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, notification.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, "notification marked as read")
+}
+
+// listRates godoc
+// @Summary Hourly rates of user
+// @Description Every rate the user has had, oldest first. A rate applies from effective_from until the next one.
+// @Tags User endpoints
+// @Param id path string true "User UUID"
+// @Success 200 {array} budget.RateResponse
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /users/{id}/rates [get]
+func (h *UserHandler) listRates(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.managementService.ListUserRates(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, policy.ErrForbidden) {
+			response.Forbidden(w, r, err)
+			return
+		}
+
+		if errors.Is(err, user.ErrNotFound) {
+			response.NotFound(w, r, err)
+			return
+		}
+
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, data)
+}
+
+// setRate godoc
+// @Summary Set a new hourly rate for user
+// @Description Takes effect on effective_from; earlier rates keep pricing the hours logged before
+// @Tags User endpoints
+// @Accept json
+// @Param id path string true "User UUID"
+// @Param body body budget.RateRequest true "Rate"
+// @Success 201 {object} budget.RateResponse
+// @Failure 400 {object} response.Response "Validation errors"
+// @Failure 403 {object} response.Response "Forbidden"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {object} response.Response "Rate already set for that day"
+// @Failure 500 {string} string "Internal server error"
+// @Router /users/{id}/rates [post]
+func (h *UserHandler) setRate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := budget.RateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, r, budget.ErrBadRequest, req)
+		return
+	}
+
+	if errs := req.Validate(); errs != nil {
+		errors := make([]error, len(errs))
+		for i, err := range errs {
+			errors[i] = err
+		}
+
+		response.BadRequests(w, r, errors, req)
+		return
+	}
+
+	data, err := h.managementService.SetUserRate(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, policy.ErrForbidden):
+			response.Forbidden(w, r, err)
+		case errors.Is(err, user.ErrNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, budget.ErrRateExists):
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.Created(w, r, "rate has been set", data)
+}
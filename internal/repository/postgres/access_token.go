@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/accesstoken"
+	"github.com/jmoiron/sqlx"
+)
+
+const accessTokenColumns = `
+	id, user_id, name, scopes, token_hash, expires_at, created_at, organization_id
+`
+
+type AccessTokenRepository struct {
+	db *sqlx.DB
+}
+
+func NewAccessTokenRepository(db *sqlx.DB) *AccessTokenRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &AccessTokenRepository{
+		db: db,
+	}
+}
+
+func (r *AccessTokenRepository) Create(ctx context.Context, t accesstoken.Token) (err error) {
+	q := `
+		INSERT INTO access_tokens (id, user_id, name, scopes, token_hash, expires_at, created_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, q, t.ID, t.UserID, t.Name, t.Scopes, t.Hash, t.ExpiresAt, t.CreatedAt, tenant(ctx))
+
+	return
+}
+
+func (r *AccessTokenRepository) List(ctx context.Context, userID string) (tokens []accesstoken.Token, err error) {
+	tokens = []accesstoken.Token{}
+
+	q := fmt.Sprintf("SELECT %s FROM access_tokens WHERE user_id = $1 AND organization_id = $2 ORDER BY created_at", accessTokenColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &tokens, q, userID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *AccessTokenRepository) Delete(ctx context.Context, userID, id string) (err error) {
+	q := `
+	DELETE FROM access_tokens WHERE id = $1 AND user_id = $2 AND organization_id = $3 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, userID, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = accesstoken.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *AccessTokenRepository) GetByHash(ctx context.Context, hash string) (t accesstoken.Token, err error) {
+	q := fmt.Sprintf("SELECT %s FROM access_tokens WHERE token_hash = $1 AND organization_id = $2", accessTokenColumns)
+
+	if err = conn(ctx, r.db).GetContext(ctx, &t, q, hash, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = accesstoken.ErrInvalidToken
+			return
+		}
+	}
+
+	return
+}
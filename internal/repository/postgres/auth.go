@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/jmoiron/sqlx"
+)
+
+const refreshTokenColumns = `
+	id, user_id, session_id, token_hash, expires_at, created_at, organization_id
+`
+
+type AuthRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuthRepository(db *sqlx.DB) *AuthRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &AuthRepository{
+		db: db,
+	}
+}
+
+func (r *AuthRepository) Create(ctx context.Context, t auth.RefreshToken) (err error) {
+	q := `
+		INSERT INTO refresh_tokens (id, user_id, session_id, token_hash, expires_at, created_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, q, t.ID, t.UserID, t.SessionID, t.Hash, t.ExpiresAt, t.CreatedAt, tenant(ctx))
+
+	return
+}
+
+func (r *AuthRepository) Consume(ctx context.Context, hash string) (t auth.RefreshToken, err error) {
+	q := fmt.Sprintf("DELETE FROM refresh_tokens WHERE token_hash = $1 AND organization_id = $2 RETURNING %s", refreshTokenColumns)
+
+	if err = conn(ctx, r.db).GetContext(ctx, &t, q, hash, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = auth.ErrInvalidToken
+			return
+		}
+	}
+
+	return
+}
+
+// CreateLogin also drops the logins abandoned at the provider.
+func (r *AuthRepository) CreateLogin(ctx context.Context, l auth.Login) (err error) {
+	if _, err = conn(ctx, r.db).ExecContext(ctx, "DELETE FROM oidc_logins WHERE expires_at < $1", time.Now().UTC()); err != nil {
+		return
+	}
+
+	q := `
+		INSERT INTO oidc_logins (state_hash, verifier, nonce, expires_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, q, l.StateHash, l.Verifier, l.Nonce, l.ExpiresAt, tenant(ctx))
+
+	return
+}
+
+func (r *AuthRepository) ConsumeLogin(ctx context.Context, stateHash string) (l auth.Login, err error) {
+	q := `
+	DELETE FROM oidc_logins WHERE state_hash = $1 RETURNING state_hash, verifier, nonce, expires_at, organization_id
+	`
+
+	if err = conn(ctx, r.db).GetContext(ctx, &l, q, stateHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = auth.ErrInvalidLogin
+			return
+		}
+	}
+
+	return
+}
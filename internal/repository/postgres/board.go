@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/board"
+	"github.com/jmoiron/sqlx"
+)
+
+type BoardRepository struct {
+	db *sqlx.DB
+}
+
+func NewBoardRepository(db *sqlx.DB) *BoardRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &BoardRepository{
+		db: db,
+	}
+}
+
+func (r *BoardRepository) Limits(ctx context.Context, projectID string) (limits []board.Limit, err error) {
+	limits = []board.Limit{}
+
+	q := `
+	SELECT project_id, status, wip_limit, strict FROM project_wip_limits WHERE project_id = $1 AND organization_id = $2
+	`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &limits, q, projectID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *BoardRepository) SetLimits(ctx context.Context, projectID string, limits []board.Limit) (err error) {
+	q := `
+		INSERT INTO project_wip_limits (project_id, status, wip_limit, strict, organization_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	return transaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM project_wip_limits WHERE project_id = $1 AND organization_id = $2", projectID, tenant(ctx)); err != nil {
+			return err
+		}
+
+		for _, l := range limits {
+			if _, err := tx.ExecContext(ctx, q, projectID, l.Status, l.WIPLimit, l.Strict, tenant(ctx)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/budget"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	rateColumns      = `id, user_id, hourly_rate, currency, effective_from`
+	timeEntryColumns = `id, task_id, user_id, hours, spent_on, note`
+	expenseColumns   = `id, task_id, amount, currency, spent_on, description`
+)
+
+type BudgetRepository struct {
+	db *sqlx.DB
+}
+
+func NewBudgetRepository(db *sqlx.DB) *BudgetRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &BudgetRepository{
+		db: db,
+	}
+}
+
+func (r *BudgetRepository) AddRate(ctx context.Context, rate budget.Rate) (err error) {
+	q := `
+		INSERT INTO user_rates (id, user_id, hourly_rate, currency, effective_from, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	args := []any{rate.ID, rate.UserID, rate.HourlyRate, rate.Currency, rate.EffectiveFrom, tenant(ctx)}
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, q, args...); err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return budget.ErrRateExists
+		}
+	}
+
+	return
+}
+
+func (r *BudgetRepository) ListRates(ctx context.Context, userID string) (rates []budget.Rate, err error) {
+	rates = []budget.Rate{}
+
+	q := "SELECT " + rateColumns + " FROM user_rates WHERE user_id = $1 AND organization_id = $2 ORDER BY effective_from"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &rates, q, userID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *BudgetRepository) AddTimeEntry(ctx context.Context, e budget.TimeEntry) (err error) {
+	q := `
+		INSERT INTO time_entries (id, task_id, user_id, hours, spent_on, note, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	args := []any{e.ID, e.TaskID, e.UserID, e.Hours, e.SpentOn, e.Note, tenant(ctx)}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, q, args...)
+
+	return
+}
+
+func (r *BudgetRepository) GetTimeEntry(ctx context.Context, taskID, id string) (e budget.TimeEntry, err error) {
+	q := "SELECT " + timeEntryColumns + " FROM time_entries WHERE task_id = $1 AND id = $2 AND organization_id = $3"
+
+	if err = conn(ctx, r.db).GetContext(ctx, &e, q, taskID, id, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = budget.ErrNotFound
+		}
+		return
+	}
+
+	return
+}
+
+func (r *BudgetRepository) ListTimeEntries(ctx context.Context, taskID string) (entries []budget.TimeEntry, err error) {
+	entries = []budget.TimeEntry{}
+
+	q := "SELECT " + timeEntryColumns + " FROM time_entries WHERE task_id = $1 AND organization_id = $2 ORDER BY spent_on, id"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &entries, q, taskID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *BudgetRepository) DeleteTimeEntry(ctx context.Context, taskID, id string) (err error) {
+	q := `
+	DELETE FROM time_entries WHERE task_id = $1 AND id = $2 AND organization_id = $3 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, taskID, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = budget.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *BudgetRepository) AddExpense(ctx context.Context, e budget.Expense) (err error) {
+	q := `
+		INSERT INTO expenses (id, task_id, amount, currency, spent_on, description, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	args := []any{e.ID, e.TaskID, e.Amount, e.Currency, e.SpentOn, e.Description, tenant(ctx)}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, q, args...)
+
+	return
+}
+
+func (r *BudgetRepository) ListExpenses(ctx context.Context, taskID string) (expenses []budget.Expense, err error) {
+	expenses = []budget.Expense{}
+
+	q := "SELECT " + expenseColumns + " FROM expenses WHERE task_id = $1 AND organization_id = $2 ORDER BY spent_on, id"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &expenses, q, taskID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *BudgetRepository) DeleteExpense(ctx context.Context, taskID, id string) (err error) {
+	q := `
+	DELETE FROM expenses WHERE task_id = $1 AND id = $2 AND organization_id = $3 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, taskID, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = budget.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *BudgetRepository) Costs(ctx context.Context, projectID string) (costs []budget.Cost, err error) {
+	costs = []budget.Cost{}
+
+	q := `
+	SELECT 'labour' AS kind, COALESCE(rate.currency, '') AS currency,
+		SUM(e.hours) AS hours, COALESCE(SUM(e.hours * rate.hourly_rate), 0) AS amount
+	FROM time_entries e
+	JOIN tasks t ON t.id = e.task_id
+	LEFT JOIN LATERAL (
+		SELECT hourly_rate, currency FROM user_rates
+		WHERE user_id = e.user_id AND effective_from <= e.spent_on AND organization_id = e.organization_id
+		ORDER BY effective_from DESC LIMIT 1
+	) rate ON true
+	WHERE t.project_id = $1 AND t.organization_id = $2
+	GROUP BY rate.currency
+	UNION ALL
+	SELECT 'expense', x.currency, 0, SUM(x.amount)
+	FROM expenses x
+	JOIN tasks t ON t.id = x.task_id
+	WHERE t.project_id = $1 AND t.organization_id = $2
+	GROUP BY x.currency
+	`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &costs, q, projectID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
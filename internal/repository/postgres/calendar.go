@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/calendar"
+	"github.com/jmoiron/sqlx"
+)
+
+const calendarTokenColumns = `
+	id, user_id, name, token_hash, created_at, organization_id
+`
+
+type CalendarRepository struct {
+	db *sqlx.DB
+}
+
+func NewCalendarRepository(db *sqlx.DB) *CalendarRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &CalendarRepository{
+		db: db,
+	}
+}
+
+func (r *CalendarRepository) Create(ctx context.Context, t calendar.Token) (err error) {
+	q := `
+		INSERT INTO calendar_tokens (id, user_id, name, token_hash, created_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, q, t.ID, t.UserID, t.Name, t.Hash, t.CreatedAt, tenant(ctx))
+
+	return
+}
+
+func (r *CalendarRepository) List(ctx context.Context, userID string) (tokens []calendar.Token, err error) {
+	tokens = []calendar.Token{}
+
+	q := fmt.Sprintf("SELECT %s FROM calendar_tokens WHERE user_id = $1 AND organization_id = $2 ORDER BY created_at", calendarTokenColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &tokens, q, userID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *CalendarRepository) Delete(ctx context.Context, userID, id string) (err error) {
+	q := `
+	DELETE FROM calendar_tokens WHERE id = $1 AND user_id = $2 AND organization_id = $3 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, userID, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = calendar.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *CalendarRepository) GetByHash(ctx context.Context, hash string) (t calendar.Token, err error) {
+	q := fmt.Sprintf("SELECT %s FROM calendar_tokens WHERE token_hash = $1", calendarTokenColumns)
+
+	if err = conn(ctx, r.db).GetContext(ctx, &t, q, hash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = calendar.ErrInvalidToken
+			return
+		}
+	}
+
+	return
+}
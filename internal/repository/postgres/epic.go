@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/epic"
+	"github.com/jmoiron/sqlx"
+)
+
+const epicColumns = `
+	id, title, description, COALESCE(owner_id, '') AS owner_id, created_at
+`
+
+type EpicRepository struct {
+	db *sqlx.DB
+}
+
+func NewEpicRepository(db *sqlx.DB) *EpicRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &EpicRepository{
+		db: db,
+	}
+}
+
+func (r *EpicRepository) Create(ctx context.Context, e epic.Entity) (string, epic.Entity, error) {
+	q := `
+		INSERT INTO epics (id, title, description, owner_id, created_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id
+	`
+
+	args := []any{e.ID, e.Title, e.Description, e.OwnerID, e.CreatedAt, tenant(ctx)}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return "", epic.Entity{}, err
+	}
+
+	return "epic has been created", e, nil
+}
+
+func (r *EpicRepository) Update(ctx context.Context, id string, e epic.Entity) (err error) {
+	sets, args := r.prepareArgs(e)
+	if len(sets) > 0 {
+		args = append(args, id, tenant(ctx))
+		q := fmt.Sprintf("UPDATE epics SET %s WHERE id = $%d AND organization_id = $%d RETURNING id", strings.Join(sets, ", "), len(args)-1, len(args))
+
+		err = conn(ctx, r.db).QueryRowContext(ctx, q, args...).Scan(&id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = epic.ErrNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *EpicRepository) Get(ctx context.Context, id string) (e epic.Entity, err error) {
+	e = epic.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM epics WHERE id = $1 AND organization_id = $2", epicColumns)
+
+	if err = conn(ctx, r.db).GetContext(ctx, &e, q, id, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = epic.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *EpicRepository) Delete(ctx context.Context, id string) (err error) {
+	q := `
+	DELETE FROM epics WHERE id = $1 AND organization_id = $2 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = epic.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *EpicRepository) List(ctx context.Context) (epics []epic.Entity, err error) {
+	epics = []epic.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM epics WHERE organization_id = $1 ORDER BY created_at", epicColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &epics, q, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *EpicRepository) prepareArgs(data epic.Entity) (sets []string, args []any) {
+	if data.Title != "" {
+		args = append(args, data.Title)
+		sets = append(sets, fmt.Sprintf("title=$%d", len(args)))
+	}
+
+	if data.Description != "" {
+		args = append(args, data.Description)
+		sets = append(sets, fmt.Sprintf("description=$%d", len(args)))
+	}
+
+	if data.OwnerID != "" {
+		args = append(args, data.OwnerID)
+		sets = append(sets, fmt.Sprintf("owner_id=$%d", len(args)))
+	}
+
+	return
+}
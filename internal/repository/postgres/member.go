@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const memberColumns = `
+	m.project_id, m.user_id, m.role, m.added_at, u.name, u.email
+`
+
+type MemberRepository struct {
+	db *sqlx.DB
+}
+
+func NewMemberRepository(db *sqlx.DB) *MemberRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &MemberRepository{
+		db: db,
+	}
+}
+
+func (r *MemberRepository) List(ctx context.Context, projectID string) (members []member.Entity, err error) {
+	members = []member.Entity{}
+
+	q := `SELECT ` + memberColumns + `
+	FROM project_members m JOIN users u ON u.id = m.user_id
+	WHERE m.project_id = $1 AND m.organization_id = $2
+	ORDER BY m.added_at, u.name
+	`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &members, q, projectID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *MemberRepository) ListByUser(ctx context.Context, userID string) (members []member.Entity, err error) {
+	members = []member.Entity{}
+
+	q := `SELECT ` + memberColumns + `
+	FROM project_members m JOIN users u ON u.id = m.user_id
+	WHERE m.user_id = $1 AND m.organization_id = $2
+	ORDER BY m.added_at
+	`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &members, q, userID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *MemberRepository) Get(ctx context.Context, projectID, userID string) (m member.Entity, err error) {
+	q := `SELECT ` + memberColumns + `
+	FROM project_members m JOIN users u ON u.id = m.user_id
+	WHERE m.project_id = $1 AND m.user_id = $2 AND m.organization_id = $3
+	`
+
+	if err = conn(ctx, r.db).GetContext(ctx, &m, q, projectID, userID, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = member.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *MemberRepository) Add(ctx context.Context, m member.Entity) (err error) {
+	q := `INSERT INTO project_members (project_id, user_id, role, organization_id) VALUES ($1, $2, $3, $4)`
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, q, m.ProjectID, m.UserID, m.Role, tenant(ctx)); err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return member.ErrExists
+		}
+	}
+
+	return
+}
+
+func (r *MemberRepository) SetRole(ctx context.Context, projectID, userID, role string) (err error) {
+	q := `UPDATE project_members SET role = $3 WHERE project_id = $1 AND user_id = $2 AND organization_id = $4 RETURNING user_id`
+
+	return transaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		if role != member.RoleOwner {
+			if err := r.checkLastOwner(ctx, tx, projectID, userID); err != nil {
+				return err
+			}
+		}
+
+		err := tx.QueryRowContext(ctx, q, projectID, userID, role, tenant(ctx)).Scan(&userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return member.ErrNotFound
+		}
+
+		return err
+	})
+}
+
+func (r *MemberRepository) Remove(ctx context.Context, projectID, userID string) (err error) {
+	q := `DELETE FROM project_members WHERE project_id = $1 AND user_id = $2 AND organization_id = $3 RETURNING user_id`
+
+	return transaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := r.checkLastOwner(ctx, tx, projectID, userID); err != nil {
+			return err
+		}
+
+		err := tx.QueryRowContext(ctx, q, projectID, userID, tenant(ctx)).Scan(&userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return member.ErrNotFound
+		}
+
+		return err
+	})
+}
+
+// checkLastOwner locks the project's owners and fails when userID is the only one.
+func (r *MemberRepository) checkLastOwner(ctx context.Context, tx *sqlx.Tx, projectID, userID string) error {
+	owners := []string{}
+
+	q := `SELECT user_id FROM project_members WHERE project_id = $1 AND role = 'owner' AND organization_id = $2 FOR UPDATE`
+
+	if err := tx.SelectContext(ctx, &owners, q, projectID, tenant(ctx)); err != nil {
+		return err
+	}
+
+	if len(owners) == 1 && owners[0] == userID {
+		return member.ErrLastOwner
+	}
+
+	return nil
+}
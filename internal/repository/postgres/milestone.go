@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/milestone"
+	"github.com/jmoiron/sqlx"
+)
+
+const milestoneColumns = `
+	id, project_id, title, description, target_date, created_at
+`
+
+type MilestoneRepository struct {
+	db *sqlx.DB
+}
+
+func NewMilestoneRepository(db *sqlx.DB) *MilestoneRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &MilestoneRepository{
+		db: db,
+	}
+}
+
+func (r *MilestoneRepository) Create(ctx context.Context, m milestone.Entity) (string, milestone.Entity, error) {
+	q := `
+		INSERT INTO milestones (id, project_id, title, description, target_date, created_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+	`
+
+	args := []any{m.ID, m.ProjectID, m.Title, m.Description, m.TargetDate, m.CreatedAt, tenant(ctx)}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return "", milestone.Entity{}, err
+	}
+
+	return "milestone has been created", m, nil
+}
+
+func (r *MilestoneRepository) Update(ctx context.Context, id string, m milestone.Entity) (err error) {
+	sets, args := r.prepareArgs(m)
+	if len(sets) > 0 {
+		args = append(args, id, tenant(ctx))
+		q := fmt.Sprintf("UPDATE milestones SET %s WHERE id = $%d AND organization_id = $%d RETURNING id", strings.Join(sets, ", "), len(args)-1, len(args))
+
+		err = conn(ctx, r.db).QueryRowContext(ctx, q, args...).Scan(&id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = milestone.ErrNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *MilestoneRepository) Get(ctx context.Context, id string) (m milestone.Entity, err error) {
+	m = milestone.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM milestones WHERE id = $1 AND organization_id = $2", milestoneColumns)
+
+	if err = conn(ctx, r.db).GetContext(ctx, &m, q, id, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = milestone.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *MilestoneRepository) Delete(ctx context.Context, id string) (err error) {
+	q := `
+	DELETE FROM milestones WHERE id = $1 AND organization_id = $2 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = milestone.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *MilestoneRepository) ListByProject(ctx context.Context, projectID string) (milestones []milestone.Entity, err error) {
+	milestones = []milestone.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM milestones WHERE project_id = $1 AND organization_id = $2 ORDER BY target_date", milestoneColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &milestones, q, projectID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *MilestoneRepository) prepareArgs(data milestone.Entity) (sets []string, args []any) {
+	if data.Title != "" {
+		args = append(args, data.Title)
+		sets = append(sets, fmt.Sprintf("title=$%d", len(args)))
+	}
+
+	if data.Description != "" {
+		args = append(args, data.Description)
+		sets = append(sets, fmt.Sprintf("description=$%d", len(args)))
+	}
+
+	if data.TargetDate != "" {
+		args = append(args, data.TargetDate)
+		sets = append(sets, fmt.Sprintf("target_date=$%d", len(args)))
+	}
+
+	return
+}
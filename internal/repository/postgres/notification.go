@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/notification"
+	"github.com/jmoiron/sqlx"
+)
+
+type NotificationRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) *NotificationRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, n notification.Entity) (created bool, err error) {
+	q := `
+		INSERT INTO notifications (id, user_id, task_id, kind, message, dedup_key, organization_id)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7)
+		ON CONFLICT (dedup_key) DO NOTHING
+		RETURNING id
+	`
+
+	args := []any{n.ID, n.UserID, n.TaskID, n.Kind, n.Message, n.DedupKey, tenant(ctx)}
+
+	var id string
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return
+	}
+
+	return true, nil
+}
+
+func (r *NotificationRepository) ListByUser(ctx context.Context, userID string, unreadOnly bool) (notifications []notification.Entity, err error) {
+	notifications = []notification.Entity{}
+
+	q := `
+	SELECT id, user_id, COALESCE(task_id, '') AS task_id, kind, message, dedup_key, created_at, read_at
+	FROM notifications
+	WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL) AND organization_id = $3
+	ORDER BY created_at DESC
+	`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &notifications, q, userID, unreadOnly, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, id string) (err error) {
+	q := `
+	UPDATE notifications SET read_at = COALESCE(read_at, now())
+	WHERE id = $1 AND user_id = $2 AND organization_id = $3 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, userID, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = notification.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/organization"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type OrganizationRepository struct {
+	db *sqlx.DB
+}
+
+func NewOrganizationRepository(db *sqlx.DB) *OrganizationRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &OrganizationRepository{
+		db: db,
+	}
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, o organization.Entity) (err error) {
+	q := `
+		INSERT INTO organizations (id, name, slug, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, q, o.ID, o.Name, o.Slug, o.CreatedAt); err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return organization.ErrExists
+		}
+	}
+
+	return
+}
+
+func (r *OrganizationRepository) Get(ctx context.Context, id string) (o organization.Entity, err error) {
+	q := `
+	SELECT id, name, slug, created_at FROM organizations WHERE id = $1
+	`
+
+	if err = conn(ctx, r.db).GetContext(ctx, &o, q, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = organization.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *OrganizationRepository) GetBySlug(ctx context.Context, slug string) (o organization.Entity, err error) {
+	q := `
+	SELECT id, name, slug, created_at FROM organizations WHERE slug = $1
+	`
+
+	if err = conn(ctx, r.db).GetContext(ctx, &o, q, slug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = organization.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *OrganizationRepository) List(ctx context.Context) (organizations []organization.Entity, err error) {
+	organizations = []organization.Entity{}
+
+	q := "SELECT id, name, slug, created_at FROM organizations ORDER BY name"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &organizations, q)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -1,10 +1,12 @@
 package postgres
 
 import (
+	"context"
 	_ "database/sql"
 	"fmt"
 
 	"github.com/canyouhearthemusic/project-management/config"
+	"github.com/canyouhearthemusic/project-management/internal/domain"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
@@ -52,3 +54,9 @@ func (db *DB) Migrate() error {
 
 	return nil
 }
+
+// tenant is the organization every statement issued for ctx is confined to.
+// Without one no rows match.
+func tenant(ctx context.Context) string {
+	return domain.TenantFromContext(ctx)
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/projecttemplate"
+	"github.com/jmoiron/sqlx"
+)
+
+const projectTemplateColumns = `
+	id, name, description, duration_days, COALESCE(source_project_id, '') AS source_project_id, created_at
+`
+
+type ProjectTemplateRepository struct {
+	db *sqlx.DB
+}
+
+func NewProjectTemplateRepository(db *sqlx.DB) *ProjectTemplateRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &ProjectTemplateRepository{
+		db: db,
+	}
+}
+
+func (r *ProjectTemplateRepository) Create(ctx context.Context, t projecttemplate.Entity) (msg string, obj projecttemplate.Entity, err error) {
+	q := `
+		INSERT INTO project_templates (id, name, description, duration_days, source_project_id, created_at, organization_id)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
+	`
+
+	args := []any{t.ID, t.Name, t.Description, t.DurationDays, t.SourceProjectID, t.CreatedAt, tenant(ctx)}
+
+	qt := `
+		INSERT INTO project_template_tasks (template_id, position, title, description, priority, status, start_offset, due_offset, predecessors, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	err = transaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+
+		for _, tt := range t.Tasks {
+			args := []any{t.ID, tt.Position, tt.Title, tt.Description, tt.Priority, tt.Status, tt.StartOffset, tt.DueOffset, tt.Predecessors, tenant(ctx)}
+
+			if _, err := tx.ExecContext(ctx, qt, args...); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	return "project template has been created", t, nil
+}
+
+func (r *ProjectTemplateRepository) Get(ctx context.Context, id string) (t projecttemplate.Entity, err error) {
+	q := `SELECT ` + projectTemplateColumns + ` FROM project_templates WHERE id = $1 AND organization_id = $2`
+
+	if err = conn(ctx, r.db).GetContext(ctx, &t, q, id, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = projecttemplate.ErrNotFound
+		}
+		return
+	}
+
+	q = `
+	SELECT template_id, position, title, description, COALESCE(priority, '') AS priority,
+		COALESCE(status, '') AS status, start_offset, due_offset, predecessors
+	FROM project_template_tasks
+	WHERE template_id = $1 AND organization_id = $2
+	ORDER BY position
+	`
+
+	t.Tasks = []projecttemplate.Task{}
+	if err = conn(ctx, r.db).SelectContext(ctx, &t.Tasks, q, id, tenant(ctx)); err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *ProjectTemplateRepository) List(ctx context.Context) (templates []projecttemplate.Entity, err error) {
+	templates = []projecttemplate.Entity{}
+
+	q := `SELECT ` + projectTemplateColumns + ` FROM project_templates WHERE organization_id = $1 ORDER BY name`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &templates, q, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *ProjectTemplateRepository) Delete(ctx context.Context, id string) (err error) {
+	q := `DELETE FROM project_templates WHERE id = $1 AND organization_id = $2 RETURNING id`
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = projecttemplate.ErrNotFound
+		}
+	}
+
+	return
+}
@@ -12,6 +12,12 @@ import (
 	"github.com/lib/pq"
 )
 
+const projectColumns = `
+	id, title, description, started_at, finished_at, COALESCE(manager_id, '') AS manager_id, status,
+	budget_amount, budget_currency, COALESCE(team_id, '') AS team_id,
+	health, health_score, health_reasons
+`
+
 type ProjectRepository struct {
 	db *sqlx.DB
 }
@@ -28,13 +34,13 @@ func NewProjectRepository(db *sqlx.DB) *ProjectRepository {
 
 func (r *ProjectRepository) Create(ctx context.Context, p project.Entity) (string, project.Entity, error) {
 	q := `
-		INSERT INTO projects (id, title, description, manager_id, started_at, finished_at)
-		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id
+		INSERT INTO projects (id, title, description, manager_id, started_at, finished_at, status, budget_amount, budget_currency, team_id, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11) RETURNING id
 	`
 
-	args := []any{p.ID, p.Title, p.Description, p.ManagerID, p.StartedAt, p.FinishedAt}
+	args := []any{p.ID, p.Title, p.Description, p.ManagerID, p.StartedAt, p.FinishedAt, p.Status, p.BudgetAmount, p.BudgetCurrency, p.TeamID, tenant(ctx)}
 
-	_, err := r.db.ExecContext(ctx, q, args...)
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", project.Entity{}, project.ErrExists
@@ -51,10 +57,10 @@ func (r *ProjectRepository) Create(ctx context.Context, p project.Entity) (strin
 func (r *ProjectRepository) Update(ctx context.Context, id string, p project.Entity) (err error) {
 	sets, args := r.prepareArgs(p)
 	if len(sets) > 0 {
-		args = append(args, id)
-		q := fmt.Sprintf("UPDATE projects SET %s WHERE id = $%d RETURNING ID", strings.Join(sets, ", "), len(args))
+		args = append(args, id, tenant(ctx))
+		q := fmt.Sprintf("UPDATE projects SET %s WHERE id = $%d AND organization_id = $%d RETURNING ID", strings.Join(sets, ", "), len(args)-1, len(args))
 
-		err = r.db.QueryRowContext(ctx, q, args...).Scan(&id)
+		err = conn(ctx, r.db).QueryRowContext(ctx, q, args...).Scan(&id)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				err = project.ErrNotFound
@@ -68,10 +74,10 @@ func (r *ProjectRepository) Update(ctx context.Context, id string, p project.Ent
 func (r *ProjectRepository) Delete(ctx context.Context, id string) (err error) {
 	q := `
 	DELETE FROM projects
-	WHERE id = $1 RETURNING id
+	WHERE id = $1 AND organization_id = $2 RETURNING id
 	`
 
-	err = r.db.QueryRowContext(ctx, q, id).Scan(&id)
+	err = conn(ctx, r.db).QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = project.ErrNotFound
@@ -84,11 +90,9 @@ func (r *ProjectRepository) Delete(ctx context.Context, id string) (err error) {
 func (r *ProjectRepository) Get(ctx context.Context, id string) (p project.Entity, err error) {
 	p = project.Entity{}
 
-	q := `
-	SELECT * FROM projects WHERE id = $1
-	`
+	q := fmt.Sprintf("SELECT %s FROM projects WHERE id = $1 AND organization_id = $2", projectColumns)
 
-	err = r.db.GetContext(ctx, &p, q, id)
+	err = conn(ctx, r.db).GetContext(ctx, &p, q, id, tenant(ctx))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = project.ErrNotFound
@@ -98,10 +102,10 @@ func (r *ProjectRepository) Get(ctx context.Context, id string) (p project.Entit
 	return
 }
 
-func (r *ProjectRepository) List(ctx context.Context) (projects []project.Entity, err error) {
-	s := "SELECT * FROM projects"
+func (r *ProjectRepository) List(ctx context.Context, includeArchived bool) (projects []project.Entity, err error) {
+	s := fmt.Sprintf("SELECT %s FROM projects WHERE organization_id = $1 AND ($2 OR status <> 'archived')", projectColumns)
 
-	err = r.db.SelectContext(ctx, &projects, s)
+	err = conn(ctx, r.db).SelectContext(ctx, &projects, s, tenant(ctx), includeArchived)
 	if err != nil {
 		return
 	}
@@ -114,9 +118,9 @@ func (r *ProjectRepository) Search(ctx context.Context, arg, value string) (proj
 
 	filter := r.prepareFilterArg(arg)
 
-	q := fmt.Sprintf("SELECT * FROM projects WHERE %s = $1", filter)
+	q := fmt.Sprintf("SELECT %s FROM projects WHERE %s = $1 AND organization_id = $2", projectColumns, filter)
 
-	err = r.db.SelectContext(ctx, &projects, q, value)
+	err = conn(ctx, r.db).SelectContext(ctx, &projects, q, value, tenant(ctx))
 	if err != nil {
 		return
 	}
@@ -129,6 +133,138 @@ func (r *ProjectRepository) Search(ctx context.Context, arg, value string) (proj
 	return
 }
 
+func (r *ProjectRepository) ListByTeam(ctx context.Context, teamID string) (projects []project.Entity, err error) {
+	projects = []project.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM projects WHERE team_id = $1 AND organization_id = $2 ORDER BY started_at", projectColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &projects, q, teamID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *ProjectRepository) SetTeam(ctx context.Context, id, teamID string) (err error) {
+	q := `
+	UPDATE projects SET team_id = NULLIF($1, '') WHERE id = $2 AND organization_id = $3 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, teamID, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = project.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *ProjectRepository) Signals(ctx context.Context, id string) (s project.Signals, err error) {
+	q := `
+	SELECT COUNT(*) AS total,
+		COUNT(*) FILTER (WHERE status = 'done') AS done,
+		COUNT(*) FILTER (WHERE status <> 'done' AND done_at < CURRENT_DATE) AS overdue,
+		COUNT(*) FILTER (WHERE priority = 'high') AS high,
+		COUNT(*) FILTER (WHERE priority = 'high' AND status = 'active') AS high_active
+	FROM tasks
+	WHERE project_id = $1 AND organization_id = $2
+	`
+
+	err = conn(ctx, r.db).GetContext(ctx, &s, q, id, tenant(ctx))
+
+	return
+}
+
+func (r *ProjectRepository) SetHealth(ctx context.Context, id string, h project.Health) (err error) {
+	q := `
+	UPDATE projects SET health = $1, health_score = $2, health_reasons = $3
+	WHERE id = $4 AND organization_id = $5 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, h.Status, h.Score, pq.StringArray(h.Reasons), id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = project.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *ProjectRepository) SetStatus(ctx context.Context, id, from, to string) (err error) {
+	q := `
+	UPDATE projects SET status = $3
+	WHERE id = $1 AND status = $2 AND organization_id = $4 RETURNING id
+	`
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, q, id, from, to, tenant(ctx)).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = project.ErrTransition
+		}
+	}
+
+	return
+}
+
+func (r *ProjectRepository) Stats(ctx context.Context, id string) (s project.Stats, err error) {
+	s.Counts = []project.Count{}
+
+	q := `
+	SELECT COALESCE(status, '') AS status, COALESCE(priority, '') AS priority, COUNT(*) AS count,
+		COUNT(*) FILTER (WHERE status <> 'done' AND done_at < CURRENT_DATE) AS overdue
+	FROM tasks
+	WHERE project_id = $1 AND organization_id = $2
+	GROUP BY 1, 2
+	`
+
+	if err = conn(ctx, r.db).SelectContext(ctx, &s.Counts, q, id, tenant(ctx)); err != nil {
+		return
+	}
+
+	q = `SELECT finished_at - started_at + 1 FROM projects WHERE id = $1 AND organization_id = $2`
+
+	if err = conn(ctx, r.db).GetContext(ctx, &s.Days, q, id, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = project.ErrNotFound
+		}
+		return
+	}
+
+	// a task counts as completed from the day it last moved to done
+	q = `
+	WITH t AS (
+		SELECT t.created_at,
+			CASE WHEN t.status = 'done' THEN (
+				SELECT MAX(h.changed_at)::date FROM task_status_history h
+				WHERE h.task_id = t.id AND h.to_status = 'done'
+			) END AS completed_at
+		FROM tasks t
+		WHERE t.project_id = $1 AND t.organization_id = $2
+	), days AS (
+		SELECT generate_series(started_at, LEAST(finished_at, CURRENT_DATE), interval '1 day')::date AS day
+		FROM projects
+		WHERE id = $1 AND organization_id = $2
+	)
+	SELECT d.day,
+		COUNT(t.created_at) FILTER (WHERE t.created_at <= d.day) AS scope,
+		COUNT(t.completed_at) FILTER (WHERE t.completed_at <= d.day) AS completed
+	FROM days d
+	LEFT JOIN t ON true
+	GROUP BY d.day
+	ORDER BY d.day
+	`
+
+	s.Burn = []project.BurnPoint{}
+	if err = conn(ctx, r.db).SelectContext(ctx, &s.Burn, q, id, tenant(ctx)); err != nil {
+		return
+	}
+
+	return
+}
+
 func (r *ProjectRepository) prepareArgs(p project.Entity) (sets []string, args []any) {
 	if p.Title != "" {
 		args = append(args, p.Title)
@@ -155,6 +291,16 @@ func (r *ProjectRepository) prepareArgs(p project.Entity) (sets []string, args [
 		sets = append(sets, fmt.Sprintf("finished_at = $%d", len(args)))
 	}
 
+	if p.BudgetCurrency != "" {
+		args = append(args, p.BudgetAmount, p.BudgetCurrency)
+		sets = append(sets, fmt.Sprintf("budget_amount = $%d, budget_currency = $%d", len(args)-1, len(args)))
+	}
+
+	if p.TeamID != "" {
+		args = append(args, p.TeamID)
+		sets = append(sets, fmt.Sprintf("team_id = $%d", len(args)))
+	}
+
 	return
 }
 
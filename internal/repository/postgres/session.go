@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/session"
+	"github.com/jmoiron/sqlx"
+)
+
+const sessionColumns = `
+	id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, organization_id
+`
+
+type SessionRepository struct {
+	db *sqlx.DB
+}
+
+func NewSessionRepository(db *sqlx.DB) *SessionRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &SessionRepository{
+		db: db,
+	}
+}
+
+// Create also drops the expired sessions of the user.
+func (r *SessionRepository) Create(ctx context.Context, s session.Session) (err error) {
+	q := `
+	DELETE FROM sessions WHERE user_id = $1 AND expires_at < $2 AND organization_id = $3
+	`
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, q, s.UserID, s.CreatedAt, tenant(ctx)); err != nil {
+		return
+	}
+
+	q = `
+		INSERT INTO sessions (id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, q, s.ID, s.UserID, s.UserAgent, s.IP, s.CreatedAt, s.LastSeenAt, s.ExpiresAt, tenant(ctx))
+
+	return
+}
+
+func (r *SessionRepository) Get(ctx context.Context, id string) (s session.Session, err error) {
+	q := fmt.Sprintf("SELECT %s FROM sessions WHERE id = $1 AND organization_id = $2", sessionColumns)
+
+	if err = conn(ctx, r.db).GetContext(ctx, &s, q, id, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = session.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *SessionRepository) List(ctx context.Context, userID string) (sessions []session.Session, err error) {
+	sessions = []session.Session{}
+
+	q := fmt.Sprintf("SELECT %s FROM sessions WHERE user_id = $1 AND expires_at > $2 AND organization_id = $3 ORDER BY last_seen_at DESC", sessionColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &sessions, q, userID, time.Now().UTC(), tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *SessionRepository) Update(ctx context.Context, s session.Session) (err error) {
+	q := `
+	UPDATE sessions SET ip = $1, last_seen_at = $2, expires_at = $3 WHERE id = $4 AND organization_id = $5 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, s.IP, s.LastSeenAt, s.ExpiresAt, s.ID, tenant(ctx)).Scan(&s.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = session.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, userID, id string) (err error) {
+	q := `
+	DELETE FROM sessions WHERE id = $1 AND user_id = $2 AND organization_id = $3 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, userID, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = session.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *SessionRepository) DeleteByUser(ctx context.Context, userID string) (err error) {
+	q := `
+	DELETE FROM sessions WHERE user_id = $1 AND organization_id = $2
+	`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, q, userID, tenant(ctx))
+
+	return
+}
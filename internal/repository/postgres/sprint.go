@@ -0,0 +1,283 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/sprint"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const sprintColumns = `
+	s.id, s.project_id, s.name, s.goal, s.start_date, s.end_date, s.state, s.started_at, s.completed_at
+`
+
+type SprintRepository struct {
+	db *sqlx.DB
+}
+
+func NewSprintRepository(db *sqlx.DB) *SprintRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &SprintRepository{
+		db: db,
+	}
+}
+
+func (r *SprintRepository) Create(ctx context.Context, s sprint.Entity) (string, sprint.Entity, error) {
+	q := `
+		INSERT INTO sprints (id, project_id, name, goal, start_date, end_date, state, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id
+	`
+
+	args := []any{s.ID, s.ProjectID, s.Name, s.Goal, s.StartDate, s.EndDate, s.State, tenant(ctx)}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return "", sprint.Entity{}, err
+	}
+
+	return "sprint has been created", s, nil
+}
+
+func (r *SprintRepository) Update(ctx context.Context, id string, s sprint.Entity) (err error) {
+	sets, args := r.prepareArgs(s)
+	if len(sets) > 0 {
+		args = append(args, id, tenant(ctx))
+		q := fmt.Sprintf("UPDATE sprints SET %s WHERE id = $%d AND organization_id = $%d RETURNING id", strings.Join(sets, ", "), len(args)-1, len(args))
+
+		err = conn(ctx, r.db).QueryRowContext(ctx, q, args...).Scan(&id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = sprint.ErrNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *SprintRepository) Get(ctx context.Context, id string) (s sprint.Entity, err error) {
+	s = sprint.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM sprints s WHERE s.id = $1 AND s.organization_id = $2", sprintColumns)
+
+	if err = conn(ctx, r.db).GetContext(ctx, &s, q, id, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = sprint.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *SprintRepository) Delete(ctx context.Context, id string) (err error) {
+	q := `
+	DELETE FROM sprints WHERE id = $1 AND organization_id = $2 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = sprint.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *SprintRepository) ListByProject(ctx context.Context, projectID string) (sprints []sprint.Entity, err error) {
+	sprints = []sprint.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM sprints s WHERE s.project_id = $1 AND s.organization_id = $2 ORDER BY s.start_date", sprintColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &sprints, q, projectID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *SprintRepository) Items(ctx context.Context, id string) (items []sprint.Item, err error) {
+	items = []sprint.Item{}
+
+	q := `
+	SELECT st.sprint_id, st.task_id, st.committed, st.completed, t.status
+	FROM sprint_tasks st
+	JOIN tasks t ON t.id = st.task_id
+	WHERE st.sprint_id = $1 AND st.organization_id = $2
+	`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &items, q, id, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *SprintRepository) AddTask(ctx context.Context, id, taskID string, committed bool) error {
+	q := `
+		INSERT INTO sprint_tasks (sprint_id, task_id, committed, organization_id)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, id, taskID, committed, tenant(ctx))
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return sprint.ErrTaskInSprint
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *SprintRepository) RemoveTask(ctx context.Context, id, taskID string) (err error) {
+	q := `
+	DELETE FROM sprint_tasks WHERE sprint_id = $1 AND task_id = $2 AND organization_id = $3 RETURNING task_id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, taskID, tenant(ctx)).Scan(&taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = sprint.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *SprintRepository) OpenSprintOf(ctx context.Context, taskID string) (s sprint.Entity, err error) {
+	// a transaction scheduling the task holds it until it commits, so that
+	// two of them cannot both find it unscheduled
+	l := `SELECT id FROM tasks WHERE id = $1 AND organization_id = $2 FOR UPDATE`
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, l, taskID, tenant(ctx)); err != nil {
+		return
+	}
+
+	q := `SELECT ` + sprintColumns + `
+	FROM sprints s
+	JOIN sprint_tasks st ON st.sprint_id = s.id
+	WHERE st.task_id = $1 AND s.state <> 'completed' AND s.organization_id = $2
+	LIMIT 1
+	`
+
+	if err = conn(ctx, r.db).GetContext(ctx, &s, q, taskID, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = sprint.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *SprintRepository) Start(ctx context.Context, id string) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	q := `
+	UPDATE sprints SET state = 'active', started_at = now()
+	WHERE id = $1 AND state = 'planned' AND organization_id = $2 RETURNING id
+	`
+
+	if err = tx.QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = sprint.ErrState
+		}
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return sprint.ErrActiveExists
+		}
+		return
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE sprint_tasks SET committed = TRUE WHERE sprint_id = $1 AND organization_id = $2", id, tenant(ctx)); err != nil {
+		return
+	}
+
+	return tx.Commit()
+}
+
+func (r *SprintRepository) Complete(ctx context.Context, id, nextID string) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	q := `
+	UPDATE sprints SET state = 'completed', completed_at = now()
+	WHERE id = $1 AND state = 'active' AND organization_id = $2 RETURNING id
+	`
+
+	if err = tx.QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = sprint.ErrState
+		}
+		return
+	}
+
+	snapshot := `
+	UPDATE sprint_tasks st SET completed = (t.status = 'done')
+	FROM tasks t
+	WHERE t.id = st.task_id AND st.sprint_id = $1 AND st.organization_id = $2
+	`
+
+	if _, err = tx.ExecContext(ctx, snapshot, id, tenant(ctx)); err != nil {
+		return
+	}
+
+	if nextID != "" {
+		rollover := `
+		INSERT INTO sprint_tasks (sprint_id, task_id, committed, organization_id)
+		SELECT n.id, st.task_id, n.state = 'active', n.organization_id
+		FROM sprint_tasks st, sprints n
+		WHERE st.sprint_id = $1 AND NOT st.completed AND n.id = $2
+			AND st.organization_id = $3 AND n.organization_id = $3
+		ON CONFLICT DO NOTHING
+		`
+
+		if _, err = tx.ExecContext(ctx, rollover, id, nextID, tenant(ctx)); err != nil {
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *SprintRepository) prepareArgs(data sprint.Entity) (sets []string, args []any) {
+	if data.Name != "" {
+		args = append(args, data.Name)
+		sets = append(sets, fmt.Sprintf("name=$%d", len(args)))
+	}
+
+	if data.Goal != "" {
+		args = append(args, data.Goal)
+		sets = append(sets, fmt.Sprintf("goal=$%d", len(args)))
+	}
+
+	if data.StartDate != "" {
+		args = append(args, data.StartDate)
+		sets = append(sets, fmt.Sprintf("start_date=$%d", len(args)))
+	}
+
+	if data.EndDate != "" {
+		args = append(args, data.EndDate)
+		sets = append(sets, fmt.Sprintf("end_date=$%d", len(args)))
+	}
+
+	return
+}
@@ -8,11 +8,18 @@ import (
 
 	"database/sql"
 
+	"github.com/canyouhearthemusic/project-management/internal/domain"
 	"github.com/canyouhearthemusic/project-management/internal/domain/task"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 )
 
+const taskColumns = `
+	id, title, description, priority, status, COALESCE(author_id, '') AS author_id,
+	project_id, created_at, done_at, COALESCE(milestone_id, '') AS milestone_id,
+	COALESCE(epic_id, '') AS epic_id, start_at, predecessor_ids, COALESCE(team_id, '') AS team_id
+`
+
 type TaskRepository struct {
 	db *sqlx.DB
 }
@@ -29,20 +36,36 @@ func NewTaskRepository(db *sqlx.DB) *TaskRepository {
 
 func (r *TaskRepository) Create(ctx context.Context, t task.Entity) (msg string, obj task.Entity, err error) {
 	q := `
-		INSERT INTO tasks (id, title, description, priority, status, author_id, project_id, created_at, done_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id
+		INSERT INTO tasks (id, title, description, priority, status, author_id, project_id, created_at, done_at, milestone_id, epic_id, start_at, predecessor_ids, team_id, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), NULLIF($11, ''), $12, COALESCE($13::varchar[], '{}'), NULLIF($14, ''), $15) RETURNING id
 	`
 
-	args := []any{t.ID, t.Title, t.Description, t.Priority, t.Status, t.AuthorID, t.ProjectID, t.CreatedAt, t.DoneAt}
+	args := []any{t.ID, t.Title, t.Description, t.Priority, t.Status, t.AuthorID, t.ProjectID, t.CreatedAt, t.DoneAt, t.MilestoneID, t.EpicID, t.StartAt, t.PredecessorIDs, t.TeamID, tenant(ctx)}
 
-	_, err = r.db.ExecContext(ctx, q, args...)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			err = task.ErrExists
-		}
-		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
-			return "", task.Entity{}, task.ErrExists
+	actorID := domain.ActorFromContext(ctx)
+	if actorID == "" {
+		err = task.ErrNoActor
+		return
+	}
+
+	h := `
+		INSERT INTO task_status_history (task_id, from_status, to_status, actor_id, organization_id)
+		VALUES ($1, NULL, $2, $3, $4)
+	`
+
+	err = transaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+				return task.ErrExists
+			}
+			return err
 		}
+
+		_, err := tx.ExecContext(ctx, h, t.ID, t.Status, actorID, tenant(ctx))
+
+		return err
+	})
+	if err != nil {
 		return
 	}
 
@@ -51,29 +74,85 @@ func (r *TaskRepository) Create(ctx context.Context, t task.Entity) (msg string,
 
 func (r *TaskRepository) Update(ctx context.Context, id string, t task.Entity) (err error) {
 	sets, args := r.prepareArgs(t)
-	if len(sets) > 0 {
-		args = append(args, id)
-		q := fmt.Sprintf("UPDATE tasks SET %s WHERE id = $%d RETURNING ID", strings.Join(sets, ", "), len(args))
+	if len(sets) == 0 {
+		return
+	}
 
-		err = r.db.QueryRowContext(ctx, q, args...).Scan(&id)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				err = task.ErrNotFound
+	args = append(args, id, tenant(ctx))
+	q := fmt.Sprintf("UPDATE tasks SET %s WHERE id = $%d AND organization_id = $%d RETURNING ID", strings.Join(sets, ", "), len(args)-1, len(args))
+
+	return transaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		if t.Status != "" {
+			if err := r.recordStatus(ctx, tx, id, t.Status); err != nil {
+				return err
 			}
 		}
+
+		if t.ProjectID != "" {
+			if err := r.changeProject(ctx, tx, id, t.ProjectID); err != nil {
+				return err
+			}
+		}
+
+		err := tx.QueryRowContext(ctx, q, args...).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return task.ErrNotFound
+		}
+
+		return err
+	})
+}
+
+// changeProject detaches the task from what belongs to its current project
+// when it moves to another one: milestones and sprints are per project. It
+// must run before the task row itself is updated, so that a milestone of
+// the new project set by the same update is kept.
+func (r *TaskRepository) changeProject(ctx context.Context, tx *sqlx.Tx, id, projectID string) error {
+	s := `
+		DELETE FROM sprint_tasks st USING tasks t
+		WHERE st.task_id = t.id AND t.id = $1 AND t.organization_id = $3 AND t.project_id <> $2
+	`
+
+	if _, err := tx.ExecContext(ctx, s, id, projectID, tenant(ctx)); err != nil {
+		return err
 	}
 
-	return
+	m := `
+		UPDATE tasks SET milestone_id = NULL
+		WHERE id = $1 AND organization_id = $3 AND project_id <> $2
+	`
+
+	_, err := tx.ExecContext(ctx, m, id, projectID, tenant(ctx))
+
+	return err
 }
 
-func (r *TaskRepository) Get(ctx context.Context, id string) (t task.Entity, err error) {
-	t = task.Entity{}
+// recordStatus appends a history entry when status differs from the task's
+// current one. It must run before the task row itself is updated and refuses
+// to record a change without the user making it.
+func (r *TaskRepository) recordStatus(ctx context.Context, tx *sqlx.Tx, id, status string) error {
+	actorID := domain.ActorFromContext(ctx)
+	if actorID == "" {
+		return task.ErrNoActor
+	}
 
 	q := `
-	SELECT * FROM tasks WHERE id = $1
+		INSERT INTO task_status_history (task_id, from_status, to_status, actor_id, organization_id)
+		SELECT id, status, $2, $3, organization_id FROM tasks
+		WHERE id = $1 AND organization_id = $4 AND status IS DISTINCT FROM $2
 	`
 
-	if err = r.db.GetContext(ctx, &t, q, id); err != nil {
+	_, err := tx.ExecContext(ctx, q, id, status, actorID, tenant(ctx))
+
+	return err
+}
+
+func (r *TaskRepository) Get(ctx context.Context, id string) (t task.Entity, err error) {
+	t = task.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM tasks WHERE id = $1 AND organization_id = $2", taskColumns)
+
+	if err = conn(ctx, r.db).GetContext(ctx, &t, q, id, tenant(ctx)); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = task.ErrNotFound
 			return
@@ -85,10 +164,10 @@ func (r *TaskRepository) Get(ctx context.Context, id string) (t task.Entity, err
 
 func (r *TaskRepository) Delete(ctx context.Context, id string) (err error) {
 	q := `
-	DELETE FROM tasks WHERE id = $1 RETURNING id
+	DELETE FROM tasks WHERE id = $1 AND organization_id = $2 RETURNING id
 	`
 
-	if err = r.db.QueryRowContext(ctx, q, id).Scan(&id); err != nil {
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = task.ErrNotFound
 			return
@@ -99,8 +178,8 @@ func (r *TaskRepository) Delete(ctx context.Context, id string) (err error) {
 }
 
 func (r *TaskRepository) List(ctx context.Context) (tasks []task.Entity, err error) {
-	q := "SELECT * FROM tasks"
-	err = r.db.SelectContext(ctx, &tasks, q)
+	q := fmt.Sprintf("SELECT %s FROM tasks WHERE organization_id = $1", taskColumns)
+	err = conn(ctx, r.db).SelectContext(ctx, &tasks, q, tenant(ctx))
 	if err != nil {
 		return
 	}
@@ -113,9 +192,9 @@ func (r *TaskRepository) Search(ctx context.Context, filter, value string) (task
 
 	filter = r.prepareFilterArg(filter)
 
-	q := fmt.Sprintf("SELECT * FROM users WHERE %s = $1", filter)
+	q := fmt.Sprintf("SELECT %s FROM tasks WHERE %s = $1 AND organization_id = $2", taskColumns, filter)
 
-	err = r.db.SelectContext(ctx, &tasks, q, value)
+	err = conn(ctx, r.db).SelectContext(ctx, &tasks, q, value, tenant(ctx))
 	if err != nil {
 		return
 	}
@@ -128,6 +207,308 @@ func (r *TaskRepository) Search(ctx context.Context, filter, value string) (task
 	return
 }
 
+func (r *TaskRepository) ListByProject(ctx context.Context, projectID string) (tasks []task.Entity, err error) {
+	tasks = []task.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM tasks WHERE project_id = $1 AND organization_id = $2", taskColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &tasks, q, projectID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *TaskRepository) ListByMilestone(ctx context.Context, milestoneID string) (tasks []task.Entity, err error) {
+	tasks = []task.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM tasks WHERE milestone_id = $1 AND organization_id = $2 ORDER BY created_at", taskColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &tasks, q, milestoneID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *TaskRepository) SetMilestone(ctx context.Context, id, milestoneID string) (err error) {
+	q := `
+	UPDATE tasks SET milestone_id = NULLIF($1, '') WHERE id = $2 AND organization_id = $3 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, milestoneID, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = task.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *TaskRepository) ListByEpic(ctx context.Context, epicID string) (tasks []task.Entity, err error) {
+	tasks = []task.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM tasks WHERE epic_id = $1 AND organization_id = $2 ORDER BY project_id, created_at", taskColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &tasks, q, epicID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *TaskRepository) SetEpic(ctx context.Context, ids []string, epicID string) (err error) {
+	q := `
+	UPDATE tasks SET epic_id = NULLIF($1, '') WHERE id = ANY($2) AND organization_id = $3
+	`
+
+	distinct := map[string]bool{}
+	for _, id := range ids {
+		distinct[id] = true
+	}
+
+	return transaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx, q, epicID, pq.Array(ids), tenant(ctx))
+		if err != nil {
+			return err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if int(n) != len(distinct) {
+			return task.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r *TaskRepository) ListByTeam(ctx context.Context, teamID string) (tasks []task.Entity, err error) {
+	tasks = []task.Entity{}
+
+	q := fmt.Sprintf(`
+	SELECT %s FROM tasks
+	WHERE organization_id = $2 AND (team_id = $1 OR team_id IS NULL AND project_id IN (
+		SELECT id FROM projects WHERE team_id = $1 AND organization_id = $2
+	))
+	ORDER BY project_id, created_at
+	`, taskColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &tasks, q, teamID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *TaskRepository) SetTeam(ctx context.Context, id, teamID string) (err error) {
+	q := `
+	UPDATE tasks SET team_id = NULLIF($1, '') WHERE id = $2 AND organization_id = $3 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, teamID, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = task.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *TaskRepository) ListDue(ctx context.Context, until string) (tasks []task.Entity, err error) {
+	tasks = []task.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM tasks WHERE status <> 'done' AND done_at <= $1 AND organization_id = $2 ORDER BY done_at", taskColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &tasks, q, until, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *TaskRepository) CountByStatus(ctx context.Context, projectID, status string) (count int, err error) {
+	q := "SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND status = $2 AND organization_id = $3"
+
+	err = conn(ctx, r.db).GetContext(ctx, &count, q, projectID, status, tenant(ctx))
+
+	return
+}
+
+func (r *TaskRepository) History(ctx context.Context, id string) (history []task.StatusChange, err error) {
+	history = []task.StatusChange{}
+
+	q := `
+	SELECT id, task_id, COALESCE(from_status, '') AS from_status, to_status,
+		COALESCE(actor_id, '') AS actor_id, changed_at
+	FROM task_status_history WHERE task_id = $1 AND organization_id = $2
+	ORDER BY changed_at, id
+	`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &history, q, id, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *TaskRepository) ProjectHistory(ctx context.Context, projectID string) (history []task.StatusChange, err error) {
+	history = []task.StatusChange{}
+
+	q := `
+	SELECT h.id, h.task_id, COALESCE(h.from_status, '') AS from_status, h.to_status,
+		COALESCE(h.actor_id, '') AS actor_id, h.changed_at
+	FROM task_status_history h
+	JOIN tasks t ON t.id = h.task_id
+	WHERE t.project_id = $1 AND t.organization_id = $2
+	ORDER BY h.task_id, h.changed_at, h.id
+	`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &history, q, projectID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *TaskRepository) UpdateMany(ctx context.Context, sel task.Selection, t task.Entity, check func([]task.Entity) error) ([]task.BulkResult, error) {
+	sets, args := r.prepareArgs(t)
+	if len(sets) == 0 {
+		return nil, task.ErrBadRequest
+	}
+
+	args = append(args, tenant(ctx))
+	q := fmt.Sprintf("UPDATE tasks SET %s WHERE organization_id = $%d AND id = $%d RETURNING id", strings.Join(sets, ", "), len(args), len(args)+1)
+
+	return r.bulk(ctx, sel, check, func(tx *sqlx.Tx, id string) error {
+		if t.Status != "" {
+			if err := r.recordStatus(ctx, tx, id, t.Status); err != nil {
+				return err
+			}
+		}
+
+		if t.ProjectID != "" {
+			if err := r.changeProject(ctx, tx, id, t.ProjectID); err != nil {
+				return err
+			}
+		}
+
+		return tx.QueryRowContext(ctx, q, append(args, id)...).Scan(&id)
+	})
+}
+
+func (r *TaskRepository) DeleteMany(ctx context.Context, sel task.Selection, check func([]task.Entity) error) ([]task.BulkResult, error) {
+	q := `
+	DELETE FROM tasks WHERE id = $1 AND organization_id = $2 RETURNING id
+	`
+
+	return r.bulk(ctx, sel, check, func(tx *sqlx.Tx, id string) error {
+		return tx.QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id)
+	})
+}
+
+// bulk selects and locks the tasks of sel, passes them to check and runs fn
+// for every one inside one transaction, so that the batch is the one checked.
+// A missing task is reported per item; any failure rolls back the whole batch
+// and marks the items that had already been applied as rolled back.
+func (r *TaskRepository) bulk(ctx context.Context, sel task.Selection, check func([]task.Entity) error, fn func(tx *sqlx.Tx, id string) error) (results []task.BulkResult, err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	tasks := []task.Entity{}
+	ids := sel.IDs
+
+	if sel.Field != "" {
+		q := fmt.Sprintf("SELECT %s FROM tasks WHERE %s = $1 AND organization_id = $2 ORDER BY created_at, id LIMIT %d FOR UPDATE", taskColumns, r.prepareFilterArg(sel.Field), task.MaxBulkItems+1)
+
+		if err = tx.SelectContext(ctx, &tasks, q, sel.Value, tenant(ctx)); err != nil {
+			return
+		}
+
+		if len(tasks) == 0 {
+			err = task.ErrNotFound
+			return
+		}
+		if len(tasks) > task.MaxBulkItems {
+			err = task.ErrTooMany
+			return
+		}
+
+		ids = make([]string, len(tasks))
+		for i, t := range tasks {
+			ids[i] = t.ID
+		}
+	} else {
+		q := fmt.Sprintf("SELECT %s FROM tasks WHERE id = ANY($1) AND organization_id = $2 FOR UPDATE", taskColumns)
+
+		if err = tx.SelectContext(ctx, &tasks, q, pq.Array(ids), tenant(ctx)); err != nil {
+			return
+		}
+	}
+
+	if err = check(tasks); err != nil {
+		return
+	}
+
+	failed := false
+	for _, id := range ids {
+		if failed {
+			results = append(results, task.BulkResult{ID: id, Err: task.ErrRolledBack})
+			continue
+		}
+
+		if err := fn(tx, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = task.ErrNotFound
+			}
+			results = append(results, task.BulkResult{ID: id, Err: err})
+
+			// postgres refuses further statements once one has errored,
+			// a missing row does not abort the transaction.
+			if !errors.Is(err, task.ErrNotFound) {
+				failed = true
+			}
+			continue
+		}
+
+		results = append(results, task.BulkResult{ID: id})
+	}
+
+	for _, res := range results {
+		if res.Err != nil {
+			failed = true
+		}
+	}
+
+	if failed {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = task.ErrRolledBack
+			}
+		}
+		return results, task.ErrBulkFailed
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
 func (r *TaskRepository) prepareArgs(data task.Entity) (sets []string, args []any) {
 	if data.Title != "" {
 		args = append(args, data.Title)
@@ -164,6 +545,31 @@ func (r *TaskRepository) prepareArgs(data task.Entity) (sets []string, args []an
 		sets = append(sets, fmt.Sprintf("done_at=$%d", len(args)))
 	}
 
+	if data.MilestoneID != "" {
+		args = append(args, data.MilestoneID)
+		sets = append(sets, fmt.Sprintf("milestone_id=$%d", len(args)))
+	}
+
+	if data.EpicID != "" {
+		args = append(args, data.EpicID)
+		sets = append(sets, fmt.Sprintf("epic_id=$%d", len(args)))
+	}
+
+	if data.TeamID != "" {
+		args = append(args, data.TeamID)
+		sets = append(sets, fmt.Sprintf("team_id=$%d", len(args)))
+	}
+
+	if data.StartAt != "" {
+		args = append(args, data.StartAt)
+		sets = append(sets, fmt.Sprintf("start_at=$%d", len(args)))
+	}
+
+	if data.PredecessorIDs != nil {
+		args = append(args, data.PredecessorIDs)
+		sets = append(sets, fmt.Sprintf("predecessor_ids=$%d", len(args)))
+	}
+
 	return
 }
 
@@ -177,7 +583,7 @@ func (r *TaskRepository) prepareFilterArg(arg string) string {
 		return "priority"
 	case "status":
 		return "status"
-	case "assignee":
+	case "assignee", "author_id":
 		return "author_id"
 	case "project_id":
 		return "project_id"
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/team"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const teamColumns = `
+	id, name, COALESCE(lead_id, '') AS lead_id, created_at
+`
+
+const teamMemberColumns = `
+	m.team_id, m.user_id, m.added_at, u.name, u.email
+`
+
+type TeamRepository struct {
+	db *sqlx.DB
+}
+
+func NewTeamRepository(db *sqlx.DB) *TeamRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &TeamRepository{
+		db: db,
+	}
+}
+
+func (r *TeamRepository) Create(ctx context.Context, t team.Entity, memberIDs []string) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	q := `
+		INSERT INTO teams (id, name, lead_id, created_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	if _, err = tx.ExecContext(ctx, q, t.ID, t.Name, t.LeadID, t.CreatedAt, tenant(ctx)); err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return team.ErrExists
+		}
+		return
+	}
+
+	for _, userID := range append([]string{t.LeadID}, memberIDs...) {
+		if err = r.addMember(ctx, tx, t.ID, userID); err != nil {
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *TeamRepository) Get(ctx context.Context, id string) (t team.Entity, err error) {
+	q := fmt.Sprintf("SELECT %s FROM teams WHERE id = $1 AND organization_id = $2", teamColumns)
+
+	if err = conn(ctx, r.db).GetContext(ctx, &t, q, id, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = team.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *TeamRepository) List(ctx context.Context) (teams []team.Entity, err error) {
+	teams = []team.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM teams WHERE organization_id = $1 ORDER BY name", teamColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &teams, q, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *TeamRepository) Update(ctx context.Context, id string, t team.Entity) (err error) {
+	sets, args := r.prepareArgs(t)
+	if len(sets) == 0 {
+		return
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	args = append(args, id, tenant(ctx))
+	q := fmt.Sprintf("UPDATE teams SET %s WHERE id = $%d AND organization_id = $%d RETURNING id", strings.Join(sets, ", "), len(args)-1, len(args))
+
+	if err = tx.QueryRowContext(ctx, q, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = team.ErrNotFound
+		}
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return team.ErrExists
+		}
+		return
+	}
+
+	if t.LeadID != "" {
+		if err = r.addMember(ctx, tx, id, t.LeadID); err != nil {
+			return
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, id string) (err error) {
+	q := `
+	DELETE FROM teams WHERE id = $1 AND organization_id = $2 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = team.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *TeamRepository) ListMembers(ctx context.Context, id string) (members []team.Member, err error) {
+	members = []team.Member{}
+
+	q := `SELECT ` + teamMemberColumns + `
+	FROM team_members m JOIN users u ON u.id = m.user_id
+	WHERE m.team_id = $1 AND m.organization_id = $2
+	ORDER BY u.name
+	`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &members, q, id, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *TeamRepository) AddMember(ctx context.Context, id, userID string) (err error) {
+	q := `INSERT INTO team_members (team_id, user_id, organization_id) VALUES ($1, $2, $3)`
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, q, id, userID, tenant(ctx)); err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return team.ErrMemberExists
+		}
+	}
+
+	return
+}
+
+func (r *TeamRepository) RemoveMember(ctx context.Context, id, userID string) (err error) {
+	q := `DELETE FROM team_members WHERE team_id = $1 AND user_id = $2 AND organization_id = $3 RETURNING user_id`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, userID, tenant(ctx)).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = team.ErrMemberNotFound
+			return
+		}
+	}
+
+	return
+}
+
+// addMember keeps existing memberships as they are.
+func (r *TeamRepository) addMember(ctx context.Context, tx *sqlx.Tx, id, userID string) error {
+	q := `
+	INSERT INTO team_members (team_id, user_id, organization_id) VALUES ($1, $2, $3)
+	ON CONFLICT (team_id, user_id) DO NOTHING
+	`
+
+	_, err := tx.ExecContext(ctx, q, id, userID, tenant(ctx))
+
+	return err
+}
+
+func (r *TeamRepository) prepareArgs(data team.Entity) (sets []string, args []any) {
+	if data.Name != "" {
+		args = append(args, data.Name)
+		sets = append(sets, fmt.Sprintf("name=$%d", len(args)))
+	}
+
+	if data.LeadID != "" {
+		args = append(args, data.LeadID)
+		sets = append(sets, fmt.Sprintf("lead_id=$%d", len(args)))
+	}
+
+	return
+}
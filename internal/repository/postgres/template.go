@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/template"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const templateColumns = `
+	id, title, description, priority, status,
+	COALESCE(author_id, '') AS author_id, COALESCE(project_id, '') AS project_id
+`
+
+type TemplateRepository struct {
+	db *sqlx.DB
+}
+
+func NewTemplateRepository(db *sqlx.DB) *TemplateRepository {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &TemplateRepository{
+		db: db,
+	}
+}
+
+func (r *TemplateRepository) Create(ctx context.Context, t template.Entity) (string, template.Entity, error) {
+	q := `
+		INSERT INTO task_templates (id, title, description, priority, status, author_id, project_id, organization_id)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), $8) RETURNING id
+	`
+
+	args := []any{t.ID, t.Title, t.Description, t.Priority, t.Status, t.AuthorID, t.ProjectID, tenant(ctx)}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return "", template.Entity{}, template.ErrExists
+		}
+		return "", template.Entity{}, err
+	}
+
+	return "template has been created", t, nil
+}
+
+func (r *TemplateRepository) Update(ctx context.Context, id string, t template.Entity) (err error) {
+	sets, args := r.prepareArgs(t)
+	if len(sets) > 0 {
+		args = append(args, id, tenant(ctx))
+		q := fmt.Sprintf("UPDATE task_templates SET %s WHERE id = $%d AND organization_id = $%d RETURNING id", strings.Join(sets, ", "), len(args)-1, len(args))
+
+		err = conn(ctx, r.db).QueryRowContext(ctx, q, args...).Scan(&id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = template.ErrNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *TemplateRepository) Get(ctx context.Context, id string) (t template.Entity, err error) {
+	t = template.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM task_templates WHERE id = $1 AND organization_id = $2", templateColumns)
+
+	if err = conn(ctx, r.db).GetContext(ctx, &t, q, id, tenant(ctx)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = template.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *TemplateRepository) Delete(ctx context.Context, id string) (err error) {
+	q := `
+	DELETE FROM task_templates WHERE id = $1 AND organization_id = $2 RETURNING id
+	`
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = template.ErrNotFound
+			return
+		}
+	}
+
+	return
+}
+
+func (r *TemplateRepository) List(ctx context.Context) (templates []template.Entity, err error) {
+	templates = []template.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM task_templates WHERE organization_id = $1", templateColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &templates, q, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// ListByProject returns the templates of the project together with the global ones.
+func (r *TemplateRepository) ListByProject(ctx context.Context, projectID string) (templates []template.Entity, err error) {
+	templates = []template.Entity{}
+
+	q := fmt.Sprintf("SELECT %s FROM task_templates WHERE (project_id = $1 OR project_id IS NULL) AND organization_id = $2", templateColumns)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &templates, q, projectID, tenant(ctx))
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (r *TemplateRepository) prepareArgs(data template.Entity) (sets []string, args []any) {
+	if data.Title != "" {
+		args = append(args, data.Title)
+		sets = append(sets, fmt.Sprintf("title=$%d", len(args)))
+	}
+
+	if data.Description != "" {
+		args = append(args, data.Description)
+		sets = append(sets, fmt.Sprintf("description=$%d", len(args)))
+	}
+
+	if data.Priority != "" {
+		args = append(args, data.Priority)
+		sets = append(sets, fmt.Sprintf("priority=$%d", len(args)))
+	}
+
+	if data.Status != "" {
+		args = append(args, data.Status)
+		sets = append(sets, fmt.Sprintf("status=$%d", len(args)))
+	}
+
+	if data.AuthorID != "" {
+		args = append(args, data.AuthorID)
+		sets = append(sets, fmt.Sprintf("author_id=$%d", len(args)))
+	}
+
+	return
+}
@@ -0,0 +1,502 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/accesstoken"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/board"
+	"github.com/canyouhearthemusic/project-management/internal/domain/budget"
+	"github.com/canyouhearthemusic/project-management/internal/domain/calendar"
+	"github.com/canyouhearthemusic/project-management/internal/domain/epic"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/milestone"
+	"github.com/canyouhearthemusic/project-management/internal/domain/notification"
+	"github.com/canyouhearthemusic/project-management/internal/domain/organization"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/projecttemplate"
+	"github.com/canyouhearthemusic/project-management/internal/domain/session"
+	"github.com/canyouhearthemusic/project-management/internal/domain/sprint"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/team"
+	"github.com/canyouhearthemusic/project-management/internal/domain/template"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// errHidden is returned by the reads of the cases below when the row of the
+// organization it was created in does not come back.
+var errHidden = errors.New("row not visible")
+
+// isolationCase reads and writes the row a tenant-scoped table holds for the
+// organization the fixture was created in.
+type isolationCase struct {
+	table string
+	// read fails unless the row is visible and unchanged.
+	read func(ctx context.Context) error
+	// write tries to change or delete the row; nil for tables whose rows
+	// are only ever added.
+	write func(ctx context.Context) error
+}
+
+// TestTenantIsolation creates a row in every tenant-scoped table for one
+// organization and checks another organization can neither read nor change
+// it. It runs against the database of TEST_DATABASE_URL, which it migrates.
+func TestTenantIsolation(t *testing.T) {
+	db := testDB(t)
+
+	orgs := NewOrganizationRepository(db)
+	a, b := testOrganization(t, orgs), testOrganization(t, orgs)
+
+	ctx := context.Background()
+	ctxA := domain.WithTenant(ctx, a.ID)
+	ctxB := domain.WithTenant(ctx, b.ID)
+
+	cases := isolationCases(t, db, ctxA)
+
+	tables := []string{}
+	if err := db.SelectContext(ctx, &tables, `
+		SELECT table_name FROM information_schema.columns
+		WHERE column_name = 'organization_id' AND table_schema = current_schema()
+	`); err != nil {
+		t.Fatal(err)
+	}
+
+	covered := map[string]bool{}
+	for _, c := range cases {
+		covered[c.table] = true
+	}
+	for _, table := range tables {
+		if !covered[table] {
+			t.Errorf("%s is tenant scoped but not covered", table)
+		}
+	}
+
+	for _, c := range cases {
+		t.Run(c.table, func(t *testing.T) {
+			if err := c.read(ctxA); err != nil {
+				t.Fatalf("read in own organization: %v", err)
+			}
+
+			if err := c.read(ctxB); err == nil {
+				t.Error("read from another organization succeeded")
+			}
+
+			if c.write != nil {
+				c.write(ctxB)
+			}
+
+			if err := c.read(ctxA); err != nil {
+				t.Errorf("row changed by another organization: %v", err)
+			}
+		})
+	}
+}
+
+func isolationCases(t *testing.T, db *sqlx.DB, ctx context.Context) []isolationCase {
+	t.Helper()
+
+	users := NewUserRepository(db)
+	projects := NewProjectRepository(db)
+	tasks := NewTaskRepository(db)
+	templates := NewTemplateRepository(db)
+	boards := NewBoardRepository(db)
+	sprints := NewSprintRepository(db)
+	milestones := NewMilestoneRepository(db)
+	epics := NewEpicRepository(db)
+	notifications := NewNotificationRepository(db)
+	members := NewMemberRepository(db)
+	projectTemplates := NewProjectTemplateRepository(db)
+	budgets := NewBudgetRepository(db)
+	teams := NewTeamRepository(db)
+	calendars := NewCalendarRepository(db)
+	auths := NewAuthRepository(db)
+	accessTokens := NewAccessTokenRepository(db)
+	sessions := NewSessionRepository(db)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	today := domain.OnlyDate(now.Format(domain.DateLayout))
+
+	u := user.Entity{ID: uuid.NewString(), Name: "Ann", Email: uuid.NewString() + "@example.com", RegistrationDate: today, Role: user.RoleDeveloper}
+	must(t, second(users.Create(ctx, u)))
+
+	p := project.Entity{ID: uuid.NewString(), Title: "Apollo", StartedAt: today, FinishedAt: today, ManagerID: u.ID, Status: project.StatusActive}
+	must(t, second(projects.Create(ctx, p)))
+
+	tk := task.Entity{ID: uuid.NewString(), Title: "Launch", Priority: "high", Status: task.StatusActive, AuthorID: u.ID, ProjectID: p.ID, CreatedAt: today, DoneAt: today, StartAt: today}
+	must(t, second(tasks.Create(domain.WithActor(ctx, u.ID), tk)))
+
+	tmpl := template.Entity{ID: uuid.NewString(), Title: "Checklist", Priority: "low", Status: task.StatusActive, AuthorID: u.ID, ProjectID: p.ID}
+	must(t, second(templates.Create(ctx, tmpl)))
+
+	must(t, boards.SetLimits(ctx, p.ID, []board.Limit{{ProjectID: p.ID, Status: task.StatusInProgress, WIPLimit: 3}}))
+
+	sp := sprint.Entity{ID: uuid.NewString(), ProjectID: p.ID, Name: "Sprint 1", StartDate: today, EndDate: today, State: sprint.StatePlanned}
+	must(t, second(sprints.Create(ctx, sp)))
+	must(t, sprints.AddTask(ctx, sp.ID, tk.ID, false))
+
+	ms := milestone.Entity{ID: uuid.NewString(), ProjectID: p.ID, Title: "Beta", TargetDate: today, CreatedAt: today}
+	must(t, second(milestones.Create(ctx, ms)))
+
+	ep := epic.Entity{ID: uuid.NewString(), Title: "Payments", OwnerID: u.ID, CreatedAt: today}
+	must(t, second(epics.Create(ctx, ep)))
+
+	n := notification.Entity{ID: uuid.NewString(), UserID: u.ID, TaskID: tk.ID, Kind: notification.KindReminder, Message: "due soon", DedupKey: uuid.NewString(), CreatedAt: now}
+	if _, err := notifications.Create(ctx, n); err != nil {
+		t.Fatal(err)
+	}
+
+	must(t, members.Add(ctx, member.Entity{ProjectID: p.ID, UserID: u.ID, Role: member.RoleOwner}))
+
+	pt := projecttemplate.Entity{ID: uuid.NewString(), Name: "Launch plan", DurationDays: 1, CreatedAt: today, Tasks: []projecttemplate.Task{{Position: 0, Title: "Launch", Priority: "high", Status: task.StatusActive}}}
+	must(t, second(projectTemplates.Create(ctx, pt)))
+
+	must(t, budgets.AddRate(ctx, budget.Rate{ID: uuid.NewString(), UserID: u.ID, HourlyRate: 50, Currency: "USD", EffectiveFrom: today}))
+
+	te := budget.TimeEntry{ID: uuid.NewString(), TaskID: tk.ID, UserID: u.ID, Hours: 2, SpentOn: today}
+	must(t, budgets.AddTimeEntry(ctx, te))
+
+	ex := budget.Expense{ID: uuid.NewString(), TaskID: tk.ID, Amount: 10, Currency: "USD", SpentOn: today}
+	must(t, budgets.AddExpense(ctx, ex))
+
+	tm := team.Entity{ID: uuid.NewString(), Name: uuid.NewString(), LeadID: u.ID, CreatedAt: today}
+	must(t, teams.Create(ctx, tm, nil))
+
+	ct := calendar.Token{ID: uuid.NewString(), UserID: u.ID, Name: "phone", Hash: uuid.NewString(), CreatedAt: now}
+	must(t, calendars.Create(ctx, ct))
+
+	s := session.Session{ID: uuid.NewString(), UserID: u.ID, CreatedAt: now, LastSeenAt: now, ExpiresAt: now.Add(time.Hour)}
+	must(t, sessions.Create(ctx, s))
+
+	rt := auth.RefreshToken{ID: uuid.NewString(), UserID: u.ID, SessionID: s.ID, Hash: uuid.NewString(), ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	must(t, auths.Create(ctx, rt))
+
+	login := auth.Login{StateHash: uuid.NewString(), Verifier: "verifier", Nonce: "nonce", ExpiresAt: now.Add(time.Hour)}
+	must(t, auths.CreateLogin(ctx, login))
+
+	at := accesstoken.Token{ID: uuid.NewString(), UserID: u.ID, Name: "ci", Scopes: []string{"tasks:read"}, Hash: uuid.NewString(), ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	must(t, accessTokens.Create(ctx, at))
+
+	return []isolationCase{
+		{
+			table: "users",
+			read: func(ctx context.Context) error {
+				return expect(users.Get(ctx, u.ID))(func(v user.Entity) bool { return v.Name == u.Name })
+			},
+			write: func(ctx context.Context) error {
+				return errors.Join(users.Update(ctx, u.ID, user.Entity{Name: "Mallory"}), users.Delete(ctx, u.ID))
+			},
+		},
+		{
+			table: "projects",
+			read: func(ctx context.Context) error {
+				return expect(projects.Get(ctx, p.ID))(func(v project.Entity) bool { return v.Title == p.Title })
+			},
+			write: func(ctx context.Context) error {
+				return errors.Join(
+					projects.Update(ctx, p.ID, project.Entity{Title: "Mallory"}),
+					projects.SetStatus(ctx, p.ID, project.StatusActive, project.StatusArchived),
+					projects.Delete(ctx, p.ID),
+				)
+			},
+		},
+		{
+			table: "tasks",
+			read: func(ctx context.Context) error {
+				return expect(tasks.Get(ctx, tk.ID))(func(v task.Entity) bool { return v.Title == tk.Title && v.EpicID == "" })
+			},
+			write: func(ctx context.Context) error {
+				return errors.Join(
+					tasks.Update(ctx, tk.ID, task.Entity{Title: "Mallory"}),
+					tasks.SetEpic(ctx, []string{tk.ID}, ep.ID),
+					tasks.Delete(ctx, tk.ID),
+				)
+			},
+		},
+		{
+			table: "task_templates",
+			read: func(ctx context.Context) error {
+				return expect(templates.Get(ctx, tmpl.ID))(func(v template.Entity) bool { return v.Title == tmpl.Title })
+			},
+			write: func(ctx context.Context) error {
+				return errors.Join(templates.Update(ctx, tmpl.ID, template.Entity{Title: "Mallory"}), templates.Delete(ctx, tmpl.ID))
+			},
+		},
+		{
+			table: "task_status_history",
+			read: func(ctx context.Context) error {
+				return expect(tasks.History(ctx, tk.ID))(func(v []task.StatusChange) bool { return len(v) == 1 })
+			},
+			write: func(ctx context.Context) error {
+				return tasks.Update(domain.WithActor(ctx, u.ID), tk.ID, task.Entity{Status: task.StatusDone})
+			},
+		},
+		{
+			table: "project_wip_limits",
+			read: func(ctx context.Context) error {
+				return expect(boards.Limits(ctx, p.ID))(func(v []board.Limit) bool { return len(v) == 1 })
+			},
+			write: func(ctx context.Context) error {
+				return boards.SetLimits(ctx, p.ID, nil)
+			},
+		},
+		{
+			table: "sprints",
+			read: func(ctx context.Context) error {
+				return expect(sprints.Get(ctx, sp.ID))(func(v sprint.Entity) bool { return v.Name == sp.Name && v.State == sprint.StatePlanned })
+			},
+			write: func(ctx context.Context) error {
+				return errors.Join(
+					sprints.Update(ctx, sp.ID, sprint.Entity{Name: "Mallory"}),
+					sprints.Start(ctx, sp.ID),
+					sprints.Delete(ctx, sp.ID),
+				)
+			},
+		},
+		{
+			table: "sprint_tasks",
+			read: func(ctx context.Context) error {
+				return expect(sprints.Items(ctx, sp.ID))(func(v []sprint.Item) bool { return len(v) == 1 })
+			},
+			write: func(ctx context.Context) error {
+				return sprints.RemoveTask(ctx, sp.ID, tk.ID)
+			},
+		},
+		{
+			table: "milestones",
+			read: func(ctx context.Context) error {
+				return expect(milestones.Get(ctx, ms.ID))(func(v milestone.Entity) bool { return v.Title == ms.Title })
+			},
+			write: func(ctx context.Context) error {
+				return errors.Join(milestones.Update(ctx, ms.ID, milestone.Entity{Title: "Mallory"}), milestones.Delete(ctx, ms.ID))
+			},
+		},
+		{
+			table: "epics",
+			read: func(ctx context.Context) error {
+				return expect(epics.Get(ctx, ep.ID))(func(v epic.Entity) bool { return v.Title == ep.Title })
+			},
+			write: func(ctx context.Context) error {
+				return errors.Join(epics.Update(ctx, ep.ID, epic.Entity{Title: "Mallory"}), epics.Delete(ctx, ep.ID))
+			},
+		},
+		{
+			table: "notifications",
+			read: func(ctx context.Context) error {
+				return expect(notifications.ListByUser(ctx, u.ID, true))(func(v []notification.Entity) bool { return len(v) == 1 })
+			},
+			write: func(ctx context.Context) error {
+				return notifications.MarkRead(ctx, u.ID, n.ID)
+			},
+		},
+		{
+			table: "project_members",
+			read: func(ctx context.Context) error {
+				return expect(members.Get(ctx, p.ID, u.ID))(func(v member.Entity) bool { return v.Role == member.RoleOwner })
+			},
+			write: func(ctx context.Context) error {
+				return errors.Join(members.SetRole(ctx, p.ID, u.ID, member.RoleViewer), members.Remove(ctx, p.ID, u.ID))
+			},
+		},
+		{
+			table: "project_templates",
+			read: func(ctx context.Context) error {
+				return expect(projectTemplates.Get(ctx, pt.ID))(func(v projecttemplate.Entity) bool { return v.Name == pt.Name })
+			},
+			write: func(ctx context.Context) error {
+				return projectTemplates.Delete(ctx, pt.ID)
+			},
+		},
+		{
+			table: "project_template_tasks",
+			read: func(ctx context.Context) error {
+				return expect(projectTemplates.Get(ctx, pt.ID))(func(v projecttemplate.Entity) bool { return len(v.Tasks) == 1 })
+			},
+			write: func(ctx context.Context) error {
+				return projectTemplates.Delete(ctx, pt.ID)
+			},
+		},
+		{
+			table: "user_rates",
+			read: func(ctx context.Context) error {
+				return expect(budgets.ListRates(ctx, u.ID))(func(v []budget.Rate) bool { return len(v) == 1 })
+			},
+		},
+		{
+			table: "time_entries",
+			read: func(ctx context.Context) error {
+				return expect(budgets.GetTimeEntry(ctx, tk.ID, te.ID))(func(v budget.TimeEntry) bool { return v.Hours == te.Hours })
+			},
+			write: func(ctx context.Context) error {
+				return budgets.DeleteTimeEntry(ctx, tk.ID, te.ID)
+			},
+		},
+		{
+			table: "expenses",
+			read: func(ctx context.Context) error {
+				return expect(budgets.ListExpenses(ctx, tk.ID))(func(v []budget.Expense) bool { return len(v) == 1 })
+			},
+			write: func(ctx context.Context) error {
+				return budgets.DeleteExpense(ctx, tk.ID, ex.ID)
+			},
+		},
+		{
+			table: "teams",
+			read: func(ctx context.Context) error {
+				return expect(teams.Get(ctx, tm.ID))(func(v team.Entity) bool { return v.Name == tm.Name })
+			},
+			write: func(ctx context.Context) error {
+				return errors.Join(teams.Update(ctx, tm.ID, team.Entity{Name: "Mallory"}), teams.Delete(ctx, tm.ID))
+			},
+		},
+		{
+			table: "team_members",
+			read: func(ctx context.Context) error {
+				return expect(teams.ListMembers(ctx, tm.ID))(func(v []team.Member) bool { return len(v) == 1 })
+			},
+			write: func(ctx context.Context) error {
+				return teams.RemoveMember(ctx, tm.ID, u.ID)
+			},
+		},
+		{
+			table: "calendar_tokens",
+			read: func(ctx context.Context) error {
+				return expect(calendars.List(ctx, u.ID))(func(v []calendar.Token) bool { return len(v) == 1 })
+			},
+			write: func(ctx context.Context) error {
+				return calendars.Delete(ctx, u.ID, ct.ID)
+			},
+		},
+		{
+			table: "refresh_tokens",
+			// consuming is the only read, the token is put back after it
+			read: func(ctx context.Context) error {
+				v, err := auths.Consume(ctx, rt.Hash)
+				if err != nil {
+					return err
+				}
+				return auths.Create(domain.WithTenant(ctx, v.OrganizationID), rt)
+			},
+		},
+		{
+			table: "oidc_logins",
+			// the provider's callback names no organization, so logins are
+			// read across organizations and carry the one they are for
+			read: func(ctx context.Context) error {
+				v, err := auths.ConsumeLogin(ctx, login.StateHash)
+				if err != nil {
+					return err
+				}
+				if err := auths.CreateLogin(domain.WithTenant(ctx, v.OrganizationID), login); err != nil {
+					return err
+				}
+				if v.OrganizationID != domain.TenantFromContext(ctx) {
+					return errHidden
+				}
+				return nil
+			},
+		},
+		{
+			table: "access_tokens",
+			read: func(ctx context.Context) error {
+				return expect(accessTokens.GetByHash(ctx, at.Hash))(func(v accesstoken.Token) bool { return v.Name == at.Name })
+			},
+			write: func(ctx context.Context) error {
+				return accessTokens.Delete(ctx, u.ID, at.ID)
+			},
+		},
+		{
+			table: "sessions",
+			read: func(ctx context.Context) error {
+				return expect(sessions.Get(ctx, s.ID))(func(v session.Session) bool { return v.IP == s.IP })
+			},
+			write: func(ctx context.Context) error {
+				return errors.Join(
+					sessions.Update(ctx, session.Session{ID: s.ID, IP: "203.0.113.1", LastSeenAt: now, ExpiresAt: now.Add(time.Hour)}),
+					sessions.Delete(ctx, u.ID, s.ID),
+					sessions.DeleteByUser(ctx, u.ID),
+				)
+			},
+		},
+	}
+}
+
+// expect turns the result of a read into errHidden unless ok holds for it.
+func expect[T any](v T, err error) func(ok func(T) bool) error {
+	return func(ok func(T) bool) error {
+		if err != nil {
+			return err
+		}
+		if !ok(v) {
+			return errHidden
+		}
+		return nil
+	}
+}
+
+// testDB connects to TEST_DATABASE_URL and migrates it, skipping the test
+// when it is not set.
+func testDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	mg, err := migrate.New("file://../../../migrations/postgres", url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mg.Up(); err != nil && err != migrate.ErrNoChange {
+		t.Fatal(err)
+	}
+
+	db, err := sqlx.Connect("postgres", url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// testOrganization creates an organization that is removed, with every row
+// of it, when the test ends.
+func testOrganization(t *testing.T, orgs *OrganizationRepository) organization.Entity {
+	t.Helper()
+
+	o := organization.Entity{
+		ID:        uuid.NewString(),
+		Name:      "Test",
+		Slug:      "test-" + uuid.NewString()[:8],
+		CreatedAt: domain.OnlyDate(time.Now().Format(domain.DateLayout)),
+	}
+
+	must(t, orgs.Create(context.Background(), o))
+	t.Cleanup(func() {
+		orgs.db.Exec("DELETE FROM organizations WHERE id = $1", o.ID)
+	})
+
+	return o
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// second drops the message and entity Create returns next to its error.
+func second[M, E any](_ M, _ E, err error) error {
+	return err
+}
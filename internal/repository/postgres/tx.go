@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Transactor runs the statements of several repositories in one transaction.
+type Transactor struct {
+	db *sqlx.DB
+}
+
+func NewTransactor(db *sqlx.DB) *Transactor {
+	if db == nil {
+		panic("db is required")
+	}
+
+	return &Transactor{
+		db: db,
+	}
+}
+
+type txKey struct{}
+
+// Transaction runs fn with a copy of ctx that repositories issue their
+// statements for in one transaction, committed when fn succeeds and rolled
+// back otherwise. Within fn it joins the transaction already running.
+func (t *Transactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, t.db, func(tx *sqlx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// transaction runs fn in the transaction of ctx or, without one, in a new
+// transaction of its own.
+func transaction(ctx context.Context, db *sqlx.DB, fn func(tx *sqlx.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// querier issues statements, on the pool or in a transaction.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn is where statements for ctx go: the transaction of ctx, if any, or
+// the pool.
+func conn(ctx context.Context, db *sqlx.DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+
+	return db
+}
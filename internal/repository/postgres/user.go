@@ -13,6 +13,10 @@ import (
 	"github.com/lib/pq"
 )
 
+const userColumns = `
+	id, name, email, registration_date, role, password_hash
+`
+
 type UserRepository struct {
 	db *sqlx.DB
 }
@@ -29,13 +33,13 @@ func NewUserRepository(db *sqlx.DB) *UserRepository {
 
 func (r *UserRepository) Create(ctx context.Context, u user.Entity) (msg string, obj user.Entity, err error) {
 	q := `
-		INSERT INTO users (id, name, email, registration_date, role)
-		VALUES ($1, $2, $3, $4, $5) RETURNING id
+		INSERT INTO users (id, name, email, registration_date, role, password_hash, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
 	`
 
-	args := []any{u.ID, u.Name, u.Email, u.RegistrationDate, u.Role}
+	args := []any{u.ID, u.Name, u.Email, u.RegistrationDate, u.Role, u.PasswordHash, tenant(ctx)}
 
-	_, err = r.db.ExecContext(ctx, q, args...)
+	_, err = conn(ctx, r.db).ExecContext(ctx, q, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = user.ErrExists
@@ -49,13 +53,40 @@ func (r *UserRepository) Create(ctx context.Context, u user.Entity) (msg string,
 	return "user has been created", u, err
 }
 
+func (r *UserRepository) CreateFirst(ctx context.Context, u user.Entity) error {
+	return transaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		// the organization is held until the user is in, so that two
+		// requests cannot both find it without users
+		l := `SELECT id FROM organizations WHERE id = $1 FOR UPDATE`
+
+		if _, err := tx.ExecContext(ctx, l, tenant(ctx)); err != nil {
+			return err
+		}
+
+		exists := false
+
+		q := `SELECT EXISTS (SELECT 1 FROM users WHERE organization_id = $1)`
+
+		if err := tx.GetContext(ctx, &exists, q, tenant(ctx)); err != nil {
+			return err
+		}
+
+		if exists {
+			return user.ErrHasUsers
+		}
+
+		_, _, err := r.Create(context.WithValue(ctx, txKey{}, tx), u)
+		return err
+	})
+}
+
 func (r *UserRepository) Update(ctx context.Context, id string, u user.Entity) (err error) {
 	sets, args := r.prepareArgs(u)
 	if len(sets) > 0 {
-		args = append(args, id)
-		q := fmt.Sprintf("UPDATE users SET %s WHERE id = $%d RETURNING ID", strings.Join(sets, ", "), len(args))
+		args = append(args, id, tenant(ctx))
+		q := fmt.Sprintf("UPDATE users SET %s WHERE id = $%d AND organization_id = $%d RETURNING ID", strings.Join(sets, ", "), len(args)-1, len(args))
 
-		err = r.db.QueryRowContext(ctx, q, args...).Scan(&id)
+		err = conn(ctx, r.db).QueryRowContext(ctx, q, args...).Scan(&id)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				err = user.ErrNotFound
@@ -69,11 +100,9 @@ func (r *UserRepository) Update(ctx context.Context, id string, u user.Entity) (
 func (r *UserRepository) Get(ctx context.Context, id string) (u user.Entity, err error) {
 	u = user.Entity{}
 
-	q := `
-	SELECT * FROM users WHERE id = $1
-	`
+	q := fmt.Sprintf("SELECT %s FROM users WHERE id = $1 AND organization_id = $2", userColumns)
 
-	if err = r.db.GetContext(ctx, &u, q, id); err != nil {
+	if err = conn(ctx, r.db).GetContext(ctx, &u, q, id, tenant(ctx)); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = user.ErrNotFound
 			return
@@ -85,10 +114,10 @@ func (r *UserRepository) Get(ctx context.Context, id string) (u user.Entity, err
 
 func (r *UserRepository) Delete(ctx context.Context, id string) (err error) {
 	q := `
-	DELETE FROM users WHERE id = $1 RETURNING id
+	DELETE FROM users WHERE id = $1 AND organization_id = $2 RETURNING id
 	`
 
-	if err = r.db.QueryRowContext(ctx, q, id).Scan(&id); err != nil {
+	if err = conn(ctx, r.db).QueryRowContext(ctx, q, id, tenant(ctx)).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = user.ErrNotFound
 			return
@@ -101,9 +130,9 @@ func (r *UserRepository) Delete(ctx context.Context, id string) (err error) {
 func (r *UserRepository) List(ctx context.Context) (users []user.Entity, err error) {
 	users = []user.Entity{}
 
-	q := "SELECT * FROM users"
+	q := fmt.Sprintf("SELECT %s FROM users WHERE organization_id = $1", userColumns)
 
-	err = r.db.SelectContext(ctx, &users, q)
+	err = conn(ctx, r.db).SelectContext(ctx, &users, q, tenant(ctx))
 	if err != nil {
 		return
 	}
@@ -116,9 +145,9 @@ func (r *UserRepository) Search(ctx context.Context, filter, value string) (user
 
 	filter = r.prepareFilterArg(filter)
 
-	q := fmt.Sprintf("SELECT * FROM users WHERE %s = $1", filter)
+	q := fmt.Sprintf("SELECT %s FROM users WHERE %s = $1 AND organization_id = $2", userColumns, filter)
 
-	err = r.db.SelectContext(ctx, &users, q, value)
+	err = conn(ctx, r.db).SelectContext(ctx, &users, q, value, tenant(ctx))
 	if err != nil {
 		return
 	}
@@ -147,6 +176,11 @@ func (r *UserRepository) prepareArgs(data user.Entity) (sets []string, args []an
 		sets = append(sets, fmt.Sprintf("role=$%d", len(args)))
 	}
 
+	if data.PasswordHash != "" {
+		args = append(args, data.PasswordHash)
+		sets = append(sets, fmt.Sprintf("password_hash=$%d", len(args)))
+	}
+
 	return
 }
 
@@ -2,8 +2,24 @@ package repository
 
 import (
 	"github.com/canyouhearthemusic/project-management/config"
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/accesstoken"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/board"
+	"github.com/canyouhearthemusic/project-management/internal/domain/budget"
+	"github.com/canyouhearthemusic/project-management/internal/domain/calendar"
+	"github.com/canyouhearthemusic/project-management/internal/domain/epic"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/milestone"
+	"github.com/canyouhearthemusic/project-management/internal/domain/notification"
+	"github.com/canyouhearthemusic/project-management/internal/domain/organization"
 	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/projecttemplate"
+	"github.com/canyouhearthemusic/project-management/internal/domain/session"
+	"github.com/canyouhearthemusic/project-management/internal/domain/sprint"
 	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/team"
+	"github.com/canyouhearthemusic/project-management/internal/domain/template"
 	"github.com/canyouhearthemusic/project-management/internal/domain/user"
 	"github.com/canyouhearthemusic/project-management/internal/repository/postgres"
 )
@@ -13,9 +29,25 @@ type Configuration func(r *Repository) error
 type Repository struct {
 	postgres postgres.DB
 
-	User    user.Repository
-	Task    task.Repository
-	Project project.Repository
+	User            user.Repository
+	Task            task.Repository
+	Project         project.Repository
+	Template        template.Repository
+	Board           board.Repository
+	Sprint          sprint.Repository
+	Milestone       milestone.Repository
+	Epic            epic.Repository
+	Notification    notification.Repository
+	Member          member.Repository
+	ProjectTemplate projecttemplate.Repository
+	Budget          budget.Repository
+	Organization    organization.Repository
+	Team            team.Repository
+	Calendar        calendar.Repository
+	Auth            auth.Repository
+	AccessToken     accesstoken.Repository
+	Session         session.Repository
+	Transactor      domain.Transactor
 }
 
 func New(configs ...Configuration) (*Repository, error) {
@@ -45,6 +77,22 @@ func WithPostgresStore(cfg config.DB) Configuration {
 		repo.User = postgres.NewUserRepository(repo.postgres.Client)
 		repo.Task = postgres.NewTaskRepository(repo.postgres.Client)
 		repo.Project = postgres.NewProjectRepository(repo.postgres.Client)
+		repo.Template = postgres.NewTemplateRepository(repo.postgres.Client)
+		repo.Board = postgres.NewBoardRepository(repo.postgres.Client)
+		repo.Sprint = postgres.NewSprintRepository(repo.postgres.Client)
+		repo.Milestone = postgres.NewMilestoneRepository(repo.postgres.Client)
+		repo.Epic = postgres.NewEpicRepository(repo.postgres.Client)
+		repo.Notification = postgres.NewNotificationRepository(repo.postgres.Client)
+		repo.Member = postgres.NewMemberRepository(repo.postgres.Client)
+		repo.ProjectTemplate = postgres.NewProjectTemplateRepository(repo.postgres.Client)
+		repo.Budget = postgres.NewBudgetRepository(repo.postgres.Client)
+		repo.Organization = postgres.NewOrganizationRepository(repo.postgres.Client)
+		repo.Team = postgres.NewTeamRepository(repo.postgres.Client)
+		repo.Calendar = postgres.NewCalendarRepository(repo.postgres.Client)
+		repo.Auth = postgres.NewAuthRepository(repo.postgres.Client)
+		repo.AccessToken = postgres.NewAccessTokenRepository(repo.postgres.Client)
+		repo.Session = postgres.NewSessionRepository(repo.postgres.Client)
+		repo.Transactor = postgres.NewTransactor(repo.postgres.Client)
 
 		return
 	}
@@ -0,0 +1,118 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/accesstoken"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateAccessToken mints a personal access token for the acting user.
+func (s *Service) CreateAccessToken(ctx context.Context, userID string, req accesstoken.Request) (accesstoken.CreatedResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizeAccessTokens(ctx, userID); err != nil {
+		return accesstoken.CreatedResponse{}, err
+	}
+
+	if _, err := s.userRepository.Get(ctx, userID); err != nil {
+		logger.Errorln("failed to get user")
+		return accesstoken.CreatedResponse{}, err
+	}
+
+	secret, hash, err := accesstoken.NewSecret()
+	if err != nil {
+		logger.Errorln("failed to generate access token")
+		return accesstoken.CreatedResponse{}, err
+	}
+
+	expires, _ := time.Parse(domain.DateLayout, req.ExpiresAt)
+
+	data := accesstoken.Token{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		Hash:      hash,
+		ExpiresAt: expires,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if err := s.accessTokenRepository.Create(ctx, data); err != nil {
+		logger.Errorln("failed to create access token")
+		return accesstoken.CreatedResponse{}, err
+	}
+
+	return accesstoken.CreatedResponse{Response: accesstoken.ParseFromEntity(data), Token: secret}, nil
+}
+
+func (s *Service) ListAccessTokens(ctx context.Context, userID string) ([]accesstoken.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizeAccessTokens(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	data, err := s.accessTokenRepository.List(ctx, userID)
+	if err != nil {
+		logger.Errorln("failed to list access tokens")
+		return nil, err
+	}
+
+	return accesstoken.ParseFromEntities(data), nil
+}
+
+func (s *Service) RevokeAccessToken(ctx context.Context, userID, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizeAccessTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	err := s.accessTokenRepository.Delete(ctx, userID, id)
+	if err != nil {
+		logger.Errorln("failed to revoke access token")
+		return err
+	}
+
+	return nil
+}
+
+// authorizeAccessTokens keeps the tokens of a user to the user signed in,
+// a token cannot mint others that outlive or outscope it.
+func (s *Service) authorizeAccessTokens(ctx context.Context, userID string) error {
+	if domain.ScopesFromContext(ctx) != nil {
+		return accesstoken.ErrSignInRequired
+	}
+
+	if domain.ActorFromContext(ctx) != userID {
+		return accesstoken.ErrNotOwner
+	}
+
+	return nil
+}
+
+// authenticateAccessToken returns ctx acting as the user of a personal access
+// token, limited to its scopes.
+func (s *Service) authenticateAccessToken(ctx context.Context, secret string) (context.Context, error) {
+	token, err := s.accessTokenRepository.GetByHash(ctx, accesstoken.Hash(secret))
+	if err != nil {
+		return ctx, err
+	}
+
+	if !time.Now().Before(token.ExpiresAt) {
+		return ctx, accesstoken.ErrInvalidToken
+	}
+
+	ctx = domain.WithActor(ctx, token.UserID)
+
+	return domain.WithScopes(ctx, append([]string{}, token.Scopes...)), nil
+}
+
+// Allows reports whether the request may use scope, see accesstoken.Allows.
+func (s *Service) Allows(ctx context.Context, scope string) bool {
+	return accesstoken.Allows(domain.ScopesFromContext(ctx), scope)
+}
@@ -0,0 +1,243 @@
+package management
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/accesstoken"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/session"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Login signs a user of the request's organization in by email and password.
+func (s *Service) Login(ctx context.Context, req auth.LoginRequest) (auth.TokenResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	users, err := s.userRepository.Search(ctx, "email", req.Email)
+	if err != nil && !errors.Is(err, user.ErrNotFound) {
+		logger.Errorln("failed to get user")
+		return auth.TokenResponse{}, err
+	}
+
+	hash := ""
+	if len(users) > 0 {
+		hash = users[0].PasswordHash
+	}
+
+	if !auth.CheckPassword(hash, req.Password) {
+		return auth.TokenResponse{}, auth.ErrInvalidCredentials
+	}
+
+	return s.startSession(ctx, users[0].ID)
+}
+
+// Bootstrap creates the first user of the request's organization, its admin,
+// and signs them in. It is how an organization without users gets one, and
+// fails with user.ErrHasUsers once it has any.
+func (s *Service) Bootstrap(ctx context.Context, req user.BootstrapRequest) (auth.TokenResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		logger.Errorln("failed to hash password")
+		return auth.TokenResponse{}, err
+	}
+
+	data := user.Entity{
+		ID:               uuid.NewString(),
+		Name:             req.Name,
+		Email:            req.Email,
+		RegistrationDate: domain.OnlyDate(time.Now().Format(domain.DateLayout)),
+		Role:             user.RoleAdmin,
+		PasswordHash:     hash,
+	}
+
+	if err := s.userRepository.CreateFirst(ctx, data); err != nil {
+		if !errors.Is(err, user.ErrHasUsers) {
+			logger.Errorln("failed to create first user")
+		}
+		return auth.TokenResponse{}, err
+	}
+
+	return s.startSession(ctx, data.ID)
+}
+
+// Refresh trades a refresh token for a new pair of tokens of its session, the
+// refresh token is spent.
+func (s *Service) Refresh(ctx context.Context, req auth.RefreshRequest) (auth.TokenResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	token, err := s.authRepository.Consume(ctx, auth.Hash(req.RefreshToken))
+	if err != nil {
+		if !errors.Is(err, auth.ErrInvalidToken) {
+			logger.Errorln("failed to consume refresh token")
+		}
+		return auth.TokenResponse{}, err
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return auth.TokenResponse{}, auth.ErrInvalidToken
+	}
+
+	data, err := s.sessionRepository.Get(ctx, token.SessionID)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return auth.TokenResponse{}, auth.ErrInvalidToken
+		}
+		logger.Errorln("failed to get session")
+		return auth.TokenResponse{}, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	data.IP = domain.ClientFromContext(ctx).IP
+	data.LastSeenAt = now
+	data.ExpiresAt = now.Add(s.signer.RefreshTTL)
+
+	if err := s.sessionRepository.Update(ctx, data); err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return auth.TokenResponse{}, auth.ErrInvalidToken
+		}
+		logger.Errorln("failed to update session")
+		return auth.TokenResponse{}, err
+	}
+
+	return s.issueTokens(ctx, data)
+}
+
+// Logout ends the session of the refresh token, its access tokens stop
+// working too.
+func (s *Service) Logout(ctx context.Context, req auth.RefreshRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	token, err := s.authRepository.Consume(ctx, auth.Hash(req.RefreshToken))
+	if err != nil {
+		if !errors.Is(err, auth.ErrInvalidToken) {
+			logger.Errorln("failed to consume refresh token")
+			return err
+		}
+		return nil
+	}
+
+	err = s.sessionRepository.Delete(ctx, token.UserID, token.SessionID)
+	if err != nil && !errors.Is(err, session.ErrNotFound) {
+		logger.Errorln("failed to delete session")
+		return err
+	}
+
+	return nil
+}
+
+// Authenticate verifies an access token, of a sign-in or a personal one,
+// and returns ctx acting as its user. Tokens are only valid in the
+// organization they were issued in and those of a sign-in only as long as
+// its session.
+func (s *Service) Authenticate(ctx context.Context, token string) (context.Context, error) {
+	if strings.HasPrefix(token, accesstoken.Prefix) {
+		return s.authenticateAccessToken(ctx, token)
+	}
+
+	claims, err := s.signer.Verify(token)
+	if err != nil {
+		return ctx, err
+	}
+
+	if claims.Organization != domain.TenantFromContext(ctx) {
+		return ctx, auth.ErrInvalidToken
+	}
+
+	data, err := s.sessionRepository.Get(ctx, claims.Session)
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			return ctx, auth.ErrInvalidToken
+		}
+		return ctx, err
+	}
+
+	if data.UserID != claims.Subject {
+		return ctx, auth.ErrInvalidToken
+	}
+
+	if now := time.Now().UTC(); now.Sub(data.LastSeenAt) >= session.SeenEvery {
+		data.IP = domain.ClientFromContext(ctx).IP
+		data.LastSeenAt = now.Truncate(time.Second)
+
+		if err := s.sessionRepository.Update(ctx, data); err != nil {
+			if errors.Is(err, session.ErrNotFound) {
+				return ctx, auth.ErrInvalidToken
+			}
+			logrus.WithContext(ctx).Errorln("failed to update session")
+		}
+	}
+
+	ctx = domain.WithActor(ctx, claims.Subject)
+
+	return domain.WithSession(ctx, data.ID), nil
+}
+
+// startSession signs the user in on the device of the request.
+func (s *Service) startSession(ctx context.Context, userID string) (auth.TokenResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	client := domain.ClientFromContext(ctx)
+
+	data := session.Session{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		UserAgent:  session.Device(client.UserAgent),
+		IP:         client.IP,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(s.signer.RefreshTTL),
+	}
+
+	if err := s.sessionRepository.Create(ctx, data); err != nil {
+		logger.Errorln("failed to create session")
+		return auth.TokenResponse{}, err
+	}
+
+	return s.issueTokens(ctx, data)
+}
+
+// issueTokens returns a new pair of tokens of the session, lasting until it
+// expires.
+func (s *Service) issueTokens(ctx context.Context, data session.Session) (auth.TokenResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	access, err := s.signer.Sign(data.UserID, domain.TenantFromContext(ctx), data.ID, now)
+	if err != nil {
+		logger.Errorln("failed to sign access token")
+		return auth.TokenResponse{}, err
+	}
+
+	secret, hash, err := auth.NewSecret()
+	if err != nil {
+		logger.Errorln("failed to generate refresh token")
+		return auth.TokenResponse{}, err
+	}
+
+	refresh := auth.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    data.UserID,
+		SessionID: data.ID,
+		Hash:      hash,
+		ExpiresAt: data.ExpiresAt,
+		CreatedAt: now,
+	}
+
+	if err := s.authRepository.Create(ctx, refresh); err != nil {
+		logger.Errorln("failed to create refresh token")
+		return auth.TokenResponse{}, err
+	}
+
+	return auth.ParseFromTokens(access, s.signer.AccessTTL, secret, refresh), nil
+}
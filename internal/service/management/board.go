@@ -0,0 +1,147 @@
+package management
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/board"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *Service) GetBoard(ctx context.Context, projectID string) (board.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize board read")
+		return board.Response{}, err
+	}
+
+	if _, err := s.projectRepository.Get(ctx, projectID); err != nil {
+		logger.Errorln("failed to get project")
+		return board.Response{}, err
+	}
+
+	tasks, err := s.taskRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to list project tasks")
+		return board.Response{}, err
+	}
+
+	limits, err := s.boardRepository.Limits(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to get wip limits")
+		return board.Response{}, err
+	}
+
+	return board.Build(projectID, tasks, limits), nil
+}
+
+func (s *Service) GetBoardLimits(ctx context.Context, projectID string) ([]board.LimitResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize wip limits read")
+		return nil, err
+	}
+
+	if _, err := s.projectRepository.Get(ctx, projectID); err != nil {
+		logger.Errorln("failed to get project")
+		return nil, err
+	}
+
+	data, err := s.boardRepository.Limits(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to get wip limits")
+		return nil, err
+	}
+
+	return board.ParseFromLimits(data), nil
+}
+
+func (s *Service) SetBoardLimits(ctx context.Context, projectID string, req board.LimitsRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if _, err := s.projectRepository.Get(ctx, projectID); err != nil {
+		logger.Errorln("failed to get project")
+		return err
+	}
+
+	if err := s.authorizeWrite(ctx, projectID, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize board limits change")
+		return err
+	}
+
+	limits := make([]board.Limit, len(req.Limits))
+	for i, l := range req.Limits {
+		limits[i] = board.Limit{
+			ProjectID: projectID,
+			Status:    l.Status,
+			WIPLimit:  l.WIPLimit,
+			Strict:    l.Strict,
+		}
+	}
+
+	err := s.boardRepository.SetLimits(ctx, projectID, limits)
+	if err != nil {
+		logger.Errorln("failed to set wip limits")
+		return err
+	}
+
+	return nil
+}
+
+// checkTaskColumn checks the WIP limit of the column a task ends up in, unless
+// it was there already. from is the task before the change, zero for a new
+// one. Every change that can put a task into a column goes through here or,
+// for several tasks, through checkWIPLimit.
+func (s *Service) checkTaskColumn(ctx context.Context, from task.Entity, projectID, status string) (string, error) {
+	if status == "" || (from.ProjectID == projectID && from.Status == status) {
+		return "", nil
+	}
+
+	return s.checkWIPLimit(ctx, projectID, status, 1)
+}
+
+// checkWIPLimit verifies there is room for n more tasks in the column.
+// It fails for strict limits and returns a warning for the others.
+func (s *Service) checkWIPLimit(ctx context.Context, projectID, status string, n int) (string, error) {
+	limits, err := s.boardRepository.Limits(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, l := range limits {
+		if l.Status != status {
+			continue
+		}
+
+		count, err := s.taskRepository.CountByStatus(ctx, projectID, status)
+		if err != nil {
+			return "", err
+		}
+
+		if !l.Exceeded(count, n) {
+			return "", nil
+		}
+
+		if l.Strict {
+			return "", board.ErrWIPLimit
+		}
+
+		return fmt.Sprintf("column %s is over its wip limit (%d/%d)", status, count+n, l.WIPLimit), nil
+	}
+
+	return "", nil
+}
+
+// withWarning appends the warning of a WIP limit to a success message.
+func withWarning(msg, warning string) string {
+	if warning == "" {
+		return msg
+	}
+
+	return msg + ", " + warning
+}
@@ -0,0 +1,271 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/budget"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SetUserRate adds a rate that takes effect on req.EffectiveFrom. Earlier
+// rates stay in place so hours already logged keep their price.
+func (s *Service) SetUserRate(ctx context.Context, userID string, req budget.RateRequest) (budget.RateResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.FinanceManage); err != nil {
+		logger.Errorln("failed to authorize user rate change")
+		return budget.RateResponse{}, err
+	}
+
+	if _, err := s.userRepository.Get(ctx, userID); err != nil {
+		logger.Errorln("failed to get user")
+		return budget.RateResponse{}, err
+	}
+
+	data := budget.Rate{
+		ID:            uuid.NewString(),
+		UserID:        userID,
+		HourlyRate:    req.HourlyRate,
+		Currency:      req.Currency,
+		EffectiveFrom: domain.OnlyDate(req.EffectiveFrom),
+	}
+
+	if err := s.budgetRepository.AddRate(ctx, data); err != nil {
+		logger.Errorln("failed to add user rate")
+		return budget.RateResponse{}, err
+	}
+
+	return budget.ParseFromRate(data), nil
+}
+
+func (s *Service) ListUserRates(ctx context.Context, userID string) ([]budget.RateResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.FinanceManage); err != nil {
+		logger.Errorln("failed to authorize user rates read")
+		return nil, err
+	}
+
+	if _, err := s.userRepository.Get(ctx, userID); err != nil {
+		logger.Errorln("failed to get user")
+		return nil, err
+	}
+
+	rates, err := s.budgetRepository.ListRates(ctx, userID)
+	if err != nil {
+		logger.Errorln("failed to list user rates")
+		return nil, err
+	}
+
+	return budget.ParseFromRates(rates), nil
+}
+
+// LogTime records hours on a task for req.UserID, the acting user by
+// default. Contributors log their own hours; logging for someone else takes
+// a maintainer.
+func (s *Service) LogTime(ctx context.Context, taskID string, req budget.TimeEntryRequest) (budget.TimeEntryResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	t, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return budget.TimeEntryResponse{}, err
+	}
+
+	actor := domain.ActorFromContext(ctx)
+	if req.UserID == "" {
+		req.UserID = actor
+	}
+
+	min := member.RoleContributor
+	if req.UserID != actor {
+		min = member.RoleMaintainer
+	}
+
+	if err = s.authorizeWrite(ctx, t.ProjectID, min); err != nil {
+		logger.Errorln("failed to authorize time entry")
+		return budget.TimeEntryResponse{}, err
+	}
+
+	if _, err = s.userRepository.Get(ctx, req.UserID); err != nil {
+		logger.Errorln("failed to get user")
+		return budget.TimeEntryResponse{}, err
+	}
+
+	data := budget.TimeEntry{
+		ID:      uuid.NewString(),
+		TaskID:  taskID,
+		UserID:  req.UserID,
+		Hours:   req.Hours,
+		SpentOn: domain.OnlyDate(req.SpentOn),
+		Note:    req.Note,
+	}
+
+	if err = s.budgetRepository.AddTimeEntry(ctx, data); err != nil {
+		logger.Errorln("failed to add time entry")
+		return budget.TimeEntryResponse{}, err
+	}
+
+	return budget.ParseFromTimeEntry(data), nil
+}
+
+func (s *Service) ListTimeEntries(ctx context.Context, taskID string) ([]budget.TimeEntryResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	t, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return nil, err
+	}
+
+	if err = s.authorize(ctx, t.ProjectID, member.RoleViewer); err != nil {
+		logger.Errorln("failed to authorize time entries read")
+		return nil, err
+	}
+
+	entries, err := s.budgetRepository.ListTimeEntries(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to list time entries")
+		return nil, err
+	}
+
+	return budget.ParseFromTimeEntries(entries), nil
+}
+
+func (s *Service) DeleteTimeEntry(ctx context.Context, taskID, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	t, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return err
+	}
+
+	e, err := s.budgetRepository.GetTimeEntry(ctx, taskID, id)
+	if err != nil {
+		logger.Errorln("failed to get time entry")
+		return err
+	}
+
+	// as with logging them, other users' hours take a maintainer
+	min := member.RoleContributor
+	if e.UserID != domain.ActorFromContext(ctx) {
+		min = member.RoleMaintainer
+	}
+
+	if err = s.authorizeWrite(ctx, t.ProjectID, min); err != nil {
+		logger.Errorln("failed to authorize time entry deletion")
+		return err
+	}
+
+	if err = s.budgetRepository.DeleteTimeEntry(ctx, taskID, id); err != nil {
+		logger.Errorln("failed to delete time entry")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) AddExpense(ctx context.Context, taskID string, req budget.ExpenseRequest) (budget.ExpenseResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	t, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return budget.ExpenseResponse{}, err
+	}
+
+	if err = s.authorizeWrite(ctx, t.ProjectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize expense")
+		return budget.ExpenseResponse{}, err
+	}
+
+	data := budget.Expense{
+		ID:          uuid.NewString(),
+		TaskID:      taskID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		SpentOn:     domain.OnlyDate(req.SpentOn),
+		Description: req.Description,
+	}
+
+	if err = s.budgetRepository.AddExpense(ctx, data); err != nil {
+		logger.Errorln("failed to add expense")
+		return budget.ExpenseResponse{}, err
+	}
+
+	return budget.ParseFromExpense(data), nil
+}
+
+func (s *Service) ListExpenses(ctx context.Context, taskID string) ([]budget.ExpenseResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	t, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return nil, err
+	}
+
+	if err = s.authorize(ctx, t.ProjectID, member.RoleViewer); err != nil {
+		logger.Errorln("failed to authorize expenses read")
+		return nil, err
+	}
+
+	expenses, err := s.budgetRepository.ListExpenses(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to list expenses")
+		return nil, err
+	}
+
+	return budget.ParseFromExpenses(expenses), nil
+}
+
+func (s *Service) DeleteExpense(ctx context.Context, taskID, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	t, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return err
+	}
+
+	if err = s.authorizeWrite(ctx, t.ProjectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize expense deletion")
+		return err
+	}
+
+	if err = s.budgetRepository.DeleteExpense(ctx, taskID, id); err != nil {
+		logger.Errorln("failed to delete expense")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) GetProjectBudget(ctx context.Context, projectID string) (budget.ReportResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorize(ctx, projectID, member.RoleViewer); err != nil {
+		logger.Errorln("failed to authorize project budget read")
+		return budget.ReportResponse{}, err
+	}
+
+	p, err := s.projectRepository.Get(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to get project")
+		return budget.ReportResponse{}, err
+	}
+
+	costs, err := s.budgetRepository.Costs(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to get project costs")
+		return budget.ReportResponse{}, err
+	}
+
+	return budget.ParseFromReport(projectID, budget.Compute(p, costs, time.Now())), nil
+}
@@ -0,0 +1,177 @@
+package management
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/calendar"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/pkg/ical"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateCalendarToken issues a feed token for the acting user.
+func (s *Service) CreateCalendarToken(ctx context.Context, userID string, req calendar.Request) (calendar.CreatedResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if domain.ActorFromContext(ctx) != userID {
+		return calendar.CreatedResponse{}, calendar.ErrNotOwner
+	}
+
+	if _, err := s.userRepository.Get(ctx, userID); err != nil {
+		logger.Errorln("failed to get user")
+		return calendar.CreatedResponse{}, err
+	}
+
+	secret, hash, err := calendar.NewSecret()
+	if err != nil {
+		logger.Errorln("failed to generate calendar token")
+		return calendar.CreatedResponse{}, err
+	}
+
+	data := calendar.Token{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      req.Name,
+		Hash:      hash,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if err := s.calendarRepository.Create(ctx, data); err != nil {
+		logger.Errorln("failed to create calendar token")
+		return calendar.CreatedResponse{}, err
+	}
+
+	return calendar.CreatedResponse{Response: calendar.ParseFromEntity(data), Token: secret}, nil
+}
+
+func (s *Service) ListCalendarTokens(ctx context.Context, userID string) ([]calendar.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if domain.ActorFromContext(ctx) != userID {
+		return nil, calendar.ErrNotOwner
+	}
+
+	data, err := s.calendarRepository.List(ctx, userID)
+	if err != nil {
+		logger.Errorln("failed to list calendar tokens")
+		return nil, err
+	}
+
+	return calendar.ParseFromEntities(data), nil
+}
+
+// RevokeCalendarToken stops the feeds subscribed to with the token.
+func (s *Service) RevokeCalendarToken(ctx context.Context, userID, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	if domain.ActorFromContext(ctx) != userID {
+		return calendar.ErrNotOwner
+	}
+
+	err := s.calendarRepository.Delete(ctx, userID, id)
+	if err != nil {
+		logger.Errorln("failed to revoke calendar token")
+		return err
+	}
+
+	return nil
+}
+
+// UserCalendar is the feed of the token's own user.
+func (s *Service) UserCalendar(ctx context.Context, secret, userID string) (ical.Calendar, error) {
+	logger := logrus.WithContext(ctx)
+
+	ctx, token, err := s.resolveCalendarToken(ctx, secret)
+	if err != nil {
+		logger.Errorln("failed to resolve calendar token")
+		return ical.Calendar{}, err
+	}
+
+	if token.UserID != userID {
+		return ical.Calendar{}, calendar.ErrForbidden
+	}
+
+	u, err := s.userRepository.Get(ctx, userID)
+	if err != nil {
+		logger.Errorln("failed to get user")
+		return ical.Calendar{}, err
+	}
+
+	tasks, err := s.taskRepository.Search(ctx, "author_id", userID)
+	if err != nil && !errors.Is(err, task.ErrNotFound) {
+		logger.Errorln("failed to list user tasks")
+		return ical.Calendar{}, err
+	}
+
+	memberships, err := s.memberRepository.ListByUser(ctx, userID)
+	if err != nil {
+		logger.Errorln("failed to list user projects")
+		return ical.Calendar{}, err
+	}
+
+	projects := []project.Entity{}
+	for _, m := range memberships {
+		p, err := s.projectRepository.Get(ctx, m.ProjectID)
+		if err != nil {
+			logger.Errorln("failed to get project")
+			return ical.Calendar{}, err
+		}
+		projects = append(projects, p)
+	}
+
+	return calendar.UserFeed(u.Name, projects, tasks, time.Now()), nil
+}
+
+// ProjectCalendar is the feed of a project the token's user is a member of.
+func (s *Service) ProjectCalendar(ctx context.Context, secret, projectID string) (ical.Calendar, error) {
+	logger := logrus.WithContext(ctx)
+
+	ctx, token, err := s.resolveCalendarToken(ctx, secret)
+	if err != nil {
+		logger.Errorln("failed to resolve calendar token")
+		return ical.Calendar{}, err
+	}
+
+	if _, err := s.memberRepository.Get(ctx, projectID, token.UserID); err != nil {
+		if errors.Is(err, member.ErrNotFound) {
+			return ical.Calendar{}, calendar.ErrForbidden
+		}
+		logger.Errorln("failed to get project member")
+		return ical.Calendar{}, err
+	}
+
+	p, err := s.projectRepository.Get(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to get project")
+		return ical.Calendar{}, err
+	}
+
+	tasks, err := s.taskRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to list project tasks")
+		return ical.Calendar{}, err
+	}
+
+	return calendar.ProjectFeed(p, tasks, time.Now()), nil
+}
+
+// resolveCalendarToken confines ctx to the organization of the token, feeds
+// are read by calendar apps that cannot name one.
+func (s *Service) resolveCalendarToken(ctx context.Context, secret string) (context.Context, calendar.Token, error) {
+	if secret == "" {
+		return ctx, calendar.Token{}, calendar.ErrInvalidToken
+	}
+
+	token, err := s.calendarRepository.GetByHash(ctx, calendar.Hash(secret))
+	if err != nil {
+		return ctx, calendar.Token{}, err
+	}
+
+	return domain.WithTenant(ctx, token.OrganizationID), token, nil
+}
@@ -0,0 +1,232 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/epic"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *Service) CreateEpic(ctx context.Context, req epic.Request) (string, epic.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.EpicsManage); err != nil {
+		logger.Errorln("failed to authorize epic creation")
+		return "", epic.Response{}, err
+	}
+
+	if _, err := s.userRepository.Get(ctx, req.OwnerID); err != nil {
+		logger.Errorln("failed to get epic owner")
+		return "", epic.Response{}, err
+	}
+
+	data := epic.Entity{
+		ID:          uuid.NewString(),
+		Title:       req.Title,
+		Description: req.Description,
+		OwnerID:     req.OwnerID,
+		CreatedAt:   domain.OnlyDate(time.Now().Format(domain.DateLayout)),
+	}
+
+	msg, obj, err := s.epicRepository.Create(ctx, data)
+	if err != nil {
+		logger.Errorln("failed to create epic")
+		return "", epic.Response{}, err
+	}
+
+	return msg, epic.ParseFromEntity(obj, epic.RollUp(nil)), nil
+}
+
+func (s *Service) GetEpic(ctx context.Context, id string) (epic.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.EpicsRead); err != nil {
+		logger.Errorln("failed to authorize epic read")
+		return epic.Response{}, err
+	}
+
+	data, err := s.epicRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get epic")
+		return epic.Response{}, err
+	}
+
+	tasks, err := s.taskRepository.ListByEpic(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list epic tasks")
+		return epic.Response{}, err
+	}
+
+	return epic.ParseFromEntity(data, epic.RollUp(tasks)), nil
+}
+
+func (s *Service) ListEpics(ctx context.Context) ([]epic.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.EpicsRead); err != nil {
+		logger.Errorln("failed to authorize listing epics")
+		return nil, err
+	}
+
+	data, err := s.epicRepository.List(ctx)
+	if err != nil {
+		logger.Errorln("failed to list epics")
+		return nil, err
+	}
+
+	res := []epic.Response{}
+	for _, e := range data {
+		tasks, err := s.taskRepository.ListByEpic(ctx, e.ID)
+		if err != nil {
+			logger.Errorln("failed to list epic tasks")
+			return nil, err
+		}
+
+		res = append(res, epic.ParseFromEntity(e, epic.RollUp(tasks)))
+	}
+
+	return res, nil
+}
+
+func (s *Service) UpdateEpic(ctx context.Context, id string, req epic.UpdateRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.EpicsManage); err != nil {
+		logger.Errorln("failed to authorize epic update")
+		return err
+	}
+
+	if req.OwnerID != "" {
+		if _, err := s.userRepository.Get(ctx, req.OwnerID); err != nil {
+			logger.Errorln("failed to get epic owner")
+			return err
+		}
+	}
+
+	data := epic.Entity{
+		Title:       req.Title,
+		Description: req.Description,
+		OwnerID:     req.OwnerID,
+	}
+
+	err := s.epicRepository.Update(ctx, id, data)
+	if err != nil {
+		logger.Errorln("failed to update epic")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteEpic(ctx context.Context, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.EpicsManage); err != nil {
+		logger.Errorln("failed to authorize epic deletion")
+		return err
+	}
+
+	err := s.epicRepository.Delete(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to delete epic")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) ListEpicTasks(ctx context.Context, id string) ([]task.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.EpicsRead); err != nil {
+		logger.Errorln("failed to authorize listing epic tasks")
+		return nil, err
+	}
+
+	if _, err := s.epicRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get epic")
+		return nil, err
+	}
+
+	data, err := s.taskRepository.ListByEpic(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list epic tasks")
+		return nil, err
+	}
+
+	return task.ParseFromEntities(data), nil
+}
+
+// AddEpicTasks checks every task before adding any, then adds them all at
+// once.
+func (s *Service) AddEpicTasks(ctx context.Context, id string, req epic.TasksRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.EpicsManage); err != nil {
+		logger.Errorln("failed to authorize epic tasks change")
+		return err
+	}
+
+	if _, err := s.epicRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get epic")
+		return err
+	}
+
+	for _, taskID := range req.TaskIDs {
+		t, err := s.taskRepository.Get(ctx, taskID)
+		if err != nil {
+			logger.Errorln("failed to get task")
+			return err
+		}
+
+		if err := s.authorizeWrite(ctx, t.ProjectID, member.RoleContributor); err != nil {
+			logger.Errorln("failed to authorize epic tasks change")
+			return err
+		}
+	}
+
+	if err := s.taskRepository.SetEpic(ctx, req.TaskIDs, id); err != nil {
+		logger.Errorln("failed to add tasks to epic")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) RemoveEpicTask(ctx context.Context, id, taskID string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.EpicsManage); err != nil {
+		logger.Errorln("failed to authorize epic tasks change")
+		return err
+	}
+
+	t, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return err
+	}
+
+	if err := s.authorizeWrite(ctx, t.ProjectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize epic tasks change")
+		return err
+	}
+
+	if t.EpicID != id {
+		return epic.ErrNotLinked
+	}
+
+	err = s.taskRepository.SetEpic(ctx, []string{taskID}, "")
+	if err != nil {
+		logger.Errorln("failed to remove task from epic")
+		return err
+	}
+
+	return nil
+}
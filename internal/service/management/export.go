@@ -0,0 +1,313 @@
+package management
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/export"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// ExportProject collects the project, its tasks and members and every user
+// they reference. It is open to maintainers, as the archive lists the
+// members' emails.
+func (s *Service) ExportProject(ctx context.Context, id string) (export.Archive, error) {
+	logger := logrus.WithContext(ctx)
+
+	p, err := s.projectRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get project")
+		return export.Archive{}, err
+	}
+
+	if err := s.authorize(ctx, id, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize project export")
+		return export.Archive{}, err
+	}
+
+	tasks, err := s.taskRepository.ListByProject(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list project tasks")
+		return export.Archive{}, err
+	}
+
+	members, err := s.memberRepository.List(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list project members")
+		return export.Archive{}, err
+	}
+
+	userIDs := []string{p.ManagerID}
+	for _, t := range tasks {
+		userIDs = append(userIDs, t.AuthorID)
+	}
+	for _, m := range members {
+		userIDs = append(userIDs, m.UserID)
+	}
+
+	m := export.Manifest{
+		ExportedAt: time.Now().UTC(),
+		Project:    project.ParseFromEntity(p),
+		Tasks:      []task.Response{},
+		Users:      []user.Response{},
+		Members:    member.ParseFromEntities(members),
+	}
+
+	for _, t := range tasks {
+		m.Tasks = append(m.Tasks, task.ParseFromEntity(t))
+	}
+
+	seen := map[string]bool{}
+	for _, userID := range userIDs {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		u, err := s.userRepository.Get(ctx, userID)
+		if err != nil {
+			logger.Errorln("failed to get referenced user")
+			return export.Archive{}, err
+		}
+
+		m.Users = append(m.Users, user.ParseFromEntity(u))
+	}
+
+	return export.Archive{Manifest: m}, nil
+}
+
+// ImportProject recreates an exported project under fresh IDs in one
+// transaction. Users are matched by email and, for those who manage users,
+// created with the default role when missing; roles are not taken from the
+// archive. Milestones and epics are not part of the archive, so the imported
+// tasks are not linked to any.
+func (s *Service) ImportProject(ctx context.Context, a export.Archive) (report export.Report, err error) {
+	logger := logrus.WithContext(ctx)
+
+	if err = s.can(ctx, policy.ProjectsCreate); err != nil {
+		logger.Errorln("failed to authorize project import")
+		return
+	}
+
+	m := a.Manifest
+	if err = validateManifest(m); err != nil {
+		logger.Errorln("failed to validate project archive")
+		return
+	}
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		report, err = s.importProject(ctx, m)
+		return err
+	})
+	if err != nil {
+		return export.Report{}, err
+	}
+
+	s.refreshHealth(ctx, report.Project.NewID)
+
+	return
+}
+
+func (s *Service) importProject(ctx context.Context, m export.Manifest) (export.Report, error) {
+	logger := logrus.WithContext(ctx)
+
+	report := export.Report{Tasks: []export.Mapping{}, Users: []export.UserMapping{}}
+
+	canCreate := false
+	users := map[string]string{}
+	for _, u := range m.Users {
+		mapping := export.UserMapping{Mapping: export.Mapping{OldID: u.ID}, Email: u.Email}
+
+		existing, err := s.userRepository.Search(ctx, "email", u.Email)
+		switch {
+		case err == nil:
+			mapping.NewID = existing[0].ID
+		case errors.Is(err, user.ErrNotFound):
+			if !canCreate {
+				if err := s.can(ctx, policy.UsersManage); err != nil {
+					logger.Errorln("failed to authorize user creation")
+					return report, err
+				}
+				canCreate = true
+			}
+
+			mapping.NewID = uuid.NewString()
+			mapping.Created = true
+
+			_, _, err = s.userRepository.Create(ctx, user.Entity{
+				ID:               mapping.NewID,
+				Name:             u.Name,
+				Email:            u.Email,
+				RegistrationDate: domain.OnlyDate(u.RegistrationDate),
+				Role:             user.DefaultRole,
+			})
+			if err != nil {
+				logger.Errorln("failed to create user")
+				return report, err
+			}
+		default:
+			logger.Errorln("failed to search user")
+			return report, err
+		}
+
+		users[u.ID] = mapping.NewID
+		report.Users = append(report.Users, mapping)
+	}
+
+	p := project.Entity{
+		ID:             uuid.NewString(),
+		Title:          m.Project.Title,
+		Description:    m.Project.Description,
+		StartedAt:      domain.OnlyDate(m.Project.StartedAt),
+		FinishedAt:     domain.OnlyDate(m.Project.FinishedAt),
+		ManagerID:      users[m.Project.ManagerID],
+		Status:         m.Project.Status,
+		BudgetAmount:   m.Project.BudgetAmount,
+		BudgetCurrency: m.Project.BudgetCurrency,
+	}
+
+	if _, _, err := s.projectRepository.Create(ctx, p); err != nil {
+		logger.Errorln("failed to create project")
+		return report, err
+	}
+	report.Project = export.Mapping{OldID: m.Project.ID, NewID: p.ID}
+
+	// a failed statement aborts the transaction, so members are added once
+	// each rather than relying on the duplicates being refused
+	roles := map[string]string{}
+	order := []string{}
+	for _, mm := range m.Members {
+		userID := users[mm.UserID]
+		if _, ok := roles[userID]; !ok {
+			order = append(order, userID)
+		}
+		roles[userID] = mm.Role
+	}
+	for _, userID := range []string{p.ManagerID, domain.ActorFromContext(ctx)} {
+		if userID == "" {
+			continue
+		}
+		if _, ok := roles[userID]; !ok {
+			order = append(order, userID)
+		}
+		roles[userID] = member.RoleOwner
+	}
+
+	for _, userID := range order {
+		err := s.memberRepository.Add(ctx, member.Entity{ProjectID: p.ID, UserID: userID, Role: roles[userID]})
+		if err != nil {
+			logger.Errorln("failed to add project member")
+			return report, err
+		}
+	}
+
+	tasks := map[string]string{}
+	for _, t := range m.Tasks {
+		tasks[t.ID] = uuid.NewString()
+	}
+
+	for _, t := range m.Tasks {
+		predecessors := []string{}
+		for _, id := range t.PredecessorIDs {
+			if newID, ok := tasks[id]; ok {
+				predecessors = append(predecessors, newID)
+			}
+		}
+
+		data := task.Entity{
+			ID:             tasks[t.ID],
+			Title:          t.Title,
+			Description:    t.Description,
+			Priority:       t.Priority,
+			Status:         t.Status,
+			AuthorID:       users[t.AuthorID],
+			ProjectID:      p.ID,
+			CreatedAt:      domain.OnlyDate(t.CreatedAt),
+			DoneAt:         domain.OnlyDate(t.DoneAt),
+			StartAt:        domain.OnlyDate(t.StartAt),
+			PredecessorIDs: predecessors,
+		}
+
+		if _, _, err := s.taskRepository.Create(ctx, data); err != nil {
+			logger.Errorln("failed to create task")
+			return report, err
+		}
+
+		report.Tasks = append(report.Tasks, export.Mapping{OldID: t.ID, NewID: data.ID})
+	}
+
+	return report, nil
+}
+
+// validateManifest checks what the database would otherwise reject halfway
+// through an import.
+func validateManifest(m export.Manifest) error {
+	if m.Project.Title == "" || !project.IsValidStatus(m.Project.Status) {
+		return export.ErrInvalid
+	}
+
+	for _, d := range []string{m.Project.StartedAt, m.Project.FinishedAt} {
+		if _, err := time.Parse(domain.DateLayout, d); err != nil {
+			return export.ErrInvalid
+		}
+	}
+
+	users := map[string]bool{"": true}
+	for _, u := range m.Users {
+		if u.ID == "" || u.Email == "" {
+			return export.ErrInvalid
+		}
+		users[u.ID] = true
+	}
+
+	if !users[m.Project.ManagerID] {
+		return export.ErrInvalid
+	}
+
+	for _, mm := range m.Members {
+		if mm.UserID == "" || !users[mm.UserID] || !member.IsValidRole(mm.Role) {
+			return export.ErrInvalid
+		}
+	}
+
+	tasks := make([]task.Entity, len(m.Tasks))
+	for i, t := range m.Tasks {
+		if t.ID == "" || !users[t.AuthorID] || !task.IsValidStatus(t.Status) || !task.IsValidPriority(t.Priority) {
+			return export.ErrInvalid
+		}
+
+		for _, d := range []string{t.CreatedAt, t.DoneAt, t.StartAt} {
+			if _, err := time.Parse(domain.DateLayout, d); err != nil {
+				return export.ErrInvalid
+			}
+		}
+
+		if t.StartAt > t.DoneAt {
+			return export.ErrInvalid
+		}
+
+		for _, id := range t.PredecessorIDs {
+			if id == t.ID {
+				return export.ErrInvalid
+			}
+		}
+
+		tasks[i] = task.Entity{ID: t.ID, StartAt: domain.OnlyDate(t.StartAt), PredecessorIDs: t.PredecessorIDs}
+	}
+
+	// predecessors outside the archive are dropped on import, as TopoSort
+	// ignores them
+	if _, err := task.TopoSort(tasks); err != nil {
+		return export.ErrInvalid
+	}
+
+	return nil
+}
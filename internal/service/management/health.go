@@ -0,0 +1,97 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/sirupsen/logrus"
+)
+
+// GetPortfolioHealth lists the health of every project that is not archived.
+func (s *Service) GetPortfolioHealth(ctx context.Context) (project.PortfolioResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize portfolio health read")
+		return project.PortfolioResponse{}, err
+	}
+
+	data, err := s.projectRepository.List(ctx, false)
+	if err != nil {
+		logger.Errorln("failed to list projects")
+		return project.PortfolioResponse{}, err
+	}
+
+	return project.ParseFromPortfolio(data), nil
+}
+
+// RefreshProjectHealth reassesses every project that is not archived, in
+// every organization. Health depends on the date as well as on the tasks,
+// so it is meant to run periodically besides the updates on changes.
+func (s *Service) RefreshProjectHealth(ctx context.Context) (int, error) {
+	logger := logrus.WithContext(ctx)
+
+	organizations, err := s.organizationRepository.List(ctx)
+	if err != nil {
+		logger.Errorln("failed to list organizations")
+		return 0, err
+	}
+
+	refreshed := 0
+	for _, o := range organizations {
+		ctx := domain.WithTenant(ctx, o.ID)
+
+		projects, err := s.projectRepository.List(ctx, false)
+		if err != nil {
+			logger.Errorln("failed to list projects")
+			return refreshed, err
+		}
+
+		for _, p := range projects {
+			if err := s.assessHealth(ctx, p); err != nil {
+				logger.Errorln("failed to assess project health")
+				return refreshed, err
+			}
+			refreshed++
+		}
+	}
+
+	return refreshed, nil
+}
+
+// refreshHealth reassesses the projects after a change to their tasks or
+// schedule. The change itself has already been made, so failures are only
+// logged and left to the periodic refresh.
+func (s *Service) refreshHealth(ctx context.Context, projectIDs ...string) {
+	logger := logrus.WithContext(ctx)
+
+	seen := map[string]bool{}
+	for _, id := range projectIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		p, err := s.projectRepository.Get(ctx, id)
+		if err != nil {
+			logger.Errorln("failed to get project")
+			continue
+		}
+
+		if err := s.assessHealth(ctx, p); err != nil {
+			logger.Errorln("failed to assess project health")
+		}
+	}
+}
+
+func (s *Service) assessHealth(ctx context.Context, p project.Entity) error {
+	signals, err := s.projectRepository.Signals(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.projectRepository.SetHealth(ctx, p.ID, project.Assess(p, signals, time.Now()))
+}
@@ -0,0 +1,191 @@
+package management
+
+import (
+	"context"
+	"errors"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *Service) ListProjectMembers(ctx context.Context, projectID string) ([]member.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize listing project members")
+		return nil, err
+	}
+
+	if _, err := s.projectRepository.Get(ctx, projectID); err != nil {
+		logger.Errorln("failed to get project")
+		return nil, err
+	}
+
+	data, err := s.memberRepository.List(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to list project members")
+		return nil, err
+	}
+
+	return member.ParseFromEntities(data), nil
+}
+
+// AddProjectMember requires the actor to be a maintainer, or an owner when
+// the new member is made an owner.
+func (s *Service) AddProjectMember(ctx context.Context, projectID string, req member.Request) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsWrite); err != nil {
+		logger.Errorln("failed to authorize project member addition")
+		return err
+	}
+
+	if _, err := s.projectRepository.Get(ctx, projectID); err != nil {
+		logger.Errorln("failed to get project")
+		return err
+	}
+
+	if err := s.authorizeWrite(ctx, projectID, grantingRole(req.Role)); err != nil {
+		logger.Errorln("failed to authorize project member change")
+		return err
+	}
+
+	if _, err := s.userRepository.Get(ctx, req.UserID); err != nil {
+		logger.Errorln("failed to get user")
+		return err
+	}
+
+	err := s.memberRepository.Add(ctx, member.Entity{
+		ProjectID: projectID,
+		UserID:    req.UserID,
+		Role:      req.Role,
+	})
+	if err != nil {
+		logger.Errorln("failed to add project member")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) UpdateProjectMember(ctx context.Context, projectID, userID string, req member.UpdateRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsWrite); err != nil {
+		logger.Errorln("failed to authorize project member update")
+		return err
+	}
+
+	current, err := s.memberRepository.Get(ctx, projectID, userID)
+	if err != nil {
+		logger.Errorln("failed to get project member")
+		return err
+	}
+
+	min := grantingRole(req.Role)
+	if current.Role == member.RoleOwner {
+		min = member.RoleOwner
+	}
+
+	if err := s.authorizeWrite(ctx, projectID, min); err != nil {
+		logger.Errorln("failed to authorize project member change")
+		return err
+	}
+
+	err = s.memberRepository.SetRole(ctx, projectID, userID, req.Role)
+	if err != nil {
+		logger.Errorln("failed to update project member")
+		return err
+	}
+
+	return nil
+}
+
+// RemoveProjectMember lets members leave a project on their own; removing
+// somebody else takes the same role as granting theirs.
+func (s *Service) RemoveProjectMember(ctx context.Context, projectID, userID string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsWrite); err != nil {
+		logger.Errorln("failed to authorize project member removal")
+		return err
+	}
+
+	current, err := s.memberRepository.Get(ctx, projectID, userID)
+	if err != nil {
+		logger.Errorln("failed to get project member")
+		return err
+	}
+
+	if err := s.checkWritable(ctx, projectID); err != nil {
+		logger.Errorln("failed to check project is writable")
+		return err
+	}
+
+	if domain.ActorFromContext(ctx) != userID {
+		if err := s.authorize(ctx, projectID, grantingRole(current.Role)); err != nil {
+			logger.Errorln("failed to authorize project member change")
+			return err
+		}
+	}
+
+	err = s.memberRepository.Remove(ctx, projectID, userID)
+	if err != nil {
+		logger.Errorln("failed to remove project member")
+		return err
+	}
+
+	return nil
+}
+
+// authorize fails with member.ErrForbidden unless the acting user holds at
+// least the min role in the project or is an admin of the organization.
+func (s *Service) authorize(ctx context.Context, projectID, min string) error {
+	actor := domain.ActorFromContext(ctx)
+	if actor == "" {
+		return member.ErrForbidden
+	}
+
+	// admins act as owners of every project
+	role, err := s.actorRole(ctx)
+	if err != nil {
+		return err
+	}
+	if policy.Allows(role, policy.ProjectsAdmin) {
+		return nil
+	}
+
+	m, err := s.memberRepository.Get(ctx, projectID, actor)
+	if err != nil {
+		if errors.Is(err, member.ErrNotFound) {
+			return member.ErrForbidden
+		}
+		return err
+	}
+
+	if !member.Allows(m.Role, min) {
+		return member.ErrForbidden
+	}
+
+	return nil
+}
+
+// authorizeWrite is authorize for changes to the project or its tasks, which
+// archived projects do not accept.
+func (s *Service) authorizeWrite(ctx context.Context, projectID, min string) error {
+	if err := s.checkWritable(ctx, projectID); err != nil {
+		return err
+	}
+
+	return s.authorize(ctx, projectID, min)
+}
+
+// grantingRole is the role needed to give or take away role.
+func grantingRole(role string) string {
+	if role == member.RoleOwner {
+		return member.RoleOwner
+	}
+	return member.RoleMaintainer
+}
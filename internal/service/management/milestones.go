@@ -0,0 +1,259 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/milestone"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *Service) CreateMilestone(ctx context.Context, projectID string, req milestone.Request) (string, milestone.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizeWrite(ctx, projectID, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize milestone creation")
+		return "", milestone.Response{}, err
+	}
+
+	data := milestone.Entity{
+		ID:          uuid.NewString(),
+		ProjectID:   projectID,
+		Title:       req.Title,
+		Description: req.Description,
+		TargetDate:  domain.OnlyDate(req.TargetDate),
+		CreatedAt:   domain.OnlyDate(time.Now().Format(domain.DateLayout)),
+	}
+
+	msg, obj, err := s.milestoneRepository.Create(ctx, data)
+	if err != nil {
+		logger.Errorln("failed to create milestone")
+		return "", milestone.Response{}, err
+	}
+
+	return msg, milestone.ParseFromEntity(obj, milestone.ComputeProgress(obj, nil, time.Now())), nil
+}
+
+func (s *Service) GetMilestone(ctx context.Context, projectID, id string) (milestone.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize milestone read")
+		return milestone.Response{}, err
+	}
+
+	data, err := s.projectMilestone(ctx, projectID, id)
+	if err != nil {
+		logger.Errorln("failed to get milestone")
+		return milestone.Response{}, err
+	}
+
+	tasks, err := s.taskRepository.ListByMilestone(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list milestone tasks")
+		return milestone.Response{}, err
+	}
+
+	return milestone.ParseFromEntity(data, milestone.ComputeProgress(data, tasks, time.Now())), nil
+}
+
+func (s *Service) ListMilestones(ctx context.Context, projectID string) ([]milestone.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize listing milestones")
+		return nil, err
+	}
+
+	data, err := s.milestoneRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to list milestones")
+		return nil, err
+	}
+
+	res := []milestone.Response{}
+	for _, m := range data {
+		tasks, err := s.taskRepository.ListByMilestone(ctx, m.ID)
+		if err != nil {
+			logger.Errorln("failed to list milestone tasks")
+			return nil, err
+		}
+
+		res = append(res, milestone.ParseFromEntity(m, milestone.ComputeProgress(m, tasks, time.Now())))
+	}
+
+	return res, nil
+}
+
+func (s *Service) UpdateMilestone(ctx context.Context, projectID, id string, req milestone.UpdateRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if _, err := s.projectMilestone(ctx, projectID, id); err != nil {
+		logger.Errorln("failed to get milestone")
+		return err
+	}
+
+	if err := s.authorizeWrite(ctx, projectID, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize milestone update")
+		return err
+	}
+
+	data := milestone.Entity{
+		Title:       req.Title,
+		Description: req.Description,
+		TargetDate:  domain.OnlyDate(req.TargetDate),
+	}
+
+	err := s.milestoneRepository.Update(ctx, id, data)
+	if err != nil {
+		logger.Errorln("failed to update milestone")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteMilestone(ctx context.Context, projectID, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	if _, err := s.projectMilestone(ctx, projectID, id); err != nil {
+		logger.Errorln("failed to get milestone")
+		return err
+	}
+
+	if err := s.authorizeWrite(ctx, projectID, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize milestone deletion")
+		return err
+	}
+
+	err := s.milestoneRepository.Delete(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to delete milestone")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) LinkMilestoneTasks(ctx context.Context, projectID, id string, req milestone.TasksRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if _, err := s.projectMilestone(ctx, projectID, id); err != nil {
+		logger.Errorln("failed to get milestone")
+		return err
+	}
+
+	if err := s.authorizeWrite(ctx, projectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize milestone tasks change")
+		return err
+	}
+
+	for _, taskID := range req.TaskIDs {
+		t, err := s.taskRepository.Get(ctx, taskID)
+		if err != nil {
+			logger.Errorln("failed to get task")
+			return err
+		}
+
+		if t.ProjectID != projectID {
+			return milestone.ErrTaskProject
+		}
+
+		if err := s.taskRepository.SetMilestone(ctx, taskID, id); err != nil {
+			logger.Errorln("failed to link task to milestone")
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) UnlinkMilestoneTask(ctx context.Context, projectID, id, taskID string) error {
+	logger := logrus.WithContext(ctx)
+
+	if _, err := s.projectMilestone(ctx, projectID, id); err != nil {
+		logger.Errorln("failed to get milestone")
+		return err
+	}
+
+	if err := s.authorizeWrite(ctx, projectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize milestone tasks change")
+		return err
+	}
+
+	t, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return err
+	}
+
+	// the task is not among the milestone's, there is no link to remove
+	if t.MilestoneID != id {
+		return milestone.ErrNotFound
+	}
+
+	err = s.taskRepository.SetMilestone(ctx, taskID, "")
+	if err != nil {
+		logger.Errorln("failed to unlink task from milestone")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) MilestoneReleaseNotes(ctx context.Context, projectID, id string) (string, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize release notes read")
+		return "", err
+	}
+
+	data, err := s.projectMilestone(ctx, projectID, id)
+	if err != nil {
+		logger.Errorln("failed to get milestone")
+		return "", err
+	}
+
+	tasks, err := s.taskRepository.ListByMilestone(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list milestone tasks")
+		return "", err
+	}
+
+	return milestone.ReleaseNotes(data, tasks), nil
+}
+
+// projectMilestone loads the milestone and hides it when it belongs to
+// another project than the one in the route.
+func (s *Service) projectMilestone(ctx context.Context, projectID, id string) (milestone.Entity, error) {
+	data, err := s.milestoneRepository.Get(ctx, id)
+	if err != nil {
+		return milestone.Entity{}, err
+	}
+
+	if data.ProjectID != projectID {
+		return milestone.Entity{}, milestone.ErrNotFound
+	}
+
+	return data, nil
+}
+
+// checkTaskMilestone verifies that the milestone a task is linked to belongs
+// to the task's project.
+func (s *Service) checkTaskMilestone(ctx context.Context, projectID, milestoneID string) error {
+	data, err := s.milestoneRepository.Get(ctx, milestoneID)
+	if err != nil {
+		return err
+	}
+
+	if data.ProjectID != projectID {
+		return milestone.ErrTaskProject
+	}
+
+	return nil
+}
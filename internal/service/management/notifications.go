@@ -0,0 +1,119 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/notification"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// DispatchDueReminders raises reminder, overdue and escalation notifications
+// for tasks due within the schedule's horizon. Notifications already sent
+// for the same task and due date are skipped, so it is safe to call on every
+// worker tick. Every organization is scanned in turn.
+func (s *Service) DispatchDueReminders(ctx context.Context, schedule notification.Schedule) (int, error) {
+	logger := logrus.WithContext(ctx)
+
+	organizations, err := s.organizationRepository.List(ctx)
+	if err != nil {
+		logger.Errorln("failed to list organizations")
+		return 0, err
+	}
+
+	sent := 0
+	for _, o := range organizations {
+		n, err := s.dispatchDueReminders(domain.WithTenant(ctx, o.ID), schedule)
+		sent += n
+		if err != nil {
+			return sent, err
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *Service) dispatchDueReminders(ctx context.Context, schedule notification.Schedule) (int, error) {
+	logger := logrus.WithContext(ctx)
+
+	today, _ := time.Parse(domain.DateLayout, time.Now().Format(domain.DateLayout))
+
+	tasks, err := s.taskRepository.ListDue(ctx, schedule.Horizon(today).Format(domain.DateLayout))
+	if err != nil {
+		logger.Errorln("failed to list due tasks")
+		return 0, err
+	}
+
+	managers := map[string]string{}
+
+	sent := 0
+	for _, t := range tasks {
+		managerID, ok := managers[t.ProjectID]
+		if !ok {
+			p, err := s.projectRepository.Get(ctx, t.ProjectID)
+			if err != nil {
+				logger.Errorln("failed to get task project")
+				return sent, err
+			}
+
+			managerID = p.ManagerID
+			managers[t.ProjectID] = managerID
+		}
+
+		for _, n := range notification.Plan(t, managerID, today, schedule) {
+			n.ID = uuid.NewString()
+
+			created, err := s.notificationRepository.Create(ctx, n)
+			if err != nil {
+				logger.Errorln("failed to create notification")
+				return sent, err
+			}
+
+			if created {
+				sent++
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *Service) ListUserNotifications(ctx context.Context, userID string, unreadOnly bool) ([]notification.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizeUser(ctx, userID); err != nil {
+		logger.Errorln("failed to authorize listing notifications")
+		return nil, err
+	}
+
+	if _, err := s.userRepository.Get(ctx, userID); err != nil {
+		logger.Errorln("failed to get user")
+		return nil, err
+	}
+
+	data, err := s.notificationRepository.ListByUser(ctx, userID, unreadOnly)
+	if err != nil {
+		logger.Errorln("failed to list notifications")
+		return nil, err
+	}
+
+	return notification.ParseFromEntities(data), nil
+}
+
+func (s *Service) ReadNotification(ctx context.Context, userID, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizeUser(ctx, userID); err != nil {
+		logger.Errorln("failed to authorize notification read")
+		return err
+	}
+
+	if err := s.notificationRepository.MarkRead(ctx, userID, id); err != nil {
+		logger.Errorln("failed to mark notification as read")
+		return err
+	}
+
+	return nil
+}
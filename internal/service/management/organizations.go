@@ -0,0 +1,134 @@
+package management
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/accesstoken"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/organization"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *Service) CreateOrganization(ctx context.Context, req organization.Request) (organization.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizePlatform(ctx); err != nil {
+		logger.Errorln("failed to authorize organization creation")
+		return organization.Response{}, err
+	}
+
+	data := organization.Entity{
+		ID:        uuid.NewString(),
+		Name:      req.Name,
+		Slug:      req.Slug,
+		CreatedAt: domain.OnlyDate(time.Now().Format(domain.DateLayout)),
+	}
+
+	if err := s.organizationRepository.Create(ctx, data); err != nil {
+		logger.Errorln("failed to create organization")
+		return organization.Response{}, err
+	}
+
+	return organization.ParseFromEntity(data), nil
+}
+
+// GetOrganization returns the organization of the request or, to those who
+// run the instance, any other.
+func (s *Service) GetOrganization(ctx context.Context, id string) (organization.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if id != domain.TenantFromContext(ctx) {
+		if err := s.authorizePlatform(ctx); err != nil {
+			logger.Errorln("failed to authorize organization read")
+			return organization.Response{}, err
+		}
+	}
+
+	data, err := s.organizationRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get organization")
+		return organization.Response{}, err
+	}
+
+	return organization.ParseFromEntity(data), nil
+}
+
+func (s *Service) ListOrganizations(ctx context.Context) ([]organization.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizePlatform(ctx); err != nil {
+		logger.Errorln("failed to authorize organizations list")
+		return nil, err
+	}
+
+	data, err := s.organizationRepository.List(ctx)
+	if err != nil {
+		logger.Errorln("failed to list organizations")
+		return nil, err
+	}
+
+	return organization.ParseFromEntities(data), nil
+}
+
+// ResolveTenant confines ctx to the organization named by key, a slug or an
+// ID. An empty key selects the default organization. An access token signed
+// for an organization confines the request to that one instead, and is
+// refused when key names another.
+func (s *Service) ResolveTenant(ctx context.Context, key, token string) (context.Context, error) {
+	org := ""
+	if token != "" && !strings.HasPrefix(token, accesstoken.Prefix) {
+		// a token that does not verify is refused by Authenticate
+		if claims, err := s.signer.Verify(token); err == nil {
+			org = claims.Organization
+		}
+	}
+
+	if key == "" && org != "" {
+		key = org
+	}
+	if key == "" {
+		key = organization.DefaultSlug
+	}
+
+	o, err := s.organizationRepository.GetBySlug(ctx, key)
+	if errors.Is(err, organization.ErrNotFound) {
+		if uuid.Validate(key) != nil {
+			return ctx, err
+		}
+		o, err = s.organizationRepository.Get(ctx, key)
+	}
+	if err != nil {
+		return ctx, err
+	}
+
+	if org != "" && o.ID != org {
+		return ctx, auth.ErrInvalidToken
+	}
+
+	return domain.WithTenant(ctx, o.ID), nil
+}
+
+// authorizePlatform admits the admins of the default organization, who run
+// the instance and so manage the organizations on it.
+func (s *Service) authorizePlatform(ctx context.Context) error {
+	if err := s.can(ctx, policy.OrganizationsManage); err != nil {
+		return err
+	}
+
+	o, err := s.organizationRepository.GetBySlug(ctx, organization.DefaultSlug)
+	if err != nil {
+		return err
+	}
+
+	if o.ID != domain.TenantFromContext(ctx) {
+		return policy.ErrForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package management
+
+import (
+	"context"
+	"errors"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+)
+
+// can requires the role of the acting user in the organization to grant perm.
+func (s *Service) can(ctx context.Context, perm policy.Permission) error {
+	role, err := s.actorRole(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !policy.Allows(role, perm) {
+		return policy.ErrForbidden
+	}
+
+	return nil
+}
+
+// actorRole is the role of the acting user, empty for anonymous requests and
+// users of another organization.
+func (s *Service) actorRole(ctx context.Context) (string, error) {
+	actor := domain.ActorFromContext(ctx)
+	if actor == "" {
+		return "", nil
+	}
+
+	u, err := s.userRepository.Get(ctx, actor)
+	if err != nil {
+		if errors.Is(err, user.ErrNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return u.Role, nil
+}
+
+// authorizeUser keeps what belongs to a user, such as sessions and
+// notifications, to the user and those who manage users.
+func (s *Service) authorizeUser(ctx context.Context, userID string) error {
+	if actor := domain.ActorFromContext(ctx); actor != "" && actor == userID {
+		return nil
+	}
+
+	return s.can(ctx, policy.UsersManage)
+}
@@ -0,0 +1,221 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/projecttemplate"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SaveProjectAsTemplate stores the project's description and tasks, with
+// their dates relative to the project start, as a new template.
+func (s *Service) SaveProjectAsTemplate(ctx context.Context, projectID string, req projecttemplate.SaveRequest) (string, projecttemplate.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesManage); err != nil {
+		logger.Errorln("failed to authorize project template creation")
+		return "", projecttemplate.Response{}, err
+	}
+
+	data, err := s.projectBlueprint(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to capture project")
+		return "", projecttemplate.Response{}, err
+	}
+
+	data.ID = uuid.NewString()
+	data.Name = req.Name
+	data.CreatedAt = domain.OnlyDate(time.Now().Format(domain.DateLayout))
+	if req.Description != "" {
+		data.Description = req.Description
+	}
+
+	msg, obj, err := s.projectTemplateRepository.Create(ctx, data)
+	if err != nil {
+		logger.Errorln("failed to create project template")
+		return "", projecttemplate.Response{}, err
+	}
+
+	return msg, projecttemplate.ParseFromEntity(obj), nil
+}
+
+func (s *Service) GetProjectTemplate(ctx context.Context, id string) (projecttemplate.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesRead); err != nil {
+		logger.Errorln("failed to authorize project template read")
+		return projecttemplate.Response{}, err
+	}
+
+	data, err := s.projectTemplateRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get project template")
+		return projecttemplate.Response{}, err
+	}
+
+	return projecttemplate.ParseFromEntity(data), nil
+}
+
+func (s *Service) ListAllProjectTemplates(ctx context.Context) ([]projecttemplate.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesRead); err != nil {
+		logger.Errorln("failed to authorize listing project templates")
+		return nil, err
+	}
+
+	data, err := s.projectTemplateRepository.List(ctx)
+	if err != nil {
+		logger.Errorln("failed to list project templates")
+		return nil, err
+	}
+
+	return projecttemplate.ParseFromEntities(data), nil
+}
+
+func (s *Service) DeleteProjectTemplate(ctx context.Context, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesManage); err != nil {
+		logger.Errorln("failed to authorize project template deletion")
+		return err
+	}
+
+	err := s.projectTemplateRepository.Delete(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to delete project template")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) CreateProjectFromTemplate(ctx context.Context, id string, req projecttemplate.InstantiateRequest) (projecttemplate.ProjectResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesRead); err != nil {
+		logger.Errorln("failed to authorize project template read")
+		return projecttemplate.ProjectResponse{}, err
+	}
+
+	data, err := s.projectTemplateRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get project template")
+		return projecttemplate.ProjectResponse{}, err
+	}
+
+	return s.instantiateProject(ctx, data, req)
+}
+
+// CloneProject deep-copies the project and its tasks into a new project
+// starting at req.StartedAt.
+func (s *Service) CloneProject(ctx context.Context, projectID string, req projecttemplate.InstantiateRequest) (projecttemplate.ProjectResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesManage); err != nil {
+		logger.Errorln("failed to authorize project clone")
+		return projecttemplate.ProjectResponse{}, err
+	}
+
+	data, err := s.projectBlueprint(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to capture project")
+		return projecttemplate.ProjectResponse{}, err
+	}
+
+	return s.instantiateProject(ctx, data, req)
+}
+
+// projectBlueprint copies every task of the project, so like an export it
+// takes a maintainer of the project.
+func (s *Service) projectBlueprint(ctx context.Context, projectID string) (projecttemplate.Entity, error) {
+	p, err := s.projectRepository.Get(ctx, projectID)
+	if err != nil {
+		return projecttemplate.Entity{}, err
+	}
+
+	if err := s.authorize(ctx, projectID, member.RoleMaintainer); err != nil {
+		return projecttemplate.Entity{}, err
+	}
+
+	tasks, err := s.taskRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		return projecttemplate.Entity{}, err
+	}
+
+	return projecttemplate.FromProject(p, tasks)
+}
+
+// instantiateProject creates a project from tmpl with all task dates shifted
+// to the new start. The tasks are authored by the actor, or the manager when
+// there is none.
+func (s *Service) instantiateProject(ctx context.Context, tmpl projecttemplate.Entity, req projecttemplate.InstantiateRequest) (projecttemplate.ProjectResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if _, err := s.userRepository.Get(ctx, req.ManagerID); err != nil {
+		logger.Errorln("failed to get project manager")
+		return projecttemplate.ProjectResponse{}, err
+	}
+
+	start, _ := time.Parse(domain.DateLayout, req.StartedAt)
+
+	description := req.Description
+	if description == "" {
+		description = tmpl.Description
+	}
+
+	authorID := domain.ActorFromContext(ctx)
+	if authorID == "" {
+		authorID = req.ManagerID
+	}
+
+	var res projecttemplate.ProjectResponse
+
+	// a project missing some of its tasks would pass for a complete copy
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		_, p, err := s.CreateProject(ctx, project.Request{
+			Title:       req.Title,
+			Description: description,
+			ManagerID:   req.ManagerID,
+			StartedAt:   req.StartedAt,
+			FinishedAt:  tmpl.FinishedAt(start),
+		})
+		if err != nil {
+			logger.Errorln("failed to create project")
+			return err
+		}
+
+		res = projecttemplate.ProjectResponse{Project: p, Tasks: []task.Response{}}
+		for _, t := range tmpl.Instantiate(p.ID, start, uuid.NewString) {
+			t.AuthorID = authorID
+
+			_, obj, err := s.taskRepository.Create(ctx, t)
+			if err != nil {
+				logger.Errorln("failed to create project task")
+				return err
+			}
+
+			res.Tasks = append(res.Tasks, task.ParseFromEntity(obj))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return projecttemplate.ProjectResponse{}, err
+	}
+
+	s.refreshHealth(ctx, res.Project.ID)
+
+	if data, err := s.projectRepository.Get(ctx, res.Project.ID); err == nil {
+		res.Project = project.ParseFromEntity(data)
+	}
+
+	return res, nil
+}
@@ -2,9 +2,14 @@ package management
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
 	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
@@ -12,18 +17,70 @@ import (
 func (s *Service) CreateProject(ctx context.Context, req project.Request) (string, project.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.ProjectsCreate); err != nil {
+		logger.Errorln("failed to authorize project creation")
+		return "", project.Response{}, err
+	}
+
+	if req.ManagerID != "" {
+		if _, err := s.userRepository.Get(ctx, req.ManagerID); err != nil {
+			logger.Errorln("failed to get project manager")
+			return "", project.Response{}, err
+		}
+	}
+
+	if req.TeamID != "" {
+		if _, err := s.teamRepository.Get(ctx, req.TeamID); err != nil {
+			logger.Errorln("failed to get project team")
+			return "", project.Response{}, err
+		}
+	}
+
 	data := project.Entity{
-		ID:          uuid.NewString(),
-		Title:       req.Title,
-		Description: req.Description,
-		ManagerID:   req.ManagerID,
-		StartedAt:   domain.OnlyDate(req.StartedAt),
-		FinishedAt:  domain.OnlyDate(req.FinishedAt),
+		ID:             uuid.NewString(),
+		Title:          req.Title,
+		Description:    req.Description,
+		ManagerID:      req.ManagerID,
+		StartedAt:      domain.OnlyDate(req.StartedAt),
+		FinishedAt:     domain.OnlyDate(req.FinishedAt),
+		Status:         project.InitialStatus(req.StartedAt, time.Now()),
+		BudgetAmount:   req.BudgetAmount,
+		BudgetCurrency: req.BudgetCurrency,
+		TeamID:         req.TeamID,
 	}
 
-	msg, obj, err := s.projectRepository.Create(ctx, data)
+	// a project without tasks is healthy, which the column defaults match
+	h := project.Assess(data, project.Signals{}, time.Now())
+	data.Health, data.HealthScore, data.HealthReasons = h.Status, h.Score, h.Reasons
+
+	// the manager and whoever created the project start out as its owners
+	owners := []string{data.ManagerID}
+	if actor := domain.ActorFromContext(ctx); actor != "" && actor != data.ManagerID {
+		owners = append(owners, actor)
+	}
+
+	var msg string
+	var obj project.Entity
+
+	// a project nobody owns could not be managed, so both land or neither
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) (err error) {
+		msg, obj, err = s.projectRepository.Create(ctx, data)
+		if err != nil {
+			logger.Errorln("failed to create project")
+			return
+		}
+
+		for _, userID := range owners {
+			err = s.memberRepository.Add(ctx, member.Entity{ProjectID: data.ID, UserID: userID, Role: member.RoleOwner})
+			if err != nil {
+				logger.Errorln("failed to add project owner")
+				return
+			}
+		}
+
+		return
+	})
 	if err != nil {
-		logger.Errorln("failed to create project")
 		return "", project.Response{}, err
 	}
 
@@ -33,6 +90,11 @@ func (s *Service) CreateProject(ctx context.Context, req project.Request) (strin
 func (s *Service) GetProject(ctx context.Context, id string) (project.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize project read")
+		return project.Response{}, err
+	}
+
 	data, err := s.projectRepository.Get(ctx, id)
 	if err != nil {
 		logger.Errorln("failed to get project")
@@ -42,28 +104,120 @@ func (s *Service) GetProject(ctx context.Context, id string) (project.Response,
 	return project.ParseFromEntity(data), nil
 }
 
+// UpdateProject requires a maintainer, or an owner when the manager changes.
+// A new manager who is not yet a member joins as an owner.
 func (s *Service) UpdateProject(ctx context.Context, id string, req project.UpdateRequest) error {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.ProjectsWrite); err != nil {
+		logger.Errorln("failed to authorize project update")
+		return err
+	}
+
+	current, err := s.projectRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get project")
+		return err
+	}
+
+	if current.Status == project.StatusArchived {
+		logger.Errorln("failed to update archived project")
+		return project.ErrArchived
+	}
+
+	managerChanged := req.ManagerID != "" && req.ManagerID != current.ManagerID
+
+	min := member.RoleMaintainer
+	if managerChanged {
+		min = member.RoleOwner
+	}
+
+	if err := s.authorize(ctx, id, min); err != nil {
+		logger.Errorln("failed to authorize project update")
+		return err
+	}
+
+	if managerChanged {
+		if _, err := s.userRepository.Get(ctx, req.ManagerID); err != nil {
+			logger.Errorln("failed to get project manager")
+			return err
+		}
+	}
+
+	if req.TeamID != "" {
+		if _, err := s.teamRepository.Get(ctx, req.TeamID); err != nil {
+			logger.Errorln("failed to get project team")
+			return err
+		}
+	}
+
 	data := project.Entity{
-		Title:       req.Title,
-		Description: req.Description,
-		ManagerID:   req.ManagerID,
-		FinishedAt:  domain.OnlyDate(req.FinishedAt),
+		Title:          req.Title,
+		Description:    req.Description,
+		ManagerID:      req.ManagerID,
+		FinishedAt:     domain.OnlyDate(req.FinishedAt),
+		BudgetAmount:   req.BudgetAmount,
+		BudgetCurrency: req.BudgetCurrency,
+		TeamID:         req.TeamID,
 	}
 
-	err := s.projectRepository.Update(ctx, id, data)
+	// the new manager owns the project from the moment they manage it
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.projectRepository.Update(ctx, id, data); err != nil {
+			logger.Errorln("failed to update project")
+			return err
+		}
+
+		if !managerChanged {
+			return nil
+		}
+
+		// a refused insert would abort the transaction, so a manager who
+		// is a member already keeps their role
+		_, err := s.memberRepository.Get(ctx, id, req.ManagerID)
+		if !errors.Is(err, member.ErrNotFound) {
+			if err != nil {
+				logger.Errorln("failed to get project member")
+			}
+			return err
+		}
+
+		if err := s.memberRepository.Add(ctx, member.Entity{ProjectID: id, UserID: req.ManagerID, Role: member.RoleOwner}); err != nil {
+			logger.Errorln("failed to add project manager as owner")
+			return err
+		}
+
+		return nil
+	})
 	if err != nil {
-		logger.Errorln("failed to update project")
 		return err
 	}
 
+	if req.FinishedAt != "" {
+		s.refreshHealth(ctx, id)
+	}
+
 	return nil
 }
 
 func (s *Service) DeleteProject(ctx context.Context, id string) error {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.ProjectsWrite); err != nil {
+		logger.Errorln("failed to authorize project deletion")
+		return err
+	}
+
+	if _, err := s.projectRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get project")
+		return err
+	}
+
+	if err := s.authorize(ctx, id, member.RoleOwner); err != nil {
+		logger.Errorln("failed to authorize project deletion")
+		return err
+	}
+
 	err := s.projectRepository.Delete(ctx, id)
 	if err != nil {
 		logger.Errorln("failed to delete project")
@@ -73,10 +227,16 @@ func (s *Service) DeleteProject(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *Service) ListProjects(ctx context.Context) ([]project.Response, error) {
+// ListProjects leaves out archived projects unless includeArchived is set.
+func (s *Service) ListProjects(ctx context.Context, includeArchived bool) ([]project.Response, error) {
 	logger := logrus.WithContext(ctx)
 
-	data, err := s.projectRepository.List(ctx)
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize listing projects")
+		return nil, err
+	}
+
+	data, err := s.projectRepository.List(ctx, includeArchived)
 	if err != nil {
 		logger.Errorln("failed to list projects")
 		return nil, project.ErrNotFound
@@ -85,9 +245,50 @@ func (s *Service) ListProjects(ctx context.Context) ([]project.Response, error)
 	return project.ParseFromEntities(data), nil
 }
 
+// TransitionProject moves the project through its lifecycle, see project.Transition.
+func (s *Service) TransitionProject(ctx context.Context, id, action string) (project.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsWrite); err != nil {
+		logger.Errorln("failed to authorize project transition")
+		return project.Response{}, err
+	}
+
+	data, err := s.projectRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get project")
+		return project.Response{}, err
+	}
+
+	if err := s.authorize(ctx, id, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize project transition")
+		return project.Response{}, err
+	}
+
+	status, err := project.Transition(data.Status, action)
+	if err != nil {
+		logger.Errorln("failed to transition project")
+		return project.Response{}, err
+	}
+
+	if err := s.projectRepository.SetStatus(ctx, id, data.Status, status); err != nil {
+		logger.Errorln("failed to set project status")
+		return project.Response{}, err
+	}
+
+	data.Status = status
+
+	return project.ParseFromEntity(data), nil
+}
+
 func (s *Service) SearchProjects(ctx context.Context, filter, value string) ([]project.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize project search")
+		return nil, err
+	}
+
 	if value == "" || !project.IsValidFilter(filter) {
 		err := project.ErrSearch
 		logger.Errorln("failed to search tasks")
@@ -102,3 +303,66 @@ func (s *Service) SearchProjects(ctx context.Context, filter, value string) ([]p
 
 	return project.ParseFromEntities(data), nil
 }
+
+func (s *Service) GetProjectMetrics(ctx context.Context, id string) (task.ProjectMetricsResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize project metrics read")
+		return task.ProjectMetricsResponse{}, err
+	}
+
+	if _, err := s.projectRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get project")
+		return task.ProjectMetricsResponse{}, err
+	}
+
+	data, err := s.taskRepository.ProjectHistory(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get project history")
+		return task.ProjectMetricsResponse{}, err
+	}
+
+	histories := map[string][]task.StatusChange{}
+	for _, c := range data {
+		histories[c.TaskID] = append(histories[c.TaskID], c)
+	}
+
+	return task.ParseFromProjectMetrics(task.AggregateMetrics(histories, time.Now())), nil
+}
+
+func (s *Service) GetProjectStats(ctx context.Context, id string) (project.StatsResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize project stats read")
+		return project.StatsResponse{}, err
+	}
+
+	if _, err := s.projectRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get project")
+		return project.StatsResponse{}, err
+	}
+
+	data, err := s.projectRepository.Stats(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get project stats")
+		return project.StatsResponse{}, err
+	}
+
+	return project.ParseFromStats(data), nil
+}
+
+// checkWritable fails with project.ErrArchived for archived projects.
+func (s *Service) checkWritable(ctx context.Context, projectID string) error {
+	p, err := s.projectRepository.Get(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	if p.Status == project.StatusArchived {
+		return project.ErrArchived
+	}
+
+	return nil
+}
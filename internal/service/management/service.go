@@ -1,15 +1,50 @@
 package management
 
 import (
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/accesstoken"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/board"
+	"github.com/canyouhearthemusic/project-management/internal/domain/budget"
+	"github.com/canyouhearthemusic/project-management/internal/domain/calendar"
+	"github.com/canyouhearthemusic/project-management/internal/domain/epic"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/milestone"
+	"github.com/canyouhearthemusic/project-management/internal/domain/notification"
+	"github.com/canyouhearthemusic/project-management/internal/domain/organization"
 	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/projecttemplate"
+	"github.com/canyouhearthemusic/project-management/internal/domain/session"
+	"github.com/canyouhearthemusic/project-management/internal/domain/sprint"
 	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/team"
+	"github.com/canyouhearthemusic/project-management/internal/domain/template"
 	"github.com/canyouhearthemusic/project-management/internal/domain/user"
 )
 
 type Service struct {
-	userRepository    user.Repository
-	taskRepository    task.Repository
-	projectRepository project.Repository
+	userRepository            user.Repository
+	taskRepository            task.Repository
+	projectRepository         project.Repository
+	templateRepository        template.Repository
+	boardRepository           board.Repository
+	sprintRepository          sprint.Repository
+	milestoneRepository       milestone.Repository
+	epicRepository            epic.Repository
+	notificationRepository    notification.Repository
+	memberRepository          member.Repository
+	projectTemplateRepository projecttemplate.Repository
+	budgetRepository          budget.Repository
+	organizationRepository    organization.Repository
+	teamRepository            team.Repository
+	calendarRepository        calendar.Repository
+	authRepository            auth.Repository
+	accessTokenRepository     accesstoken.Repository
+	sessionRepository         session.Repository
+	transactor                domain.Transactor
+
+	signer *auth.Signer
+	sso    *auth.SSO
 }
 
 type Configuration func(s *Service) error
@@ -44,3 +79,132 @@ func WithProjectRepository(projectRepository project.Repository) Configuration {
 		return nil
 	}
 }
+
+func WithTemplateRepository(templateRepository template.Repository) Configuration {
+	return func(s *Service) error {
+		s.templateRepository = templateRepository
+		return nil
+	}
+}
+
+func WithBoardRepository(boardRepository board.Repository) Configuration {
+	return func(s *Service) error {
+		s.boardRepository = boardRepository
+		return nil
+	}
+}
+
+func WithSprintRepository(sprintRepository sprint.Repository) Configuration {
+	return func(s *Service) error {
+		s.sprintRepository = sprintRepository
+		return nil
+	}
+}
+
+func WithMilestoneRepository(milestoneRepository milestone.Repository) Configuration {
+	return func(s *Service) error {
+		s.milestoneRepository = milestoneRepository
+		return nil
+	}
+}
+
+func WithEpicRepository(epicRepository epic.Repository) Configuration {
+	return func(s *Service) error {
+		s.epicRepository = epicRepository
+		return nil
+	}
+}
+
+func WithNotificationRepository(notificationRepository notification.Repository) Configuration {
+	return func(s *Service) error {
+		s.notificationRepository = notificationRepository
+		return nil
+	}
+}
+
+func WithMemberRepository(memberRepository member.Repository) Configuration {
+	return func(s *Service) error {
+		s.memberRepository = memberRepository
+		return nil
+	}
+}
+
+func WithProjectTemplateRepository(projectTemplateRepository projecttemplate.Repository) Configuration {
+	return func(s *Service) error {
+		s.projectTemplateRepository = projectTemplateRepository
+		return nil
+	}
+}
+
+func WithBudgetRepository(budgetRepository budget.Repository) Configuration {
+	return func(s *Service) error {
+		s.budgetRepository = budgetRepository
+		return nil
+	}
+}
+
+func WithOrganizationRepository(organizationRepository organization.Repository) Configuration {
+	return func(s *Service) error {
+		s.organizationRepository = organizationRepository
+		return nil
+	}
+}
+
+func WithTeamRepository(teamRepository team.Repository) Configuration {
+	return func(s *Service) error {
+		s.teamRepository = teamRepository
+		return nil
+	}
+}
+
+func WithCalendarRepository(calendarRepository calendar.Repository) Configuration {
+	return func(s *Service) error {
+		s.calendarRepository = calendarRepository
+		return nil
+	}
+}
+
+func WithAuthRepository(authRepository auth.Repository) Configuration {
+	return func(s *Service) error {
+		s.authRepository = authRepository
+		return nil
+	}
+}
+
+func WithSigner(signer *auth.Signer) Configuration {
+	return func(s *Service) error {
+		s.signer = signer
+		return nil
+	}
+}
+
+func WithAccessTokenRepository(accessTokenRepository accesstoken.Repository) Configuration {
+	return func(s *Service) error {
+		s.accessTokenRepository = accessTokenRepository
+		return nil
+	}
+}
+
+// WithSSO enables signing in through an OpenID Connect provider.
+func WithSSO(sso *auth.SSO) Configuration {
+	return func(s *Service) error {
+		s.sso = sso
+		return nil
+	}
+}
+
+func WithSessionRepository(sessionRepository session.Repository) Configuration {
+	return func(s *Service) error {
+		s.sessionRepository = sessionRepository
+		return nil
+	}
+}
+
+// WithTransactor lets operations spanning several repositories run in one
+// transaction.
+func WithTransactor(transactor domain.Transactor) Configuration {
+	return func(s *Service) error {
+		s.transactor = transactor
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package management
+
+import (
+	"context"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/session"
+	"github.com/sirupsen/logrus"
+)
+
+// ListSessions returns the active sessions of a user, the one the request is
+// made in marked current.
+func (s *Service) ListSessions(ctx context.Context, userID string) ([]session.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizeUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	data, err := s.sessionRepository.List(ctx, userID)
+	if err != nil {
+		logger.Errorln("failed to list sessions")
+		return nil, err
+	}
+
+	return session.ParseFromEntities(data, domain.SessionFromContext(ctx)), nil
+}
+
+// RevokeSession signs a user out of one session.
+func (s *Service) RevokeSession(ctx context.Context, userID, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizeUser(ctx, userID); err != nil {
+		return err
+	}
+
+	err := s.sessionRepository.Delete(ctx, userID, id)
+	if err != nil {
+		logger.Errorln("failed to revoke session")
+		return err
+	}
+
+	return nil
+}
+
+// RevokeSessions signs a user out everywhere, as when they leave the
+// organization. Their personal access tokens are revoked separately.
+func (s *Service) RevokeSessions(ctx context.Context, userID string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizeUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.userRepository.Get(ctx, userID); err != nil {
+		logger.Errorln("failed to get user")
+		return err
+	}
+
+	err := s.sessionRepository.DeleteByUser(ctx, userID)
+	if err != nil {
+		logger.Errorln("failed to revoke sessions")
+		return err
+	}
+
+	return nil
+}
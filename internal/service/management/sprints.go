@@ -0,0 +1,326 @@
+package management
+
+import (
+	"context"
+	"errors"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/sprint"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *Service) CreateSprint(ctx context.Context, projectID string, req sprint.Request) (string, sprint.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.authorizeWrite(ctx, projectID, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize sprint creation")
+		return "", sprint.Response{}, err
+	}
+
+	data := sprint.Entity{
+		ID:        uuid.NewString(),
+		ProjectID: projectID,
+		Name:      req.Name,
+		Goal:      req.Goal,
+		StartDate: domain.OnlyDate(req.StartDate),
+		EndDate:   domain.OnlyDate(req.EndDate),
+		State:     sprint.StatePlanned,
+	}
+
+	msg, obj, err := s.sprintRepository.Create(ctx, data)
+	if err != nil {
+		logger.Errorln("failed to create sprint")
+		return "", sprint.Response{}, err
+	}
+
+	return msg, sprint.ParseFromEntity(obj), nil
+}
+
+func (s *Service) GetSprint(ctx context.Context, id string) (sprint.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize sprint read")
+		return sprint.Response{}, err
+	}
+
+	data, err := s.sprintRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get sprint")
+		return sprint.Response{}, err
+	}
+
+	return sprint.ParseFromEntity(data), nil
+}
+
+func (s *Service) ListProjectSprints(ctx context.Context, projectID string) ([]sprint.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize listing sprints")
+		return nil, err
+	}
+
+	if _, err := s.projectRepository.Get(ctx, projectID); err != nil {
+		logger.Errorln("failed to get project")
+		return nil, err
+	}
+
+	data, err := s.sprintRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to list sprints")
+		return nil, err
+	}
+
+	return sprint.ParseFromEntities(data), nil
+}
+
+func (s *Service) UpdateSprint(ctx context.Context, id string, req sprint.UpdateRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	current, err := s.sprintRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get sprint")
+		return err
+	}
+
+	if current.State == sprint.StateCompleted {
+		return sprint.ErrState
+	}
+
+	if err := s.authorizeWrite(ctx, current.ProjectID, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize sprint update")
+		return err
+	}
+
+	data := sprint.Entity{
+		Name:      req.Name,
+		Goal:      req.Goal,
+		StartDate: domain.OnlyDate(req.StartDate),
+		EndDate:   domain.OnlyDate(req.EndDate),
+	}
+
+	err = s.sprintRepository.Update(ctx, id, data)
+	if err != nil {
+		logger.Errorln("failed to update sprint")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSprint removes a sprint that has not been started yet; its tasks go
+// back to the backlog.
+func (s *Service) DeleteSprint(ctx context.Context, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	current, err := s.sprintRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get sprint")
+		return err
+	}
+
+	if current.State != sprint.StatePlanned {
+		return sprint.ErrState
+	}
+
+	if err := s.authorizeWrite(ctx, current.ProjectID, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize sprint deletion")
+		return err
+	}
+
+	err = s.sprintRepository.Delete(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to delete sprint")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) ListSprintTasks(ctx context.Context, id string) ([]sprint.ItemResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize listing sprint tasks")
+		return nil, err
+	}
+
+	if _, err := s.sprintRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get sprint")
+		return nil, err
+	}
+
+	data, err := s.sprintRepository.Items(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list sprint tasks")
+		return nil, err
+	}
+
+	return sprint.ParseFromItems(data), nil
+}
+
+// AddSprintTasks schedules tasks of the sprint's project. Tasks added to an
+// active sprint are tracked as scope added after the commitment.
+func (s *Service) AddSprintTasks(ctx context.Context, id string, req sprint.TasksRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	sp, err := s.sprintRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get sprint")
+		return err
+	}
+
+	if sp.State == sprint.StateCompleted {
+		return sprint.ErrState
+	}
+
+	if err := s.authorizeWrite(ctx, sp.ProjectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize sprint tasks change")
+		return err
+	}
+
+	for _, taskID := range req.TaskIDs {
+		t, err := s.taskRepository.Get(ctx, taskID)
+		if err != nil {
+			logger.Errorln("failed to get task")
+			return err
+		}
+
+		if t.ProjectID != sp.ProjectID {
+			return sprint.ErrTaskProject
+		}
+	}
+
+	// the tasks stay locked from the check to the commit, so that no other
+	// sprint takes them in between
+	return s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		for _, taskID := range req.TaskIDs {
+			if _, err := s.sprintRepository.OpenSprintOf(ctx, taskID); err == nil {
+				return sprint.ErrTaskInSprint
+			} else if !errors.Is(err, sprint.ErrNotFound) {
+				logger.Errorln("failed to get open sprint of task")
+				return err
+			}
+		}
+
+		for _, taskID := range req.TaskIDs {
+			if err := s.sprintRepository.AddTask(ctx, id, taskID, false); err != nil {
+				logger.Errorln("failed to add task to sprint")
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *Service) RemoveSprintTask(ctx context.Context, id, taskID string) error {
+	logger := logrus.WithContext(ctx)
+
+	sp, err := s.sprintRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get sprint")
+		return err
+	}
+
+	if sp.State == sprint.StateCompleted {
+		return sprint.ErrState
+	}
+
+	if err := s.authorizeWrite(ctx, sp.ProjectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize sprint tasks change")
+		return err
+	}
+
+	err = s.sprintRepository.RemoveTask(ctx, id, taskID)
+	if err != nil {
+		logger.Errorln("failed to remove task from sprint")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) StartSprint(ctx context.Context, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	sp, err := s.sprintRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get sprint")
+		return err
+	}
+
+	if err := s.authorizeWrite(ctx, sp.ProjectID, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize sprint start")
+		return err
+	}
+
+	err = s.sprintRepository.Start(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to start sprint")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) CompleteSprint(ctx context.Context, id string, req sprint.CompleteRequest) (sprint.SummaryResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	sp, err := s.sprintRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get sprint")
+		return sprint.SummaryResponse{}, err
+	}
+
+	if err := s.authorizeWrite(ctx, sp.ProjectID, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize sprint completion")
+		return sprint.SummaryResponse{}, err
+	}
+
+	if req.NextSprintID != "" {
+		next, err := s.sprintRepository.Get(ctx, req.NextSprintID)
+		if err != nil {
+			logger.Errorln("failed to get next sprint")
+			return sprint.SummaryResponse{}, err
+		}
+
+		if next.ID == sp.ID || next.ProjectID != sp.ProjectID || next.State == sprint.StateCompleted {
+			return sprint.SummaryResponse{}, sprint.ErrRollover
+		}
+	}
+
+	if err := s.sprintRepository.Complete(ctx, id, req.NextSprintID); err != nil {
+		logger.Errorln("failed to complete sprint")
+		return sprint.SummaryResponse{}, err
+	}
+
+	return s.GetSprintSummary(ctx, id)
+}
+
+func (s *Service) GetSprintSummary(ctx context.Context, id string) (sprint.SummaryResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize sprint summary read")
+		return sprint.SummaryResponse{}, err
+	}
+
+	sp, err := s.sprintRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get sprint")
+		return sprint.SummaryResponse{}, err
+	}
+
+	items, err := s.sprintRepository.Items(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list sprint tasks")
+		return sprint.SummaryResponse{}, err
+	}
+
+	return sprint.ParseFromSummary(sp, sprint.Summarize(items)), nil
+}
@@ -0,0 +1,156 @@
+package management
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/pkg/oidc"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SSOLoginURL starts a sign-in through the provider into the organization
+// of the request and returns where to send the user agent.
+func (s *Service) SSOLoginURL(ctx context.Context) (string, error) {
+	logger := logrus.WithContext(ctx)
+
+	if s.sso == nil {
+		return "", auth.ErrSSODisabled
+	}
+
+	state, err := oidc.NewVerifier()
+	if err != nil {
+		logger.Errorln("failed to generate state")
+		return "", err
+	}
+	nonce, err := oidc.NewVerifier()
+	if err != nil {
+		logger.Errorln("failed to generate nonce")
+		return "", err
+	}
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		logger.Errorln("failed to generate code verifier")
+		return "", err
+	}
+
+	url, err := s.sso.Client.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		logger.Errorln("failed to discover identity provider")
+		return "", err
+	}
+
+	data := auth.Login{
+		StateHash: auth.Hash(state),
+		Verifier:  verifier,
+		Nonce:     nonce,
+		ExpiresAt: time.Now().UTC().Add(auth.LoginTTL),
+	}
+
+	if err := s.authRepository.CreateLogin(ctx, data); err != nil {
+		logger.Errorln("failed to create login")
+		return "", err
+	}
+
+	return url, nil
+}
+
+// SSOCallback finishes a sign-in with the code the provider redirected back
+// with. The user is matched by email, or created, in the organization the
+// sign-in started in.
+func (s *Service) SSOCallback(ctx context.Context, state, code string) (auth.TokenResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if s.sso == nil {
+		return auth.TokenResponse{}, auth.ErrSSODisabled
+	}
+
+	login, err := s.authRepository.ConsumeLogin(ctx, auth.Hash(state))
+	if err != nil {
+		if !errors.Is(err, auth.ErrInvalidLogin) {
+			logger.Errorln("failed to consume login")
+		}
+		return auth.TokenResponse{}, err
+	}
+
+	if time.Now().UTC().After(login.ExpiresAt) {
+		return auth.TokenResponse{}, auth.ErrInvalidLogin
+	}
+
+	ctx = domain.WithTenant(ctx, login.OrganizationID)
+
+	claims, err := s.sso.Client.Exchange(ctx, code, login.Verifier, login.Nonce)
+	if err != nil {
+		logger.Errorln("failed to exchange authorization code:", err)
+		return auth.TokenResponse{}, err
+	}
+
+	userID, err := s.ssoUser(ctx, claims)
+	if err != nil {
+		return auth.TokenResponse{}, err
+	}
+
+	return s.startSession(ctx, userID)
+}
+
+// ssoUser links the claims to the user with their email, creating one when
+// there is none. The role follows the user's groups whenever one maps.
+func (s *Service) ssoUser(ctx context.Context, claims oidc.Claims) (string, error) {
+	logger := logrus.WithContext(ctx)
+
+	email := claims.String("email")
+	if email == "" {
+		return "", auth.ErrEmailUnverified
+	}
+	if verified, ok := claims.Bool("email_verified"); ok && !verified {
+		return "", auth.ErrEmailUnverified
+	}
+
+	role := s.sso.Role(claims.Strings(s.sso.GroupsClaim))
+
+	users, err := s.userRepository.Search(ctx, "email", email)
+	if err != nil && !errors.Is(err, user.ErrNotFound) {
+		logger.Errorln("failed to get user")
+		return "", err
+	}
+
+	if len(users) > 0 {
+		u := users[0]
+		if role != "" && role != u.Role {
+			if err := s.userRepository.Update(ctx, u.ID, user.Entity{Role: role}); err != nil {
+				logger.Errorln("failed to update user role")
+				return "", err
+			}
+		}
+
+		return u.ID, nil
+	}
+
+	if role == "" {
+		role = s.sso.DefaultRole
+	}
+
+	name := claims.String("name")
+	if name == "" {
+		name = email
+	}
+
+	data := user.Entity{
+		ID:               uuid.NewString(),
+		Name:             name,
+		Email:            email,
+		RegistrationDate: domain.OnlyDate(time.Now().Format(domain.DateLayout)),
+		Role:             role,
+	}
+
+	if _, _, err := s.userRepository.Create(ctx, data); err != nil {
+		logger.Errorln("failed to create user")
+		return "", err
+	}
+
+	return data.ID, nil
+}
@@ -0,0 +1,376 @@
+package management_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/session"
+	"github.com/canyouhearthemusic/project-management/internal/domain/user"
+	"github.com/canyouhearthemusic/project-management/internal/service/management"
+	"github.com/canyouhearthemusic/project-management/pkg/oidc"
+	"github.com/canyouhearthemusic/project-management/pkg/oidc/oidctest"
+)
+
+const (
+	ssoClientID    = "project-management"
+	ssoRedirectURL = "https://pm.example.com/api/v1/auth/oidc/callback"
+	ssoTenant      = "7f0c1e9a-3a51-4c8f-9d0e-2b6f4d1a8c35"
+)
+
+// TestSSOCallback signs users in through a test provider, from the login
+// URL to the tokens of the callback, and checks the role their groups give
+// them.
+func TestSSOCallback(t *testing.T) {
+	cases := []struct {
+		name   string
+		groups []string
+		// existing is the role of the user with the email before the
+		// sign-in, empty when there is none.
+		existing string
+		role     string
+	}{
+		{name: "new in mapped group", groups: []string{"pm-managers"}, role: user.RoleManager},
+		{name: "new in several mapped groups", groups: []string{"pm-managers", "pm-admins", "staff"}, role: user.RoleAdmin},
+		{name: "new in no mapped group", groups: []string{"staff"}, role: user.RoleDeveloper},
+		{name: "new without groups", role: user.RoleDeveloper},
+		{name: "existing promoted", groups: []string{"pm-admins"}, existing: user.RoleDeveloper, role: user.RoleAdmin},
+		{name: "existing demoted", groups: []string{"pm-developers"}, existing: user.RoleManager, role: user.RoleDeveloper},
+		{name: "existing in no mapped group", groups: []string{"staff"}, existing: user.RoleManager, role: user.RoleManager},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f := newSSOFixture(t)
+
+			if c.existing != "" {
+				f.users.Create(context.Background(), user.Entity{ID: "existing", Email: "ada@example.com", Role: c.existing})
+			}
+
+			state, code := f.signIn(t, map[string]any{"groups": c.groups})
+
+			res, err := f.service.SSOCallback(context.Background(), state, code)
+			if err != nil {
+				t.Fatalf("SSOCallback: %v", err)
+			}
+
+			claims, err := f.signer.Verify(res.AccessToken)
+			if err != nil {
+				t.Fatalf("access token: %v", err)
+			}
+			if claims.Organization != ssoTenant {
+				t.Errorf("organization = %q, want %q", claims.Organization, ssoTenant)
+			}
+
+			u, ok := f.users.byID(claims.Subject)
+			if !ok {
+				t.Fatalf("user %q of the access token does not exist", claims.Subject)
+			}
+			if c.existing != "" && u.ID != "existing" {
+				t.Errorf("signed in as new user %q, want the existing one", u.ID)
+			}
+			if u.Email != "ada@example.com" || u.Role != c.role {
+				t.Errorf("user = %s %s, want ada@example.com %s", u.Email, u.Role, c.role)
+			}
+
+			if _, ok := f.sessions.get(claims.Session); !ok {
+				t.Errorf("session %q of the access token does not exist", claims.Session)
+			}
+		})
+	}
+}
+
+// TestSSOCallbackRejected checks the callbacks that must not sign anybody in.
+func TestSSOCallbackRejected(t *testing.T) {
+	t.Run("replayed state", func(t *testing.T) {
+		f := newSSOFixture(t)
+		ctx := context.Background()
+
+		state, code := f.signIn(t, nil)
+		if _, err := f.service.SSOCallback(ctx, state, code); err != nil {
+			t.Fatalf("first SSOCallback: %v", err)
+		}
+
+		_, err := f.service.SSOCallback(ctx, state, code)
+		if !errors.Is(err, auth.ErrInvalidLogin) {
+			t.Fatalf("second SSOCallback error = %v, want %v", err, auth.ErrInvalidLogin)
+		}
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		f := newSSOFixture(t)
+
+		_, code := f.signIn(t, nil)
+
+		_, err := f.service.SSOCallback(context.Background(), "forged", code)
+		if !errors.Is(err, auth.ErrInvalidLogin) {
+			t.Fatalf("SSOCallback error = %v, want %v", err, auth.ErrInvalidLogin)
+		}
+	})
+
+	t.Run("code of another sign-in", func(t *testing.T) {
+		f := newSSOFixture(t)
+		ctx := context.Background()
+
+		state, _ := f.signIn(t, nil)
+		_, code := f.signIn(t, nil)
+
+		// the code was issued for the PKCE challenge of the other sign-in
+		_, err := f.service.SSOCallback(ctx, state, code)
+		if !errors.Is(err, oidc.ErrToken) {
+			t.Fatalf("SSOCallback error = %v, want %v", err, oidc.ErrToken)
+		}
+	})
+
+	t.Run("expired login", func(t *testing.T) {
+		f := newSSOFixture(t)
+
+		state, code := f.signIn(t, nil)
+		f.logins.expire()
+
+		_, err := f.service.SSOCallback(context.Background(), state, code)
+		if !errors.Is(err, auth.ErrInvalidLogin) {
+			t.Fatalf("SSOCallback error = %v, want %v", err, auth.ErrInvalidLogin)
+		}
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		f := newSSOFixture(t)
+
+		state, code := f.signIn(t, map[string]any{"email_verified": false})
+
+		_, err := f.service.SSOCallback(context.Background(), state, code)
+		if !errors.Is(err, auth.ErrEmailUnverified) {
+			t.Fatalf("SSOCallback error = %v, want %v", err, auth.ErrEmailUnverified)
+		}
+	})
+
+	t.Run("forged ID token", func(t *testing.T) {
+		f := newSSOFixture(t)
+
+		other := oidctest.NewIssuer(t, ssoClientID)
+		f.issuer.SigningKey = other.SigningKey
+
+		state, code := f.signIn(t, nil)
+
+		_, err := f.service.SSOCallback(context.Background(), state, code)
+		if !errors.Is(err, oidc.ErrVerify) {
+			t.Fatalf("SSOCallback error = %v, want %v", err, oidc.ErrVerify)
+		}
+		if len(f.users.all()) != 0 {
+			t.Errorf("users were created for a forged token")
+		}
+	})
+}
+
+type ssoFixture struct {
+	issuer   *oidctest.Issuer
+	signer   *auth.Signer
+	logins   *fakeAuthRepository
+	users    *fakeUserRepository
+	sessions *fakeSessionRepository
+	service  *management.Service
+}
+
+func newSSOFixture(t *testing.T) *ssoFixture {
+	issuer := oidctest.NewIssuer(t, ssoClientID)
+
+	f := &ssoFixture{
+		issuer:   issuer,
+		signer:   auth.NewSigner("secret", time.Minute, time.Hour),
+		logins:   &fakeAuthRepository{logins: map[string]auth.Login{}},
+		users:    &fakeUserRepository{},
+		sessions: &fakeSessionRepository{sessions: map[string]session.Session{}},
+	}
+
+	f.service = management.New(
+		management.WithAuthRepository(f.logins),
+		management.WithUserRepository(f.users),
+		management.WithSessionRepository(f.sessions),
+		management.WithSigner(f.signer),
+		management.WithSSO(&auth.SSO{
+			Client:      oidc.New(issuer.URL, ssoClientID, "secret", ssoRedirectURL, []string{"openid", "email", "groups"}),
+			GroupsClaim: "groups",
+			Roles: map[string]string{
+				"pm-admins":     user.RoleAdmin,
+				"pm-managers":   user.RoleManager,
+				"pm-developers": user.RoleDeveloper,
+			},
+			DefaultRole: user.RoleDeveloper,
+		}),
+	)
+
+	return f
+}
+
+// signIn starts a sign-in into the test organization and signs Ada in at the
+// provider with the claims, returning the state and code of the callback.
+func (f *ssoFixture) signIn(t *testing.T, claims map[string]any) (state, code string) {
+	t.Helper()
+
+	authURL, err := f.service.SSOLoginURL(domain.WithTenant(context.Background(), ssoTenant))
+	if err != nil {
+		t.Fatalf("SSOLoginURL: %v", err)
+	}
+
+	data := map[string]any{"sub": "ada", "email": "ada@example.com", "email_verified": true, "name": "Ada"}
+	for k, v := range claims {
+		data[k] = v
+	}
+
+	back, err := f.issuer.Authorize(authURL, data)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+
+	return back.Query().Get("state"), back.Query().Get("code")
+}
+
+// The fakes keep what the sign-in stores in memory. Methods the sign-in
+// does not use are left to the embedded interfaces and panic.
+
+type fakeAuthRepository struct {
+	auth.Repository
+
+	mu     sync.Mutex
+	logins map[string]auth.Login
+	tokens []auth.RefreshToken
+}
+
+func (r *fakeAuthRepository) Create(ctx context.Context, t auth.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t.OrganizationID = domain.TenantFromContext(ctx)
+	r.tokens = append(r.tokens, t)
+
+	return nil
+}
+
+func (r *fakeAuthRepository) CreateLogin(ctx context.Context, l auth.Login) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l.OrganizationID = domain.TenantFromContext(ctx)
+	r.logins[l.StateHash] = l
+
+	return nil
+}
+
+func (r *fakeAuthRepository) ConsumeLogin(ctx context.Context, stateHash string) (auth.Login, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, ok := r.logins[stateHash]
+	if !ok {
+		return auth.Login{}, auth.ErrInvalidLogin
+	}
+	delete(r.logins, stateHash)
+
+	return l, nil
+}
+
+// expire lets the logins waiting for their callback run out.
+func (r *fakeAuthRepository) expire() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, l := range r.logins {
+		l.ExpiresAt = time.Now().UTC().Add(-time.Second)
+		r.logins[hash] = l
+	}
+}
+
+type fakeUserRepository struct {
+	user.Repository
+
+	mu    sync.Mutex
+	users []user.Entity
+}
+
+func (r *fakeUserRepository) Search(ctx context.Context, filter, value string) ([]user.Entity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := []user.Entity{}
+	for _, u := range r.users {
+		if filter == "email" && u.Email == value {
+			res = append(res, u)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, user.ErrNotFound
+	}
+
+	return res, nil
+}
+
+func (r *fakeUserRepository) Create(ctx context.Context, u user.Entity) (string, user.Entity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users = append(r.users, u)
+
+	return "user created", u, nil
+}
+
+// Update only changes the role, the one field the sign-in updates.
+func (r *fakeUserRepository) Update(ctx context.Context, id string, u user.Entity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.users {
+		if r.users[i].ID == id {
+			r.users[i].Role = u.Role
+			return nil
+		}
+	}
+
+	return user.ErrNotFound
+}
+
+func (r *fakeUserRepository) byID(id string) (user.Entity, bool) {
+	for _, u := range r.all() {
+		if u.ID == id {
+			return u, true
+		}
+	}
+
+	return user.Entity{}, false
+}
+
+func (r *fakeUserRepository) all() []user.Entity {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]user.Entity{}, r.users...)
+}
+
+type fakeSessionRepository struct {
+	session.Repository
+
+	mu       sync.Mutex
+	sessions map[string]session.Session
+}
+
+func (r *fakeSessionRepository) Create(ctx context.Context, s session.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[s.ID] = s
+
+	return nil
+}
+
+func (r *fakeSessionRepository) get(id string) (session.Session, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.sessions[id]
+	return s, ok
+}
@@ -2,26 +2,90 @@ package management
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
 	"github.com/canyouhearthemusic/project-management/internal/domain/task"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// CreateTask adds the warning of a column over its non-strict WIP limit to
+// the message.
 func (s *Service) CreateTask(ctx context.Context, req task.Request) (string, task.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.TasksWrite); err != nil {
+		logger.Errorln("failed to authorize task creation")
+		return "", task.Response{}, err
+	}
+
+	if err := s.authorizeWrite(ctx, req.ProjectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize task creation")
+		return "", task.Response{}, err
+	}
+
+	if req.AuthorID != "" {
+		if _, err := s.userRepository.Get(ctx, req.AuthorID); err != nil {
+			logger.Errorln("failed to get task author")
+			return "", task.Response{}, err
+		}
+	}
+
+	if req.MilestoneID != "" {
+		if err := s.checkTaskMilestone(ctx, req.ProjectID, req.MilestoneID); err != nil {
+			logger.Errorln("failed to check task milestone")
+			return "", task.Response{}, err
+		}
+	}
+
+	if req.EpicID != "" {
+		if _, err := s.epicRepository.Get(ctx, req.EpicID); err != nil {
+			logger.Errorln("failed to get epic")
+			return "", task.Response{}, err
+		}
+	}
+
+	if req.TeamID != "" {
+		if _, err := s.teamRepository.Get(ctx, req.TeamID); err != nil {
+			logger.Errorln("failed to get task team")
+			return "", task.Response{}, err
+		}
+	}
+
 	data := task.Entity{
-		ID:          uuid.NewString(),
-		Title:       req.Title,
-		Description: req.Description,
-		Priority:    req.Priority,
-		Status:      req.Status,
-		CreatedAt:   domain.OnlyDate(req.CreatedAt),
-		DoneAt:      domain.OnlyDate(req.DoneAt),
-		AuthorID:    req.AuthorID,
-		ProjectID:   req.ProjectID,
+		ID:             uuid.NewString(),
+		Title:          req.Title,
+		Description:    req.Description,
+		Priority:       req.Priority,
+		Status:         req.Status,
+		CreatedAt:      domain.OnlyDate(req.CreatedAt),
+		DoneAt:         domain.OnlyDate(req.DoneAt),
+		AuthorID:       req.AuthorID,
+		ProjectID:      req.ProjectID,
+		MilestoneID:    req.MilestoneID,
+		EpicID:         req.EpicID,
+		TeamID:         req.TeamID,
+		StartAt:        domain.OnlyDate(req.StartAt),
+		PredecessorIDs: req.PredecessorIDs,
+	}
+
+	if data.StartAt == "" {
+		data.StartAt = data.CreatedAt
+	}
+
+	if err := s.checkPredecessors(ctx, data.ProjectID, data.ID, req.PredecessorIDs); err != nil {
+		logger.Errorln("failed to check task predecessors")
+		return "", task.Response{}, err
+	}
+
+	warning, err := s.checkTaskColumn(ctx, task.Entity{}, data.ProjectID, data.Status)
+	if err != nil {
+		logger.Errorln("failed to check wip limit")
+		return "", task.Response{}, err
 	}
 
 	msg, obj, err := s.taskRepository.Create(ctx, data)
@@ -30,12 +94,19 @@ func (s *Service) CreateTask(ctx context.Context, req task.Request) (string, tas
 		return "", task.Response{}, err
 	}
 
-	return msg, task.ParseFromEntity(obj), nil
+	s.refreshHealth(ctx, data.ProjectID)
+
+	return withWarning(msg, warning), task.ParseFromEntity(obj), nil
 }
 
 func (s *Service) GetTask(ctx context.Context, id string) (task.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.TasksRead); err != nil {
+		logger.Errorln("failed to authorize task read")
+		return task.Response{}, err
+	}
+
 	data, err := s.taskRepository.Get(ctx, id)
 	if err != nil {
 		logger.Errorln("failed to get task")
@@ -45,43 +116,266 @@ func (s *Service) GetTask(ctx context.Context, id string) (task.Response, error)
 	return task.ParseFromEntity(data), nil
 }
 
-func (s *Service) UpdateTask(ctx context.Context, id string, req task.UpdateRequest) error {
+// UpdateTask returns a warning when the task is moved into a board column
+// that is over its non-strict WIP limit.
+func (s *Service) UpdateTask(ctx context.Context, id string, req task.UpdateRequest) (string, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.TasksWrite); err != nil {
+		logger.Errorln("failed to authorize task update")
+		return "", err
+	}
+
+	if req.ProjectID != "" {
+		if _, err := s.projectRepository.Get(ctx, req.ProjectID); err != nil {
+			logger.Errorln("failed to get target project")
+			return "", err
+		}
+	}
+
+	if req.EpicID != "" {
+		if _, err := s.epicRepository.Get(ctx, req.EpicID); err != nil {
+			logger.Errorln("failed to get epic")
+			return "", err
+		}
+	}
+
+	if req.TeamID != "" {
+		if _, err := s.teamRepository.Get(ctx, req.TeamID); err != nil {
+			logger.Errorln("failed to get task team")
+			return "", err
+		}
+	}
+
+	if req.AuthorID != "" {
+		if _, err := s.userRepository.Get(ctx, req.AuthorID); err != nil {
+			logger.Errorln("failed to get task author")
+			return "", err
+		}
+	}
+
+	current, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return "", err
+	}
+
+	projectID := current.ProjectID
+	if req.ProjectID != "" {
+		projectID = req.ProjectID
+	}
+
+	if err := s.authorizeTaskProjects(ctx, current.ProjectID, projectID); err != nil {
+		logger.Errorln("failed to authorize task update")
+		return "", err
+	}
+
+	if req.MilestoneID != "" {
+		if err := s.checkTaskMilestone(ctx, projectID, req.MilestoneID); err != nil {
+			logger.Errorln("failed to check task milestone")
+			return "", err
+		}
+	}
+
+	start, due := current.StartAt, current.DoneAt
+	if req.StartAt != "" {
+		start = domain.OnlyDate(req.StartAt)
+	}
+	if req.DoneAt != "" {
+		due = domain.OnlyDate(req.DoneAt)
+	}
+	if start > due {
+		logger.Errorln("failed to check task schedule")
+		return "", task.ErrSchedule
+	}
+
+	predecessors := req.PredecessorIDs
+	if predecessors == nil && projectID != current.ProjectID {
+		// dependencies do not cross projects
+		predecessors = []string{}
+	}
+
+	if err := s.checkPredecessors(ctx, projectID, id, predecessors); err != nil {
+		logger.Errorln("failed to check task predecessors")
+		return "", err
+	}
+
+	status := current.Status
+	if req.Status != "" {
+		status = req.Status
+	}
+
+	warning, err := s.checkTaskColumn(ctx, current, projectID, status)
+	if err != nil {
+		logger.Errorln("failed to check wip limit")
+		return "", err
+	}
+
 	data := task.Entity{
-		Title:       req.Title,
-		Description: req.Description,
-		Priority:    req.Priority,
-		Status:      req.Status,
-		DoneAt:      domain.OnlyDate(req.DoneAt),
-		AuthorID:    req.AuthorID,
-		ProjectID:   req.AuthorID,
+		Title:          req.Title,
+		Description:    req.Description,
+		Priority:       req.Priority,
+		Status:         req.Status,
+		DoneAt:         domain.OnlyDate(req.DoneAt),
+		AuthorID:       req.AuthorID,
+		ProjectID:      req.ProjectID,
+		MilestoneID:    req.MilestoneID,
+		EpicID:         req.EpicID,
+		TeamID:         req.TeamID,
+		StartAt:        domain.OnlyDate(req.StartAt),
+		PredecessorIDs: predecessors,
 	}
 
-	err := s.taskRepository.Update(ctx, id, data)
+	err = s.taskRepository.Update(ctx, id, data)
 	if err != nil {
 		logger.Errorln("failed to update task")
-		return err
+		return "", err
 	}
 
-	return nil
+	s.refreshHealth(ctx, current.ProjectID, projectID)
+
+	return warning, nil
+}
+
+// MoveTask returns a warning when the task lands in a board column that is
+// over its non-strict WIP limit.
+func (s *Service) MoveTask(ctx context.Context, id string, req task.MoveRequest) (string, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TasksWrite); err != nil {
+		logger.Errorln("failed to authorize task move")
+		return "", err
+	}
+
+	if _, err := s.projectRepository.Get(ctx, req.ProjectID); err != nil {
+		logger.Errorln("failed to get target project")
+		return "", err
+	}
+
+	current, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return "", err
+	}
+
+	if err := s.authorizeTaskProjects(ctx, current.ProjectID, req.ProjectID); err != nil {
+		logger.Errorln("failed to authorize task move")
+		return "", err
+	}
+
+	warning, err := s.checkTaskColumn(ctx, current, req.ProjectID, current.Status)
+	if err != nil {
+		logger.Errorln("failed to check wip limit")
+		return "", err
+	}
+
+	data := task.Entity{ProjectID: req.ProjectID}
+	if req.ProjectID != current.ProjectID {
+		data.PredecessorIDs = []string{}
+	}
+
+	err = s.taskRepository.Update(ctx, id, data)
+	if err != nil {
+		logger.Errorln("failed to move task")
+		return "", err
+	}
+
+	s.refreshHealth(ctx, current.ProjectID, req.ProjectID)
+
+	return warning, nil
+}
+
+// CloneTask adds the warning of a column over its non-strict WIP limit to
+// the message.
+func (s *Service) CloneTask(ctx context.Context, id string, req task.CloneRequest) (string, task.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TasksWrite); err != nil {
+		logger.Errorln("failed to authorize task clone")
+		return "", task.Response{}, err
+	}
+
+	data, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return "", task.Response{}, err
+	}
+
+	if req.ProjectID != "" {
+		if _, err := s.projectRepository.Get(ctx, req.ProjectID); err != nil {
+			logger.Errorln("failed to get target project")
+			return "", task.Response{}, err
+		}
+		if data.ProjectID != req.ProjectID {
+			data.MilestoneID = ""
+		}
+		data.ProjectID = req.ProjectID
+	}
+
+	if err := s.authorizeWrite(ctx, data.ProjectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize task clone")
+		return "", task.Response{}, err
+	}
+
+	warning, err := s.checkTaskColumn(ctx, task.Entity{}, data.ProjectID, data.Status)
+	if err != nil {
+		logger.Errorln("failed to check wip limit")
+		return "", task.Response{}, err
+	}
+
+	data.ID = uuid.NewString()
+	data.CreatedAt = domain.OnlyDate(time.Now().Format(domain.DateLayout))
+	data.PredecessorIDs = nil
+
+	msg, obj, err := s.taskRepository.Create(ctx, data)
+	if err != nil {
+		logger.Errorln("failed to clone task")
+		return "", task.Response{}, err
+	}
+
+	s.refreshHealth(ctx, data.ProjectID)
+
+	return withWarning(msg, warning), task.ParseFromEntity(obj), nil
 }
 
 func (s *Service) DeleteTask(ctx context.Context, id string) error {
 	logger := logrus.WithContext(ctx)
 
-	err := s.taskRepository.Delete(ctx, id)
+	if err := s.can(ctx, policy.TasksWrite); err != nil {
+		logger.Errorln("failed to authorize task deletion")
+		return err
+	}
+
+	current, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return err
+	}
+
+	if err := s.authorizeWrite(ctx, current.ProjectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize task deletion")
+		return err
+	}
+
+	err = s.taskRepository.Delete(ctx, id)
 	if err != nil {
 		logger.Errorln("failed to delete task")
 		return err
 	}
 
+	s.refreshHealth(ctx, current.ProjectID)
+
 	return nil
 }
 
 func (s *Service) ListTasks(ctx context.Context) ([]task.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.TasksRead); err != nil {
+		logger.Errorln("failed to authorize listing tasks")
+		return nil, err
+	}
+
 	data, err := s.taskRepository.List(ctx)
 	if err != nil {
 		logger.Errorln("failed to get tasks")
@@ -94,6 +388,11 @@ func (s *Service) ListTasks(ctx context.Context) ([]task.Response, error) {
 func (s *Service) SearchTasks(ctx context.Context, filter, value string) ([]task.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.TasksRead); err != nil {
+		logger.Errorln("failed to authorize task search")
+		return nil, err
+	}
+
 	if value == "" || !task.IsValidFilter(filter) {
 		err := task.ErrSearch
 		logger.Errorln("failed to search tasks")
@@ -108,3 +407,200 @@ func (s *Service) SearchTasks(ctx context.Context, filter, value string) ([]task
 
 	return task.ParseFromEntities(data), nil
 }
+
+// BulkTasks applies one operation to a set of tasks selected by IDs or by a
+// search filter. Either every task is changed or none is.
+func (s *Service) BulkTasks(ctx context.Context, req task.BulkRequest) (task.BulkResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TasksWrite); err != nil {
+		logger.Errorln("failed to authorize bulk operation")
+		return task.BulkResponse{}, err
+	}
+
+	// every project touched, including a move target, needs a contributor
+	// role and every column tasks land in room for them; the tasks are
+	// checked as selected in the transaction, unknown IDs are left to the
+	// per-item report
+	projects := map[string]bool{}
+	var warnings []string
+	check := func(tasks []task.Entity) error {
+		type column struct{ projectID, status string }
+		entering := map[column]int{}
+
+		if req.Operation == task.BulkProject {
+			projects[req.Value] = true
+		}
+		for _, t := range tasks {
+			projects[t.ProjectID] = true
+
+			switch {
+			case req.Operation == task.BulkStatus && t.Status != req.Value:
+				entering[column{t.ProjectID, req.Value}]++
+			case req.Operation == task.BulkProject && t.ProjectID != req.Value:
+				entering[column{req.Value, t.Status}]++
+			}
+		}
+
+		for projectID := range projects {
+			if err := s.authorizeWrite(ctx, projectID, member.RoleContributor); err != nil {
+				logger.Errorln("failed to authorize bulk operation")
+				return err
+			}
+		}
+
+		for c, n := range entering {
+			warning, err := s.checkWIPLimit(ctx, c.projectID, c.status, n)
+			if err != nil {
+				logger.Errorln("failed to check wip limit")
+				return err
+			}
+			if warning != "" {
+				warnings = append(warnings, warning)
+			}
+		}
+
+		return nil
+	}
+
+	var (
+		results []task.BulkResult
+		err     error
+	)
+
+	sel := req.Selection()
+
+	switch req.Operation {
+	case task.BulkDelete:
+		results, err = s.taskRepository.DeleteMany(ctx, sel, check)
+	case task.BulkProject:
+		if _, err := s.projectRepository.Get(ctx, req.Value); err != nil {
+			logger.Errorln("failed to get target project")
+			return task.BulkResponse{}, err
+		}
+		results, err = s.taskRepository.UpdateMany(ctx, sel, task.Entity{ProjectID: req.Value, PredecessorIDs: []string{}}, check)
+	case task.BulkAssignee:
+		if _, err := s.userRepository.Get(ctx, req.Value); err != nil {
+			logger.Errorln("failed to get assignee")
+			return task.BulkResponse{}, err
+		}
+		results, err = s.taskRepository.UpdateMany(ctx, sel, task.Entity{AuthorID: req.Value}, check)
+	case task.BulkStatus:
+		results, err = s.taskRepository.UpdateMany(ctx, sel, task.Entity{Status: req.Value}, check)
+	case task.BulkPriority:
+		results, err = s.taskRepository.UpdateMany(ctx, sel, task.Entity{Priority: req.Value}, check)
+	default:
+		return task.BulkResponse{}, task.ErrBadRequest
+	}
+
+	if err != nil {
+		logger.Errorln("failed to apply bulk operation")
+		return task.ParseFromBulkResults(req.Operation, results), err
+	}
+
+	for projectID := range projects {
+		s.refreshHealth(ctx, projectID)
+	}
+
+	res := task.ParseFromBulkResults(req.Operation, results)
+	sort.Strings(warnings)
+	res.Warnings = warnings
+
+	return res, nil
+}
+
+func (s *Service) GetTaskHistory(ctx context.Context, id string) ([]task.StatusChangeResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TasksRead); err != nil {
+		logger.Errorln("failed to authorize task history read")
+		return nil, err
+	}
+
+	if _, err := s.taskRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get task")
+		return nil, err
+	}
+
+	data, err := s.taskRepository.History(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get task history")
+		return nil, err
+	}
+
+	return task.ParseFromHistory(data), nil
+}
+
+func (s *Service) GetTaskMetrics(ctx context.Context, id string) (task.MetricsResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TasksRead); err != nil {
+		logger.Errorln("failed to authorize task metrics read")
+		return task.MetricsResponse{}, err
+	}
+
+	if _, err := s.taskRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get task")
+		return task.MetricsResponse{}, err
+	}
+
+	data, err := s.taskRepository.History(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get task history")
+		return task.MetricsResponse{}, err
+	}
+
+	return task.ParseFromMetrics(task.ComputeMetrics(data, time.Now())), nil
+}
+
+// authorizeTaskProjects requires a contributor role in the task's current
+// project and, when it differs, in the project it is moved to.
+func (s *Service) authorizeTaskProjects(ctx context.Context, from, to string) error {
+	if err := s.authorizeWrite(ctx, from, member.RoleContributor); err != nil {
+		return err
+	}
+
+	if to != from {
+		return s.authorizeWrite(ctx, to, member.RoleContributor)
+	}
+
+	return nil
+}
+
+// checkPredecessors verifies that ids are other tasks of the project and
+// that making them the predecessors of taskID keeps the dependencies acyclic.
+func (s *Service) checkPredecessors(ctx context.Context, projectID, taskID string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tasks, err := s.taskRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	inProject := map[string]bool{}
+	for _, t := range tasks {
+		inProject[t.ID] = true
+	}
+
+	for _, id := range ids {
+		if id == taskID || !inProject[id] {
+			return task.ErrPredecessor
+		}
+	}
+
+	found := false
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			tasks[i].PredecessorIDs = ids
+			found = true
+		}
+	}
+	if !found {
+		tasks = append(tasks, task.Entity{ID: taskID, PredecessorIDs: ids})
+	}
+
+	_, err = task.TopoSort(tasks)
+	return err
+}
@@ -0,0 +1,428 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/member"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/project"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/team"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *Service) CreateTeam(ctx context.Context, req team.Request) (team.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsManage); err != nil {
+		logger.Errorln("failed to authorize team creation")
+		return team.Response{}, err
+	}
+
+	for _, userID := range append([]string{req.LeadID}, req.MemberIDs...) {
+		if _, err := s.userRepository.Get(ctx, userID); err != nil {
+			logger.Errorln("failed to get team member")
+			return team.Response{}, err
+		}
+	}
+
+	data := team.Entity{
+		ID:        uuid.NewString(),
+		Name:      req.Name,
+		LeadID:    req.LeadID,
+		CreatedAt: domain.OnlyDate(time.Now().Format(domain.DateLayout)),
+	}
+
+	if err := s.teamRepository.Create(ctx, data, req.MemberIDs); err != nil {
+		logger.Errorln("failed to create team")
+		return team.Response{}, err
+	}
+
+	return s.GetTeam(ctx, data.ID)
+}
+
+func (s *Service) GetTeam(ctx context.Context, id string) (team.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsRead); err != nil {
+		logger.Errorln("failed to authorize team read")
+		return team.Response{}, err
+	}
+
+	data, err := s.teamRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get team")
+		return team.Response{}, err
+	}
+
+	members, err := s.teamRepository.ListMembers(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list team members")
+		return team.Response{}, err
+	}
+
+	return team.ParseFromEntity(data, members), nil
+}
+
+func (s *Service) ListTeams(ctx context.Context) ([]team.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsRead); err != nil {
+		logger.Errorln("failed to authorize listing teams")
+		return nil, err
+	}
+
+	data, err := s.teamRepository.List(ctx)
+	if err != nil {
+		logger.Errorln("failed to list teams")
+		return nil, err
+	}
+
+	res := []team.Response{}
+	for _, t := range data {
+		members, err := s.teamRepository.ListMembers(ctx, t.ID)
+		if err != nil {
+			logger.Errorln("failed to list team members")
+			return nil, err
+		}
+
+		res = append(res, team.ParseFromEntity(t, members))
+	}
+
+	return res, nil
+}
+
+func (s *Service) UpdateTeam(ctx context.Context, id string, req team.UpdateRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsManage); err != nil {
+		logger.Errorln("failed to authorize team update")
+		return err
+	}
+
+	if req.LeadID != "" {
+		if _, err := s.userRepository.Get(ctx, req.LeadID); err != nil {
+			logger.Errorln("failed to get team lead")
+			return err
+		}
+	}
+
+	data := team.Entity{
+		Name:   req.Name,
+		LeadID: req.LeadID,
+	}
+
+	err := s.teamRepository.Update(ctx, id, data)
+	if err != nil {
+		logger.Errorln("failed to update team")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteTeam leaves the team's projects and tasks unassigned.
+func (s *Service) DeleteTeam(ctx context.Context, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsManage); err != nil {
+		logger.Errorln("failed to authorize team deletion")
+		return err
+	}
+
+	err := s.teamRepository.Delete(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to delete team")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) ListTeamMembers(ctx context.Context, id string) ([]team.MemberResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsRead); err != nil {
+		logger.Errorln("failed to authorize listing team members")
+		return nil, err
+	}
+
+	if _, err := s.teamRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get team")
+		return nil, err
+	}
+
+	data, err := s.teamRepository.ListMembers(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list team members")
+		return nil, err
+	}
+
+	return team.ParseFromMembers(data), nil
+}
+
+func (s *Service) AddTeamMember(ctx context.Context, id string, req team.MemberRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsManage); err != nil {
+		logger.Errorln("failed to authorize team member addition")
+		return err
+	}
+
+	if _, err := s.teamRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get team")
+		return err
+	}
+
+	if _, err := s.userRepository.Get(ctx, req.UserID); err != nil {
+		logger.Errorln("failed to get user")
+		return err
+	}
+
+	err := s.teamRepository.AddMember(ctx, id, req.UserID)
+	if err != nil {
+		logger.Errorln("failed to add team member")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) RemoveTeamMember(ctx context.Context, id, userID string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsManage); err != nil {
+		logger.Errorln("failed to authorize team member removal")
+		return err
+	}
+
+	t, err := s.teamRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get team")
+		return err
+	}
+
+	if t.LeadID == userID {
+		return team.ErrLead
+	}
+
+	err = s.teamRepository.RemoveMember(ctx, id, userID)
+	if err != nil {
+		logger.Errorln("failed to remove team member")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) ListTeamProjects(ctx context.Context, id string) ([]project.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsRead); err != nil {
+		logger.Errorln("failed to authorize listing team projects")
+		return nil, err
+	}
+
+	if _, err := s.teamRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get team")
+		return nil, err
+	}
+
+	data, err := s.projectRepository.ListByTeam(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list team projects")
+		return nil, err
+	}
+
+	return project.ParseFromEntities(data), nil
+}
+
+// AddTeamProjects takes a maintainer role in each of the projects.
+func (s *Service) AddTeamProjects(ctx context.Context, id string, req team.ProjectsRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsManage); err != nil {
+		logger.Errorln("failed to authorize team assignment")
+		return err
+	}
+
+	if _, err := s.teamRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get team")
+		return err
+	}
+
+	for _, projectID := range req.ProjectIDs {
+		if _, err := s.projectRepository.Get(ctx, projectID); err != nil {
+			logger.Errorln("failed to get project")
+			return err
+		}
+
+		if err := s.authorizeWrite(ctx, projectID, member.RoleMaintainer); err != nil {
+			logger.Errorln("failed to authorize team assignment")
+			return err
+		}
+
+		if err := s.projectRepository.SetTeam(ctx, projectID, id); err != nil {
+			logger.Errorln("failed to assign project to team")
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) RemoveTeamProject(ctx context.Context, id, projectID string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsManage); err != nil {
+		logger.Errorln("failed to authorize team assignment")
+		return err
+	}
+
+	p, err := s.projectRepository.Get(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to get project")
+		return err
+	}
+
+	if p.TeamID != id {
+		return team.ErrNotAssigned
+	}
+
+	if err := s.authorizeWrite(ctx, projectID, member.RoleMaintainer); err != nil {
+		logger.Errorln("failed to authorize team assignment")
+		return err
+	}
+
+	err = s.projectRepository.SetTeam(ctx, projectID, "")
+	if err != nil {
+		logger.Errorln("failed to unassign project from team")
+		return err
+	}
+
+	return nil
+}
+
+// ListTeamTasks includes the tasks of the team's projects that are not
+// assigned to another team.
+func (s *Service) ListTeamTasks(ctx context.Context, id string) ([]task.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsRead); err != nil {
+		logger.Errorln("failed to authorize listing team tasks")
+		return nil, err
+	}
+
+	if _, err := s.teamRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get team")
+		return nil, err
+	}
+
+	data, err := s.taskRepository.ListByTeam(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list team tasks")
+		return nil, err
+	}
+
+	return task.ParseFromEntities(data), nil
+}
+
+func (s *Service) AddTeamTasks(ctx context.Context, id string, req team.TasksRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsManage); err != nil {
+		logger.Errorln("failed to authorize team assignment")
+		return err
+	}
+
+	if _, err := s.teamRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get team")
+		return err
+	}
+
+	for _, taskID := range req.TaskIDs {
+		t, err := s.taskRepository.Get(ctx, taskID)
+		if err != nil {
+			logger.Errorln("failed to get task")
+			return err
+		}
+
+		if err := s.authorizeWrite(ctx, t.ProjectID, member.RoleContributor); err != nil {
+			logger.Errorln("failed to authorize team assignment")
+			return err
+		}
+
+		if err := s.taskRepository.SetTeam(ctx, taskID, id); err != nil {
+			logger.Errorln("failed to assign task to team")
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) RemoveTeamTask(ctx context.Context, id, taskID string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsManage); err != nil {
+		logger.Errorln("failed to authorize team assignment")
+		return err
+	}
+
+	t, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		logger.Errorln("failed to get task")
+		return err
+	}
+
+	if t.TeamID != id {
+		return team.ErrNotAssigned
+	}
+
+	if err := s.authorizeWrite(ctx, t.ProjectID, member.RoleContributor); err != nil {
+		logger.Errorln("failed to authorize team assignment")
+		return err
+	}
+
+	err = s.taskRepository.SetTeam(ctx, taskID, "")
+	if err != nil {
+		logger.Errorln("failed to unassign task from team")
+		return err
+	}
+
+	return nil
+}
+
+// GetTeamWorkload spreads the tasks of ListTeamTasks over the team members.
+func (s *Service) GetTeamWorkload(ctx context.Context, id string) (team.WorkloadResponse, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TeamsRead); err != nil {
+		logger.Errorln("failed to authorize team workload read")
+		return team.WorkloadResponse{}, err
+	}
+
+	if _, err := s.teamRepository.Get(ctx, id); err != nil {
+		logger.Errorln("failed to get team")
+		return team.WorkloadResponse{}, err
+	}
+
+	members, err := s.teamRepository.ListMembers(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list team members")
+		return team.WorkloadResponse{}, err
+	}
+
+	tasks, err := s.taskRepository.ListByTeam(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list team tasks")
+		return team.WorkloadResponse{}, err
+	}
+
+	today := domain.OnlyDate(time.Now().Format(domain.DateLayout))
+
+	return team.ParseFromWorkload(id, team.Balance(members, tasks, today)), nil
+}
@@ -0,0 +1,230 @@
+package management
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/task"
+	"github.com/canyouhearthemusic/project-management/internal/domain/template"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *Service) CreateTemplate(ctx context.Context, req template.Request) (string, template.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesManage); err != nil {
+		logger.Errorln("failed to authorize template creation")
+		return "", template.Response{}, err
+	}
+
+	if req.ProjectID != "" {
+		if _, err := s.projectRepository.Get(ctx, req.ProjectID); err != nil {
+			logger.Errorln("failed to get template project")
+			return "", template.Response{}, err
+		}
+	}
+
+	if req.AuthorID != "" {
+		if _, err := s.userRepository.Get(ctx, req.AuthorID); err != nil {
+			logger.Errorln("failed to get template author")
+			return "", template.Response{}, err
+		}
+	}
+
+	data := template.Entity{
+		ID:          uuid.NewString(),
+		Title:       req.Title,
+		Description: req.Description,
+		Priority:    req.Priority,
+		Status:      req.Status,
+		AuthorID:    req.AuthorID,
+		ProjectID:   req.ProjectID,
+	}
+
+	msg, obj, err := s.templateRepository.Create(ctx, data)
+	if err != nil {
+		logger.Errorln("failed to create template")
+		return "", template.Response{}, err
+	}
+
+	return msg, template.ParseFromEntity(obj), nil
+}
+
+func (s *Service) GetTemplate(ctx context.Context, id string) (template.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesRead); err != nil {
+		logger.Errorln("failed to authorize template read")
+		return template.Response{}, err
+	}
+
+	data, err := s.templateRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get template")
+		return template.Response{}, err
+	}
+
+	return template.ParseFromEntity(data), nil
+}
+
+func (s *Service) UpdateTemplate(ctx context.Context, id string, req template.UpdateRequest) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesManage); err != nil {
+		logger.Errorln("failed to authorize template update")
+		return err
+	}
+
+	if req.AuthorID != "" {
+		if _, err := s.userRepository.Get(ctx, req.AuthorID); err != nil {
+			logger.Errorln("failed to get template author")
+			return err
+		}
+	}
+
+	data := template.Entity{
+		Title:       req.Title,
+		Description: req.Description,
+		Priority:    req.Priority,
+		Status:      req.Status,
+		AuthorID:    req.AuthorID,
+	}
+
+	err := s.templateRepository.Update(ctx, id, data)
+	if err != nil {
+		logger.Errorln("failed to update template")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) DeleteTemplate(ctx context.Context, id string) error {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesManage); err != nil {
+		logger.Errorln("failed to authorize template deletion")
+		return err
+	}
+
+	err := s.templateRepository.Delete(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to delete template")
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) ListTemplates(ctx context.Context) ([]template.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesRead); err != nil {
+		logger.Errorln("failed to authorize listing templates")
+		return nil, err
+	}
+
+	data, err := s.templateRepository.List(ctx)
+	if err != nil {
+		logger.Errorln("failed to list templates")
+		return nil, err
+	}
+
+	return template.ParseFromEntities(data), nil
+}
+
+func (s *Service) ListProjectTemplates(ctx context.Context, projectID string) ([]template.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesRead); err != nil {
+		logger.Errorln("failed to authorize listing project templates")
+		return nil, err
+	}
+
+	data, err := s.templateRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to list project templates")
+		return nil, err
+	}
+
+	return template.ParseFromEntities(data), nil
+}
+
+// CreateTaskFromTemplate instantiates the template as a new task of the project.
+// Besides the caller's variables, {{project}} and {{date}} are always available.
+func (s *Service) CreateTaskFromTemplate(ctx context.Context, projectID, templateID string, req template.InstantiateRequest) (string, task.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.TemplatesRead); err != nil {
+		logger.Errorln("failed to authorize template read")
+		return "", task.Response{}, err
+	}
+
+	p, err := s.projectRepository.Get(ctx, projectID)
+	if err != nil {
+		logger.Errorln("failed to get project")
+		return "", task.Response{}, err
+	}
+
+	tmpl, err := s.templateRepository.Get(ctx, templateID)
+	if err != nil {
+		logger.Errorln("failed to get template")
+		return "", task.Response{}, err
+	}
+
+	if !tmpl.AvailableIn(projectID) {
+		logger.Errorln("template does not belong to project")
+		return "", task.Response{}, template.ErrScope
+	}
+
+	today := time.Now().Format(domain.DateLayout)
+
+	vars := map[string]string{
+		"project": p.Title,
+		"date":    today,
+	}
+	for k, v := range req.Variables {
+		vars[k] = v
+	}
+
+	title, missingTitle := template.Render(tmpl.Title, vars)
+	description, missingDescription := template.Render(tmpl.Description, vars)
+	if missing := append(missingTitle, missingDescription...); len(missing) > 0 {
+		logger.Errorln("failed to render template")
+		return "", task.Response{}, fmt.Errorf("%w: %s", template.ErrVariables, strings.Join(missing, ", "))
+	}
+
+	authorID := tmpl.AuthorID
+	if req.AuthorID != "" {
+		authorID = req.AuthorID
+	}
+
+	taskReq := task.Request{
+		Title:       title,
+		Description: description,
+		Priority:    tmpl.Priority,
+		Status:      tmpl.Status,
+		AuthorID:    authorID,
+		ProjectID:   projectID,
+		CreatedAt:   today,
+		DoneAt:      req.DoneAt,
+	}
+
+	if errs := taskReq.Validate(); errs != nil {
+		joined := make([]error, len(errs))
+		for i, err := range errs {
+			joined[i] = err
+		}
+
+		logger.Errorln("rendered template is not a valid task")
+		return "", task.Response{}, errors.Join(joined...)
+	}
+
+	return s.CreateTask(ctx, taskReq)
+}
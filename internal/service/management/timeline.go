@@ -0,0 +1,38 @@
+package management
+
+import (
+	"context"
+
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
+	"github.com/canyouhearthemusic/project-management/internal/domain/timeline"
+	"github.com/sirupsen/logrus"
+)
+
+func (s *Service) GetProjectTimeline(ctx context.Context, id string) (timeline.Response, error) {
+	logger := logrus.WithContext(ctx)
+
+	if err := s.can(ctx, policy.ProjectsRead); err != nil {
+		logger.Errorln("failed to authorize project timeline read")
+		return timeline.Response{}, err
+	}
+
+	p, err := s.projectRepository.Get(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to get project")
+		return timeline.Response{}, err
+	}
+
+	tasks, err := s.taskRepository.ListByProject(ctx, id)
+	if err != nil {
+		logger.Errorln("failed to list project tasks")
+		return timeline.Response{}, err
+	}
+
+	data, err := timeline.Build(p, tasks)
+	if err != nil {
+		logger.Errorln("failed to build project timeline")
+		return timeline.Response{}, err
+	}
+
+	return timeline.ParseFromTimeline(data), nil
+}
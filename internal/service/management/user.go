@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/canyouhearthemusic/project-management/internal/domain"
+	"github.com/canyouhearthemusic/project-management/internal/domain/auth"
+	"github.com/canyouhearthemusic/project-management/internal/domain/policy"
 	"github.com/canyouhearthemusic/project-management/internal/domain/user"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -12,6 +14,11 @@ import (
 func (s *Service) ListUsers(ctx context.Context) ([]user.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.UsersRead); err != nil {
+		logger.Errorln("failed to authorize listing users")
+		return nil, err
+	}
+
 	data, err := s.userRepository.List(ctx)
 	if err != nil {
 		logger.Errorln("failed to get users")
@@ -24,6 +31,11 @@ func (s *Service) ListUsers(ctx context.Context) ([]user.Response, error) {
 func (s *Service) CreateUser(ctx context.Context, req user.Request) (string, user.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.UsersManage); err != nil {
+		logger.Errorln("failed to authorize user creation")
+		return "", user.Response{}, err
+	}
+
 	data := user.Entity{
 		ID:               uuid.NewString(),
 		Name:             req.Name,
@@ -32,6 +44,15 @@ func (s *Service) CreateUser(ctx context.Context, req user.Request) (string, use
 		Role:             req.Role,
 	}
 
+	if req.Password != "" {
+		hash, err := auth.HashPassword(req.Password)
+		if err != nil {
+			logger.Errorln("failed to hash password")
+			return "", user.Response{}, err
+		}
+		data.PasswordHash = hash
+	}
+
 	msg, obj, err := s.userRepository.Create(ctx, data)
 	if err != nil {
 		logger.Errorln("failed to create user")
@@ -44,6 +65,11 @@ func (s *Service) CreateUser(ctx context.Context, req user.Request) (string, use
 func (s *Service) GetUser(ctx context.Context, id string) (user.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.UsersRead); err != nil {
+		logger.Errorln("failed to authorize user read")
+		return user.Response{}, err
+	}
+
 	data, err := s.userRepository.Get(ctx, id)
 	if err != nil {
 		logger.Errorln("failed to get user")
@@ -53,27 +79,63 @@ func (s *Service) GetUser(ctx context.Context, id string) (user.Response, error)
 	return user.ParseFromEntity(data), nil
 }
 
+// UpdateUser lets users edit their own profile and admins everyone's. Roles
+// are only ever granted by admins.
 func (s *Service) UpdateUser(ctx context.Context, id string, req user.UpdateRequest) error {
 	logger := logrus.WithContext(ctx)
 
+	if req.Role != "" || domain.ActorFromContext(ctx) != id {
+		if err := s.can(ctx, policy.UsersManage); err != nil {
+			logger.Errorln("failed to authorize user update")
+			return err
+		}
+	}
+
 	data := user.Entity{
 		Name:  req.Name,
 		Email: req.Email,
 		Role:  req.Role,
 	}
 
+	if req.Password != "" {
+		// not with a personal access token, which could then outlive it
+		if domain.ScopesFromContext(ctx) != nil {
+			return user.ErrForbidden
+		}
+
+		hash, err := auth.HashPassword(req.Password)
+		if err != nil {
+			logger.Errorln("failed to hash password")
+			return err
+		}
+		data.PasswordHash = hash
+	}
+
 	err := s.userRepository.Update(ctx, id, data)
 	if err != nil {
 		logger.Errorln("failed to update user")
 		return err
 	}
 
+	// a new password signs the user out everywhere
+	if req.Password != "" {
+		if err := s.sessionRepository.DeleteByUser(ctx, id); err != nil {
+			logger.Errorln("failed to revoke sessions")
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (s *Service) DeleteUser(ctx context.Context, id string) error {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.UsersManage); err != nil {
+		logger.Errorln("failed to authorize user deletion")
+		return err
+	}
+
 	err := s.userRepository.Delete(ctx, id)
 	if err != nil {
 		logger.Errorln("failed to delete user")
@@ -86,6 +148,11 @@ func (s *Service) DeleteUser(ctx context.Context, id string) error {
 func (s *Service) SearchUsers(ctx context.Context, filter, value string) ([]user.Response, error) {
 	logger := logrus.WithContext(ctx)
 
+	if err := s.can(ctx, policy.UsersRead); err != nil {
+		logger.Errorln("failed to authorize user search")
+		return nil, err
+	}
+
 	if value == "" || !user.IsValidFilter(filter) {
 		err := user.ErrSearch
 		logger.Errorln("failed to search users")
@@ -100,3 +167,34 @@ func (s *Service) SearchUsers(ctx context.Context, filter, value string) ([]user
 
 	return user.ParseFromEntities(data), nil
 }
+
+// SetPassword gives the user of the organization with the email a password
+// and signs them out everywhere. It is for operators on the command line,
+// such as to let the users of an install from before passwords sign in.
+func (s *Service) SetPassword(ctx context.Context, email, password string) error {
+	logger := logrus.WithContext(ctx)
+
+	users, err := s.userRepository.Search(ctx, "email", email)
+	if err != nil {
+		logger.Errorln("failed to get user")
+		return err
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		logger.Errorln("failed to hash password")
+		return err
+	}
+
+	if err := s.userRepository.Update(ctx, users[0].ID, user.Entity{PasswordHash: hash}); err != nil {
+		logger.Errorln("failed to update user")
+		return err
+	}
+
+	if err := s.sessionRepository.DeleteByUser(ctx, users[0].ID); err != nil {
+		logger.Errorln("failed to revoke sessions")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS task_templates (
+	id VARCHAR(255) PRIMARY KEY,
+	title VARCHAR(255) NOT NULL,
+	description VARCHAR(255) NOT NULL,
+	priority VARCHAR CHECK (priority IN ('low', 'medium', 'high')),
+	status VARCHAR CHECK (status IN ('active', 'in_progress', 'done')),
+	author_id VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+	project_id VARCHAR(255) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS task_templates_project_idx ON task_templates(project_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS task_status_history;
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_check;
+UPDATE tasks SET status = 'in_proccess' WHERE status = 'in_progress';
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check CHECK (status IN ('active', 'in_proccess', 'done'));
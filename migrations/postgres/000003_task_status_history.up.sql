@@ -0,0 +1,17 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_check;
+UPDATE tasks SET status = 'in_progress' WHERE status = 'in_proccess';
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check CHECK (status IN ('active', 'in_progress', 'done'));
+
+CREATE TABLE IF NOT EXISTS task_status_history (
+	id BIGSERIAL PRIMARY KEY,
+	task_id VARCHAR(255) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	from_status VARCHAR,
+	to_status VARCHAR NOT NULL,
+	actor_id VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+	changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS task_status_history_task_idx ON task_status_history(task_id, changed_at);
+
+INSERT INTO task_status_history (task_id, from_status, to_status, changed_at)
+SELECT id, NULL, status, created_at FROM tasks;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS project_wip_limits (
+	project_id VARCHAR(255) NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+	status VARCHAR NOT NULL CHECK (status IN ('active', 'in_progress', 'done')),
+	wip_limit INTEGER NOT NULL CHECK (wip_limit > 0),
+	strict BOOLEAN NOT NULL DEFAULT FALSE,
+	PRIMARY KEY (project_id, status)
+);
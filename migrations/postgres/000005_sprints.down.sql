@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sprint_tasks;
+DROP TABLE IF EXISTS sprints;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS sprints (
+	id VARCHAR(255) PRIMARY KEY,
+	project_id VARCHAR(255) NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+	name VARCHAR(255) NOT NULL,
+	goal VARCHAR(255) NOT NULL DEFAULT '',
+	start_date DATE NOT NULL,
+	end_date DATE NOT NULL,
+	state VARCHAR NOT NULL DEFAULT 'planned' CHECK (state IN ('planned', 'active', 'completed')),
+	started_at TIMESTAMPTZ,
+	completed_at TIMESTAMPTZ,
+	CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS sprints_project_idx ON sprints(project_id);
+CREATE UNIQUE INDEX IF NOT EXISTS sprints_one_active_idx ON sprints(project_id) WHERE state = 'active';
+
+CREATE TABLE IF NOT EXISTS sprint_tasks (
+	sprint_id VARCHAR(255) NOT NULL REFERENCES sprints(id) ON DELETE CASCADE,
+	task_id VARCHAR(255) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	committed BOOLEAN NOT NULL DEFAULT FALSE,
+	completed BOOLEAN,
+	PRIMARY KEY (sprint_id, task_id)
+);
+
+CREATE INDEX IF NOT EXISTS sprint_tasks_task_idx ON sprint_tasks(task_id);
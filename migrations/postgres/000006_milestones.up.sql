@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS milestones (
+	id VARCHAR(255) PRIMARY KEY,
+	project_id VARCHAR(255) NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+	title VARCHAR(255) NOT NULL,
+	description VARCHAR(255) NOT NULL DEFAULT '',
+	target_date DATE NOT NULL,
+	created_at DATE NOT NULL DEFAULT CURRENT_DATE
+);
+
+CREATE INDEX IF NOT EXISTS milestones_project_idx ON milestones(project_id);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS milestone_id VARCHAR(255) REFERENCES milestones(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_milestone_idx ON tasks(milestone_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS epics (
+	id VARCHAR(255) PRIMARY KEY,
+	title VARCHAR(255) NOT NULL,
+	description VARCHAR(255) NOT NULL DEFAULT '',
+	owner_id VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+	created_at DATE NOT NULL DEFAULT CURRENT_DATE
+);
+
+CREATE INDEX IF NOT EXISTS epics_owner_idx ON epics(owner_id);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS epic_id VARCHAR(255) REFERENCES epics(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_epic_idx ON tasks(epic_id);
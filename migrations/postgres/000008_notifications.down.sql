@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS tasks_done_at_idx;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS notifications (
+	id VARCHAR(255) PRIMARY KEY,
+	user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	task_id VARCHAR(255) REFERENCES tasks(id) ON DELETE CASCADE,
+	kind VARCHAR NOT NULL CHECK (kind IN ('reminder', 'overdue', 'escalation')),
+	message VARCHAR NOT NULL,
+	dedup_key VARCHAR(255) NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	read_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS notifications_user_idx ON notifications(user_id, created_at);
+CREATE INDEX IF NOT EXISTS tasks_done_at_idx ON tasks(done_at);
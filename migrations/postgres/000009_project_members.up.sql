@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS project_members (
+	project_id VARCHAR(255) NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+	user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	role VARCHAR NOT NULL CHECK (role IN ('owner', 'maintainer', 'contributor', 'viewer')),
+	added_at DATE NOT NULL DEFAULT CURRENT_DATE,
+	PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS project_members_user_idx ON project_members(user_id);
+
+-- keep existing projects editable: managers own them, task authors contribute
+INSERT INTO project_members (project_id, user_id, role)
+SELECT id, manager_id, 'owner' FROM projects WHERE manager_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO project_members (project_id, user_id, role)
+SELECT DISTINCT project_id, author_id, 'contributor' FROM tasks
+WHERE project_id IS NOT NULL AND author_id IS NOT NULL
+ON CONFLICT DO NOTHING;
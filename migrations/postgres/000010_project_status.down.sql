@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS projects_status_idx;
+ALTER TABLE projects DROP COLUMN IF EXISTS status;
@@ -0,0 +1,6 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS status VARCHAR NOT NULL DEFAULT 'active'
+	CHECK (status IN ('planned', 'active', 'on_hold', 'completed', 'archived'));
+
+UPDATE projects SET status = 'planned' WHERE started_at > CURRENT_DATE;
+
+CREATE INDEX IF NOT EXISTS projects_status_idx ON projects(status);
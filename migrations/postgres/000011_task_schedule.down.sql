@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS predecessor_ids;
+ALTER TABLE tasks DROP COLUMN IF EXISTS start_at;
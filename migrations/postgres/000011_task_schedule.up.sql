@@ -0,0 +1,5 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS start_at DATE;
+UPDATE tasks SET start_at = LEAST(created_at, done_at) WHERE start_at IS NULL;
+ALTER TABLE tasks ALTER COLUMN start_at SET NOT NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS predecessor_ids VARCHAR(255)[] NOT NULL DEFAULT '{}';
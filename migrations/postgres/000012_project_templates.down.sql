@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS project_template_tasks;
+DROP TABLE IF EXISTS project_templates;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS project_templates (
+	id VARCHAR(255) PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	description VARCHAR(255) NOT NULL,
+	duration_days INTEGER NOT NULL CHECK (duration_days >= 0),
+	source_project_id VARCHAR(255) REFERENCES projects(id) ON DELETE SET NULL,
+	created_at DATE NOT NULL
+);
+
+-- task dates are kept as day offsets from the project start, predecessors
+-- as positions within the template
+CREATE TABLE IF NOT EXISTS project_template_tasks (
+	template_id VARCHAR(255) NOT NULL REFERENCES project_templates(id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	title VARCHAR(255) NOT NULL,
+	description VARCHAR(255) NOT NULL,
+	priority VARCHAR CHECK (priority IN ('low', 'medium', 'high')),
+	status VARCHAR CHECK (status IN ('active', 'in_progress', 'done')),
+	start_offset INTEGER NOT NULL,
+	due_offset INTEGER NOT NULL,
+	predecessors INTEGER[] NOT NULL DEFAULT '{}',
+	PRIMARY KEY (template_id, position)
+);
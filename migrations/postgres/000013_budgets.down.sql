@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS expenses;
+DROP TABLE IF EXISTS time_entries;
+DROP TABLE IF EXISTS user_rates;
+
+ALTER TABLE projects DROP COLUMN IF EXISTS budget_currency;
+ALTER TABLE projects DROP COLUMN IF EXISTS budget_amount;
@@ -0,0 +1,34 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS budget_amount NUMERIC(14, 2) NOT NULL DEFAULT 0 CHECK (budget_amount >= 0);
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS budget_currency VARCHAR(3) NOT NULL DEFAULT '';
+
+-- rates are never updated in place; the one in effect on a day is the
+-- latest with effective_from on or before it
+CREATE TABLE IF NOT EXISTS user_rates (
+	id VARCHAR(255) PRIMARY KEY,
+	user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	hourly_rate NUMERIC(10, 2) NOT NULL CHECK (hourly_rate >= 0),
+	currency VARCHAR(3) NOT NULL,
+	effective_from DATE NOT NULL,
+	UNIQUE (user_id, effective_from)
+);
+
+CREATE TABLE IF NOT EXISTS time_entries (
+	id VARCHAR(255) PRIMARY KEY,
+	task_id VARCHAR(255) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	hours NUMERIC(6, 2) NOT NULL CHECK (hours > 0),
+	spent_on DATE NOT NULL,
+	note VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS expenses (
+	id VARCHAR(255) PRIMARY KEY,
+	task_id VARCHAR(255) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	amount NUMERIC(14, 2) NOT NULL CHECK (amount > 0),
+	currency VARCHAR(3) NOT NULL,
+	spent_on DATE NOT NULL,
+	description VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS time_entries_task_idx ON time_entries(task_id);
+CREATE INDEX IF NOT EXISTS expenses_task_idx ON expenses(task_id);
@@ -0,0 +1,19 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_organization_email_key;
+
+DO $$
+DECLARE
+	t TEXT;
+BEGIN
+	FOREACH t IN ARRAY ARRAY[
+		'users', 'projects', 'tasks', 'task_templates', 'task_status_history',
+		'project_wip_limits', 'sprints', 'sprint_tasks', 'milestones', 'epics',
+		'notifications', 'project_members', 'project_templates', 'project_template_tasks',
+		'user_rates', 'time_entries', 'expenses'
+	] LOOP
+		EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS organization_id', t);
+	END LOOP;
+END $$;
+
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS organizations (
+	id VARCHAR(255) PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	slug VARCHAR(63) NOT NULL UNIQUE,
+	created_at DATE NOT NULL DEFAULT CURRENT_DATE
+);
+
+-- everything that existed so far belongs to the default organization, which
+-- also serves requests that do not name one
+INSERT INTO organizations (id, name, slug)
+VALUES (gen_random_uuid()::varchar, 'Default', 'default')
+ON CONFLICT (slug) DO NOTHING;
+
+DO $$
+DECLARE
+	t TEXT;
+	org VARCHAR(255);
+BEGIN
+	SELECT id INTO org FROM organizations WHERE slug = 'default';
+
+	FOREACH t IN ARRAY ARRAY[
+		'users', 'projects', 'tasks', 'task_templates', 'task_status_history',
+		'project_wip_limits', 'sprints', 'sprint_tasks', 'milestones', 'epics',
+		'notifications', 'project_members', 'project_templates', 'project_template_tasks',
+		'user_rates', 'time_entries', 'expenses'
+	] LOOP
+		EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS organization_id VARCHAR(255) REFERENCES organizations(id) ON DELETE CASCADE', t);
+		EXECUTE format('UPDATE %I SET organization_id = $1 WHERE organization_id IS NULL', t) USING org;
+		EXECUTE format('ALTER TABLE %I ALTER COLUMN organization_id SET NOT NULL', t);
+		EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON %I(organization_id)', t || '_organization_idx', t);
+	END LOOP;
+END $$;
+
+-- emails only need to be unique within an organization
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_organization_email_key UNIQUE (organization_id, email);
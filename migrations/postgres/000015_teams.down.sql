@@ -0,0 +1,4 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS team_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS team_id;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
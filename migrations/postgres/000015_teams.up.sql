@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS teams (
+	id VARCHAR(255) PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	lead_id VARCHAR(255) REFERENCES users(id) ON DELETE SET NULL,
+	created_at DATE NOT NULL DEFAULT CURRENT_DATE,
+	organization_id VARCHAR(255) NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+	UNIQUE (organization_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS teams_lead_idx ON teams(lead_id);
+
+CREATE TABLE IF NOT EXISTS team_members (
+	team_id VARCHAR(255) NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+	user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	added_at DATE NOT NULL DEFAULT CURRENT_DATE,
+	organization_id VARCHAR(255) NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+	PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS team_members_user_idx ON team_members(user_id);
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS team_id VARCHAR(255) REFERENCES teams(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS team_id VARCHAR(255) REFERENCES teams(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS projects_team_idx ON projects(team_id);
+CREATE INDEX IF NOT EXISTS tasks_team_idx ON tasks(team_id);
@@ -0,0 +1,3 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS health_reasons;
+ALTER TABLE projects DROP COLUMN IF EXISTS health_score;
+ALTER TABLE projects DROP COLUMN IF EXISTS health;
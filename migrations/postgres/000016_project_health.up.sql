@@ -0,0 +1,5 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS health VARCHAR(5) NOT NULL DEFAULT 'green' CHECK (health IN ('green', 'amber', 'red'));
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS health_score SMALLINT NOT NULL DEFAULT 100;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS health_reasons VARCHAR(255)[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS projects_health_idx ON projects(health);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+	id VARCHAR(255) PRIMARY KEY,
+	user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	name VARCHAR(255) NOT NULL,
+	token_hash VARCHAR(64) NOT NULL UNIQUE,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	organization_id VARCHAR(255) NOT NULL REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS calendar_tokens_user_idx ON calendar_tokens(user_id);
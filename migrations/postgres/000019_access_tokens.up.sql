@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS access_tokens (
+	id VARCHAR(255) PRIMARY KEY,
+	user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	name VARCHAR(255) NOT NULL,
+	scopes VARCHAR(255)[] NOT NULL DEFAULT '{}',
+	token_hash VARCHAR(64) NOT NULL UNIQUE,
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	organization_id VARCHAR(255) NOT NULL REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS access_tokens_user_idx ON access_tokens(user_id);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS oidc_logins (
+	state_hash VARCHAR(64) PRIMARY KEY,
+	verifier VARCHAR(255) NOT NULL,
+	nonce VARCHAR(255) NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	organization_id VARCHAR(255) NOT NULL REFERENCES organizations(id) ON DELETE CASCADE
+);
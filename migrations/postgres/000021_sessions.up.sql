@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS sessions (
+	id VARCHAR(255) PRIMARY KEY,
+	user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	user_agent VARCHAR(255) NOT NULL DEFAULT '',
+	ip VARCHAR(64) NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	expires_at TIMESTAMP NOT NULL,
+	organization_id VARCHAR(255) NOT NULL REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS sessions_user_idx ON sessions(user_id);
+
+-- refresh tokens issued before sessions belong to none, their users sign in again
+DELETE FROM refresh_tokens;
+
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS session_id VARCHAR(255) NOT NULL REFERENCES sessions(id) ON DELETE CASCADE;
@@ -0,0 +1,8 @@
+-- without the recipient the keys of one event for several users collide,
+-- the earliest notification is kept
+DELETE FROM notifications WHERE id NOT IN (
+	SELECT DISTINCT ON (substr(dedup_key, length(user_id) + 2)) id FROM notifications
+	ORDER BY substr(dedup_key, length(user_id) + 2), created_at
+);
+
+UPDATE notifications SET dedup_key = substr(dedup_key, length(user_id) + 2);
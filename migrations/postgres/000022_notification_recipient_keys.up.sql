@@ -0,0 +1,2 @@
+-- dedup keys name their recipient, so that a new assignee is notified too
+UPDATE notifications SET dedup_key = user_id || ':' || dedup_key;
@@ -0,0 +1,135 @@
+// Package ical writes calendars in the iCalendar format of RFC 5545.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const prodID = "-//project-management//calendar//EN"
+
+// Event spans whole days from Start through End.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+}
+
+// Todo is due at the end of the day Due.
+type Todo struct {
+	UID         string
+	Summary     string
+	Description string
+	Due         time.Time
+	Completed   bool
+	// Priority runs from 1, the highest, to 9; 0 leaves it undefined.
+	Priority int
+}
+
+type Calendar struct {
+	Name string
+	// Stamp is when the calendar was generated.
+	Stamp  time.Time
+	Events []Event
+	Todos  []Todo
+}
+
+func (c Calendar) Write(w io.Writer) error {
+	b := bufio.NewWriter(w)
+
+	line := func(name, value string) {
+		b.WriteString(fold(name + ":" + value))
+		b.WriteString("\r\n")
+	}
+
+	stamp := c.Stamp.UTC().Format("20060102T150405Z")
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", prodID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", stamp)
+		line("DTSTART;VALUE=DATE", date(e.Start))
+		// DTEND is exclusive
+		line("DTEND;VALUE=DATE", date(e.End.AddDate(0, 0, 1)))
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		line("END", "VEVENT")
+	}
+
+	for _, t := range c.Todos {
+		line("BEGIN", "VTODO")
+		line("UID", t.UID)
+		line("DTSTAMP", stamp)
+		line("DUE;VALUE=DATE", date(t.Due))
+		line("SUMMARY", escape(t.Summary))
+		if t.Description != "" {
+			line("DESCRIPTION", escape(t.Description))
+		}
+		if t.Priority > 0 {
+			line("PRIORITY", strconv.Itoa(t.Priority))
+		}
+		if t.Completed {
+			line("STATUS", "COMPLETED")
+		} else {
+			line("STATUS", "NEEDS-ACTION")
+		}
+		line("END", "VTODO")
+	}
+
+	line("END", "VCALENDAR")
+
+	return b.Flush()
+}
+
+func date(t time.Time) string {
+	return t.Format("20060102")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// fold breaks content lines longer than 75 octets, never inside a UTF-8
+// sequence; continuation lines start with a space.
+func fold(s string) string {
+	const limit = 75
+
+	if len(s) <= limit {
+		return s
+	}
+
+	var b strings.Builder
+	n := limit
+	for len(s) > n {
+		i := n
+		for i > 0 && s[i]&0xC0 == 0x80 {
+			i--
+		}
+		b.WriteString(s[:i])
+		b.WriteString("\r\n ")
+		s = s[i:]
+		// the leading space counts toward the next line
+		n = limit - 1
+	}
+	b.WriteString(s)
+
+	return b.String()
+}
@@ -0,0 +1,198 @@
+// Package oidc is a relying party of the OpenID Connect authorization code
+// flow with PKCE.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrToken  = errors.New("provider did not issue an ID token")
+	ErrVerify = errors.New("ID token failed verification")
+)
+
+// Client signs users in with one provider. Its endpoints are discovered from
+// the issuer on first use.
+type Client struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+
+	http *http.Client
+
+	mu       sync.Mutex
+	provider *provider
+}
+
+type provider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+
+	keys keySet
+}
+
+func New(issuer, clientID, clientSecret, redirectURL string, scopes []string) *Client {
+	if issuer == "" || clientID == "" || redirectURL == "" {
+		panic("issuer, client ID and redirect URL are required")
+	}
+
+	return &Client{
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		http:         &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewVerifier returns a random PKCE code verifier, also fit for a state or
+// a nonce.
+func NewVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Challenge is the S256 PKCE code challenge of verifier.
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL is where the user agent is sent to sign in. The provider
+// redirects back with the state and a code for Exchange.
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	p, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.clientID},
+		"redirect_uri":          {c.redirectURL},
+		"scope":                 {strings.Join(c.scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {Challenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	return p.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange trades the code for an ID token and returns its verified claims.
+func (c *Client) Exchange(ctx context.Context, code, verifier, nonce string) (Claims, error) {
+	p, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.redirectURL},
+		"client_id":     {c.clientID},
+		"code_verifier": {verifier},
+	}
+	if c.clientSecret != "" {
+		form.Set("client_secret", c.clientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body := struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode token response: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK || body.IDToken == "" {
+		if body.Error != "" {
+			return nil, fmt.Errorf("%w: %s %s", ErrToken, body.Error, body.ErrorDescription)
+		}
+		return nil, ErrToken
+	}
+
+	return c.verify(ctx, p, body.IDToken, nonce)
+}
+
+func (c *Client) discover(ctx context.Context) (*provider, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.provider != nil {
+		return c.provider, nil
+	}
+
+	p := &provider{}
+	if err := c.get(ctx, c.issuer+"/.well-known/openid-configuration", p); err != nil {
+		return nil, fmt.Errorf("discover provider: %w", err)
+	}
+
+	if strings.TrimSuffix(p.Issuer, "/") != c.issuer {
+		return nil, fmt.Errorf("discover provider: issuer %q does not match %q", p.Issuer, c.issuer)
+	}
+
+	c.provider = p
+
+	return p, nil
+}
+
+func (c *Client) get(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, res.Status)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
@@ -0,0 +1,166 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/pkg/oidc"
+	"github.com/canyouhearthemusic/project-management/pkg/oidc/oidctest"
+)
+
+const (
+	clientID    = "project-management"
+	redirectURL = "https://pm.example.com/api/v1/auth/oidc/callback"
+)
+
+// TestExchange runs the authorization code flow against a test provider and
+// checks which ID tokens the client accepts.
+func TestExchange(t *testing.T) {
+	forger, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		claims map[string]any
+		// verifier replaces the code verifier the sign-in started with.
+		verifier string
+		// nonce replaces the nonce the sign-in started with.
+		nonce string
+		forge bool
+		err   error
+	}{
+		{name: "verified"},
+		{name: "other verifier", verifier: "other", err: oidc.ErrToken},
+		{name: "other nonce", nonce: "other", err: oidc.ErrVerify},
+		{name: "other audience", claims: map[string]any{"aud": "other"}, err: oidc.ErrVerify},
+		{name: "other issuer", claims: map[string]any{"iss": "https://other.example.com"}, err: oidc.ErrVerify},
+		{name: "expired", claims: map[string]any{"exp": time.Now().Add(-time.Minute).Unix()}, err: oidc.ErrVerify},
+		{name: "no expiry", claims: map[string]any{"exp": nil}, err: oidc.ErrVerify},
+		{name: "forged", forge: true, err: oidc.ErrVerify},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			issuer := oidctest.NewIssuer(t, clientID)
+			if c.forge {
+				issuer.SigningKey = forger
+			}
+			client := oidc.New(issuer.URL, clientID, "secret", redirectURL, []string{"openid", "email"})
+
+			state, nonce, verifier := verifier(t), verifier(t), verifier(t)
+
+			authURL, err := client.AuthCodeURL(ctx, state, nonce, verifier)
+			if err != nil {
+				t.Fatalf("AuthCodeURL: %v", err)
+			}
+
+			claims := map[string]any{"sub": "42", "email": "ada@example.com"}
+			for k, v := range c.claims {
+				claims[k] = v
+			}
+
+			back, err := issuer.Authorize(authURL, claims)
+			if err != nil {
+				t.Fatalf("Authorize: %v", err)
+			}
+			if got := back.Query().Get("state"); got != state {
+				t.Fatalf("state = %q, want %q", got, state)
+			}
+
+			if c.verifier != "" {
+				verifier = c.verifier
+			}
+			if c.nonce != "" {
+				nonce = c.nonce
+			}
+
+			got, err := client.Exchange(ctx, back.Query().Get("code"), verifier, nonce)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("Exchange error = %v, want %v", err, c.err)
+			}
+			if c.err != nil {
+				return
+			}
+
+			if got.String("email") != "ada@example.com" || got.String("sub") != "42" {
+				t.Errorf("claims = %v", got)
+			}
+		})
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	issuer := oidctest.NewIssuer(t, clientID)
+	client := oidc.New(issuer.URL+"/", clientID, "", redirectURL, []string{"openid", "email", "groups"})
+
+	raw, err := client.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURL},
+		"scope":                 {"openid email groups"},
+		"state":                 {"state"},
+		"nonce":                 {"nonce"},
+		"code_challenge":        {oidc.Challenge("verifier")},
+		"code_challenge_method": {"S256"},
+	}
+	if got := u.Query(); got.Encode() != want.Encode() {
+		t.Errorf("query = %v, want %v", got, want)
+	}
+}
+
+// TestCodeOnce checks that the provider does not trade a code twice, so a
+// replayed callback cannot sign in again.
+func TestCodeOnce(t *testing.T) {
+	ctx := context.Background()
+
+	issuer := oidctest.NewIssuer(t, clientID)
+	client := oidc.New(issuer.URL, clientID, "", redirectURL, []string{"openid"})
+
+	authURL, err := client.AuthCodeURL(ctx, "state", "nonce", "verifier")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+
+	back, err := issuer.Authorize(authURL, map[string]any{"sub": "42"})
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+
+	code := back.Query().Get("code")
+	if _, err := client.Exchange(ctx, code, "verifier", "nonce"); err != nil {
+		t.Fatalf("first Exchange: %v", err)
+	}
+	if _, err := client.Exchange(ctx, code, "verifier", "nonce"); !errors.Is(err, oidc.ErrToken) {
+		t.Fatalf("second Exchange error = %v, want %v", err, oidc.ErrToken)
+	}
+}
+
+func verifier(t *testing.T) string {
+	t.Helper()
+
+	v, err := oidc.NewVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return v
+}
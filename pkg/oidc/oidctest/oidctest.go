@@ -0,0 +1,203 @@
+// Package oidctest is an OpenID Connect provider for tests of relying
+// parties. It serves discovery, its signing keys and a token endpoint that
+// checks the PKCE code verifier, and signs users in without a login page.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/canyouhearthemusic/project-management/pkg/oidc"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyID names the key the issuer publishes.
+const KeyID = "test-key"
+
+// Issuer is a provider for the one client ID, closed when the test ends.
+type Issuer struct {
+	*httptest.Server
+
+	ClientID string
+	// SigningKey signs the ID tokens. It is the published key unless a test
+	// replaces it to forge tokens.
+	SigningKey *rsa.PrivateKey
+
+	key *rsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]grant
+}
+
+// grant is an authorization code waiting to be traded for an ID token.
+type grant struct {
+	challenge   string
+	redirectURL string
+	claims      jwt.MapClaims
+}
+
+func NewIssuer(t testing.TB, clientID string) *Issuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := &Issuer{
+		ClientID:   clientID,
+		SigningKey: key,
+		key:        key,
+		grants:     map[string]grant{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", i.discovery)
+	mux.HandleFunc("GET /keys", i.keys)
+	mux.HandleFunc("POST /token", i.token)
+
+	i.Server = httptest.NewServer(mux)
+	t.Cleanup(i.Server.Close)
+
+	return i
+}
+
+// Authorize signs a user with the claims in at the authorization URL the
+// relying party sent the user agent to, and returns where the provider
+// redirects the user agent back to. The ID token carries the claims over
+// the issuer, audience, nonce and a lifetime of a minute, so tests can
+// override them.
+func (i *Issuer) Authorize(authURL string, claims map[string]any) (*url.URL, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	switch {
+	case u.Scheme+"://"+u.Host != i.URL || u.Path != "/authorize":
+		return nil, errors.New("not the authorization endpoint")
+	case q.Get("response_type") != "code":
+		return nil, errors.New("response type is not code")
+	case q.Get("client_id") != i.ClientID:
+		return nil, errors.New("unknown client")
+	case q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "":
+		return nil, errors.New("missing S256 code challenge")
+	case q.Get("state") == "":
+		return nil, errors.New("missing state")
+	}
+
+	now := time.Now()
+	data := jwt.MapClaims{
+		"iss":   i.URL,
+		"aud":   i.ClientID,
+		"nonce": q.Get("nonce"),
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Minute).Unix(),
+	}
+	for k, v := range claims {
+		data[k] = v
+	}
+
+	code, err := oidc.NewVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	i.mu.Lock()
+	i.grants[code] = grant{
+		challenge:   q.Get("code_challenge"),
+		redirectURL: q.Get("redirect_uri"),
+		claims:      data,
+	}
+	i.mu.Unlock()
+
+	back, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		return nil, err
+	}
+	back.RawQuery = url.Values{"state": {q.Get("state")}, "code": {code}}.Encode()
+
+	return back, nil
+}
+
+func (i *Issuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 i.URL,
+		"authorization_endpoint": i.URL + "/authorize",
+		"token_endpoint":         i.URL + "/token",
+		"jwks_uri":               i.URL + "/keys",
+	})
+}
+
+func (i *Issuer) keys(w http.ResponseWriter, r *http.Request) {
+	encode := func(b []byte) string {
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kid": KeyID,
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   encode(i.key.N.Bytes()),
+			"e":   encode(big.NewInt(int64(i.key.E)).Bytes()),
+		}},
+	})
+}
+
+// token trades a code once, for the client and redirect URL it was issued
+// to and the verifier of its challenge.
+func (i *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	i.mu.Lock()
+	g, ok := i.grants[r.PostForm.Get("code")]
+	delete(i.grants, r.PostForm.Get("code"))
+	i.mu.Unlock()
+
+	if !ok ||
+		r.PostForm.Get("grant_type") != "authorization_code" ||
+		r.PostForm.Get("client_id") != i.ClientID ||
+		r.PostForm.Get("redirect_uri") != g.redirectURL ||
+		oidc.Challenge(r.PostForm.Get("code_verifier")) != g.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, g.claims)
+	token.Header["kid"] = KeyID
+
+	idToken, err := token.SignedString(i.SigningKey)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
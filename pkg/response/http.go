@@ -23,6 +23,17 @@ func OK(w http.ResponseWriter, r *http.Request, data any) {
 	render.JSON(w, r, v)
 }
 
+// Warning reports a successful request that the client should be told about.
+func Warning(w http.ResponseWriter, r *http.Request, msg string) {
+	render.Status(r, http.StatusOK)
+
+	v := Response{
+		Success: true,
+		Message: msg,
+	}
+	render.JSON(w, r, v)
+}
+
 func Created(w http.ResponseWriter, r *http.Request, msg string, data any) {
 	render.Status(r, http.StatusCreated)
 
@@ -72,6 +83,36 @@ func NotFound(w http.ResponseWriter, r *http.Request, err error) {
 	render.JSON(w, r, v)
 }
 
+func Unauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusUnauthorized)
+
+	v := Response{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
+func Forbidden(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusForbidden)
+
+	v := Response{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
+func Conflict(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusConflict)
+
+	v := Response{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
 func InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	render.Status(r, http.StatusInternalServerError)
 
@@ -0,0 +1,86 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNoToken is handed to fail for requests without a bearer token when
+	// one is required.
+	ErrNoToken = errors.New("bearer token is required")
+	// ErrScope is handed to fail for requests the token lacks the scope of.
+	ErrScope = errors.New("token lacks the scope of the request")
+)
+
+// Authenticate passes the bearer token of the Authorization header to verify,
+// which returns the context carrying the user the token was issued to.
+// Requests with a token verify fails for are handed to fail, as are requests
+// without one when required is set; otherwise those go on anonymously.
+func Authenticate(
+	required bool,
+	verify func(ctx context.Context, token string) (context.Context, error),
+	fail func(w http.ResponseWriter, r *http.Request, err error),
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := bearer(r.Header.Get("Authorization"))
+			if token == "" {
+				if required {
+					fail(w, r, ErrNoToken)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, err := verify(r.Context(), token)
+			if err != nil {
+				fail(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func bearer(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
+
+// Scope requires requests to resource to be allowed the scope of their
+// method: "<resource>:read" for reading, "<resource>:admin" for deleting and
+// "<resource>:write" otherwise. Requests allow denies are handed to fail.
+func Scope(
+	resource string,
+	allow func(ctx context.Context, scope string) bool,
+	fail func(w http.ResponseWriter, r *http.Request, err error),
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			level := "write"
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				level = "read"
+			case http.MethodDelete:
+				level = "admin"
+			}
+
+			if !allow(r.Context(), resource+":"+level) {
+				fail(w, r, ErrScope)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
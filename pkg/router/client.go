@@ -0,0 +1,23 @@
+package router
+
+import (
+	"context"
+	"net"
+	"net/http"
+)
+
+// Client passes the user agent and IP address of requests to inject, which
+// stores them in the request context for the layers below. The address is
+// the one middleware.RealIP settled on.
+func Client(inject func(ctx context.Context, userAgent, ip string) context.Context) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				ip = r.RemoteAddr
+			}
+
+			next.ServeHTTP(w, r.WithContext(inject(r.Context(), r.UserAgent(), ip)))
+		})
+	}
+}
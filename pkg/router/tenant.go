@@ -0,0 +1,51 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// TenantHeader names the organization, by slug or ID, a request is made in.
+const TenantHeader = "X-Organization"
+
+// Tenant names the organization of a request by TenantHeader or, when
+// baseDomain is set, by the subdomain of the Host under baseDomain, e.g.
+// acme.example.com for baseDomain example.com. The key, empty when neither
+// is present, is passed to resolve together with the bearer token of the
+// request, whose signed organization takes precedence over the key; resolve
+// returns the context confined to that organization. Requests resolve fails
+// for are handed to fail.
+func Tenant(
+	baseDomain string,
+	resolve func(ctx context.Context, key, token string) (context.Context, error),
+	fail func(w http.ResponseWriter, r *http.Request, err error),
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(TenantHeader)
+			if key == "" && baseDomain != "" {
+				key = subdomain(r.Host, baseDomain)
+			}
+
+			ctx, err := resolve(r.Context(), key, bearer(r.Header.Get("Authorization")))
+			if err != nil {
+				fail(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func subdomain(host, baseDomain string) string {
+	host, _, _ = strings.Cut(host, ":")
+
+	sub, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(baseDomain))
+	if !ok {
+		return ""
+	}
+
+	return sub
+}
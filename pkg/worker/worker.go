@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Job is a unit of periodic work. Errors are passed to the worker's error
+// handler and do not stop the worker.
+type Job func(ctx context.Context) error
+
+type Worker struct {
+	interval time.Duration
+	job      Job
+	onError  func(error)
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type Configuration func(w *Worker) error
+
+func New(interval time.Duration, job Job, configs ...Configuration) (w *Worker, err error) {
+	if interval <= 0 {
+		return nil, errors.New("worker interval must be positive")
+	}
+
+	w = &Worker{
+		interval: interval,
+		job:      job,
+		onError:  func(error) {},
+	}
+
+	for _, cfg := range configs {
+		if err = cfg(w); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Start runs the job once immediately and then on every tick until Stop is called.
+func (w *Worker) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := w.job(ctx); err != nil && ctx.Err() == nil {
+				w.onError(err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop cancels the running job and waits for it to return or for ctx to expire.
+func (w *Worker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func WithErrorHandler(fn func(error)) Configuration {
+	return func(w *Worker) error {
+		w.onError = fn
+		return nil
+	}
+}